@@ -0,0 +1,19 @@
+action "bunnynet_pullzone_purge" "index" {
+  config {
+    pullzone = bunnynet_pullzone.example.id
+    paths    = ["/index.html", "/assets/*"]
+  }
+}
+
+resource "bunnynet_storage_file" "index" {
+  zone    = bunnynet_storage_zone.example.id
+  path    = "index.html"
+  content = "<h1>Hello world</h1>"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.bunnynet_pullzone_purge.index]
+    }
+  }
+}
@@ -0,0 +1,11 @@
+data "bunnynet_dns_zone" "example" {
+  domain = "example.org"
+}
+
+data "bunnynet_dns_record_health" "example" {
+  zone = data.bunnynet_dns_zone.example.id
+}
+
+output "offline_records" {
+  value = [for r in data.bunnynet_dns_record_health.example.data : r.name if r.monitor_status == "Offline"]
+}
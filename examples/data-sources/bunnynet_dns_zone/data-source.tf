@@ -8,3 +8,7 @@ output "nameservers" {
     data.bunnynet_dns_zone.example.nameserver2,
   ]
 }
+
+output "ds_record" {
+  value = data.bunnynet_dns_zone.example.dnssec_ds_record
+}
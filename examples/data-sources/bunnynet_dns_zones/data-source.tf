@@ -0,0 +1,8 @@
+data "bunnynet_dns_zones" "without_dnssec" {
+  domain_suffix  = "example.org"
+  dnssec_enabled = false
+}
+
+output "zones_without_dnssec" {
+  value = [for zone in data.bunnynet_dns_zones.without_dnssec.data : zone.domain]
+}
@@ -0,0 +1,7 @@
+data "bunnynet_pullzone" "by_name" {
+  name = "example"
+}
+
+data "bunnynet_pullzone" "by_hostname" {
+  hostname = "cdn.example.com"
+}
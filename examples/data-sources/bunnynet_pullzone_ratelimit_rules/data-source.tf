@@ -0,0 +1,7 @@
+data "bunnynet_pullzone_ratelimit_rules" "test" {
+  pullzone = bunnynet_pullzone.test.id
+}
+
+output "ratelimit_rule_ids" {
+  value = { for rule in data.bunnynet_pullzone_ratelimit_rules.test.rules : rule.name => rule.id }
+}
@@ -0,0 +1,13 @@
+data "bunnynet_pullzone_shield_events" "blocked" {
+  pullzone = bunnynet_pullzone.test.id
+  from     = timeadd(plantimestamp(), "-1h")
+  to       = plantimestamp()
+  actions  = ["block", "challenge"]
+}
+
+check "waf_rule_matches" {
+  assert {
+    condition     = lookup(data.bunnynet_pullzone_shield_events.blocked.rule_counts, bunnynet_pullzone_waf_rule.block_scanners.id, 0) < 1000
+    error_message = "The block_scanners WAF rule matched more than 1000 requests in the last hour."
+  }
+}
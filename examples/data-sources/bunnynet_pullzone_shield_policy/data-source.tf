@@ -0,0 +1,7 @@
+data "bunnynet_pullzone_shield_policy" "production" {
+  pullzone = bunnynet_pullzone.production.id
+}
+
+output "shield_policy" {
+  value = data.bunnynet_pullzone_shield_policy.production.document
+}
@@ -0,0 +1,14 @@
+data "bunnynet_pullzone_shield_waf_rules" "managed" {}
+
+resource "bunnynet_pullzone_shield" "test" {
+  // ...
+  pullzone = bunnynet_pullzone.test.id
+
+  waf {
+    // disable every scanner detection rule, except the User-Agent one
+    rules_disabled = [
+      for rule in data.bunnynet_pullzone_shield_waf_rules.managed.rules : rule.id
+      if rule.group == "REQUEST-913-SCANNER-DETECTION" && rule.id != "913100"
+    ]
+  }
+}
@@ -0,0 +1,8 @@
+data "bunnynet_pullzones" "example" {
+  hostname_suffix = "example.com"
+  tier            = "Standard"
+}
+
+output "pullzone_cdn_domains" {
+  value = { for pz in data.bunnynet_pullzones.example.data : pz.name => pz.cdn_domain }
+}
@@ -0,0 +1,7 @@
+data "bunnynet_shield_curated_access_lists" "curated" {
+  pullzone = bunnynet_pullzone.test.id
+}
+
+output "tor_last_updated" {
+  value = data.bunnynet_shield_curated_access_lists.curated.data["TOR Exit Nodes"].last_updated
+}
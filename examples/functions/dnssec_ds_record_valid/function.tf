@@ -0,0 +1,14 @@
+data "bunnynet_dns_zone" "example" {
+  domain = "example.org"
+}
+
+variable "registrar_ds_record" {
+  type = string
+}
+
+check "dnssec" {
+  assert {
+    condition     = provider::bunnynet::dnssec_ds_record_valid(var.registrar_ds_record, data.bunnynet_dns_zone.example.dnssec_dnskey_record)
+    error_message = "The DS record configured at the registrar does not match the DNS zone key."
+  }
+}
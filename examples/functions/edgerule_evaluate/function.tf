@@ -0,0 +1,34 @@
+resource "bunnynet_pullzone_edgerule" "block_admin" {
+  enabled     = true
+  pullzone    = bunnynet_pullzone.example.id
+  description = "Block admin area"
+  action      = "BlockRequest"
+  match_type  = "MatchAll"
+
+  triggers = [
+    {
+      type       = "Url"
+      match_type = "MatchAny"
+      patterns   = ["*/wp-admin/*"]
+      parameter1 = null
+      parameter2 = null
+    },
+    {
+      type       = "RemoteIP"
+      match_type = "MatchNone"
+      patterns   = ["10.0.0.0/8"]
+      parameter1 = null
+      parameter2 = null
+    },
+  ]
+}
+
+check "edgerules" {
+  assert {
+    condition = contains(provider::bunnynet::edgerule_evaluate([bunnynet_pullzone_edgerule.block_admin], {
+      url       = "https://example.com/wp-admin/index.php"
+      remote_ip = "203.0.113.10"
+    }).actions[*].type, "BlockRequest")
+    error_message = "Requests to the admin area should be blocked."
+  }
+}
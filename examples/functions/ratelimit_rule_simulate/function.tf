@@ -0,0 +1,27 @@
+resource "bunnynet_pullzone_ratelimit_rule" "login" {
+  pullzone = bunnynet_pullzone.example.id
+  name     = "Limit login attempts"
+
+  condition {
+    variable = "REQUEST_URI"
+    operator = "BEGINSWITH"
+    value    = "/login"
+  }
+
+  limit {
+    requests = 10
+    interval = 60
+  }
+
+  response {
+    interval = 300
+  }
+}
+
+# requests.csv:
+# timestamp,ip,path,method,user-agent
+# 2026-01-01T10:00:00Z,192.0.2.10,/login,POST,Mozilla/5.0
+# ...
+output "login_ratelimit" {
+  value = provider::bunnynet::ratelimit_rule_simulate(bunnynet_pullzone_ratelimit_rule.login, file("${path.module}/requests.csv"))
+}
@@ -0,0 +1,9 @@
+variable "stream_token_key" {
+  type      = string
+  sensitive = true
+}
+
+output "embed_url" {
+  value     = provider::bunnynet::sign_stream_embed(bunnynet_stream_library.example.id, bunnynet_stream_video.example.id, var.stream_token_key, 1767225600)
+  sensitive = true
+}
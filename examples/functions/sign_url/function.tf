@@ -0,0 +1,11 @@
+locals {
+  expires = parseint(formatdate("X", timeadd(plantimestamp(), "24h")), 10)
+}
+
+output "signed_url" {
+  value = provider::bunnynet::sign_url("https://${bunnynet_pullzone.example.cdn_domain}/videos/video.mp4", bunnynet_pullzone.example.token_auth_key, local.expires, {
+    token_path        = "/videos/"
+    countries_allowed = ["SI", "HR"]
+  })
+  sensitive = true
+}
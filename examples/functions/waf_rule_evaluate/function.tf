@@ -0,0 +1,34 @@
+resource "bunnynet_pullzone_waf_rule" "block_scanners" {
+  pullzone        = bunnynet_pullzone.example.id
+  name            = "Block scanners"
+  transformations = ["LOWERCASE"]
+
+  condition {
+    variable       = "REQUEST_HEADERS"
+    variable_value = "User-Agent"
+    operator       = "RX"
+    value          = "(sqlmap|nikto)"
+  }
+
+  response {
+    action = "Block"
+  }
+}
+
+check "waf_rules" {
+  assert {
+    condition = provider::bunnynet::waf_rule_evaluate(bunnynet_pullzone_waf_rule.block_scanners, {
+      url     = "https://example.com/"
+      headers = { "User-Agent" = "sqlmap/1.7" }
+    }).action == "Block"
+    error_message = "Scanners should be blocked."
+  }
+
+  assert {
+    condition = !provider::bunnynet::waf_rule_evaluate(bunnynet_pullzone_waf_rule.block_scanners, {
+      url     = "https://example.com/"
+      headers = { "User-Agent" = "Mozilla/5.0" }
+    }).matched
+    error_message = "Browsers should not be blocked."
+  }
+}
@@ -0,0 +1,21 @@
+resource "bunnynet_pullzone_waf_rule" "block_scanners" {
+  pullzone        = bunnynet_pullzone.example.id
+  name            = "Block scanners"
+  transformations = ["LOWERCASE"]
+
+  condition {
+    variable       = "REQUEST_HEADERS"
+    variable_value = "User-Agent"
+    operator       = "RX"
+    value          = "(sqlmap|nikto)"
+  }
+
+  response {
+    action = "Block"
+  }
+}
+
+# lowercase(request_headers["User-Agent"]) matches "(sqlmap|nikto)"
+output "block_scanners_expression" {
+  value = provider::bunnynet::waf_rule_expression(bunnynet_pullzone_waf_rule.block_scanners)
+}
@@ -0,0 +1,8 @@
+resource "bunnynet_dns_acme_challenge" "www" {
+  zone  = bunnynet_dns_zone.example.id
+  name  = "www"
+  value = "gfj9Xq...Rg85nM"
+
+  poll_interval = 10
+  timeout       = 600
+}
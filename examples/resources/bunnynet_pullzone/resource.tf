@@ -10,3 +10,14 @@ resource "bunnynet_pullzone" "example" {
     tier = "Standard"
   }
 }
+
+# uses an existing pullzone as template, only overriding the origin
+resource "bunnynet_pullzone" "customer" {
+  name      = "my-customer-website"
+  copy_from = bunnynet_pullzone.example.id
+
+  origin {
+    type = "OriginUrl"
+    url  = "https://192.0.2.2"
+  }
+}
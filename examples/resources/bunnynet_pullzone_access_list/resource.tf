@@ -8,3 +8,12 @@ resource "bunnynet_pullzone_access_list" "hetzner" {
     "2001:db8:cafe::/48"
   ]
 }
+
+resource "bunnynet_pullzone_access_list" "threat_feed" {
+  pullzone      = bunnynet_pullzone.test.id
+  name          = "Threat feed"
+  action        = "Block"
+  type          = "CIDR"
+  source_file   = "${path.module}/threat-feed.csv"
+  source_format = "csv_header"
+}
@@ -0,0 +1 @@
+terraform import bunnynet_pullzone_edgerule_order.example "$PULLZONE_ID"
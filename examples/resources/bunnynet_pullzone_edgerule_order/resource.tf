@@ -0,0 +1,9 @@
+resource "bunnynet_pullzone_edgerule_order" "example" {
+  pullzone = bunnynet_pullzone.example.id
+
+  edgerules = [
+    bunnynet_pullzone_edgerule.force_ssl.id,
+    bunnynet_pullzone_edgerule.redirect_admin.id,
+    bunnynet_pullzone_edgerule.cache_images.id,
+  ]
+}
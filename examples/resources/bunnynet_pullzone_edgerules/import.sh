@@ -0,0 +1 @@
+terraform import bunnynet_pullzone_edgerules.example "$PULLZONE_ID"
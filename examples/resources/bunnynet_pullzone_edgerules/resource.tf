@@ -0,0 +1,8 @@
+resource "bunnynet_pullzone_edgerules" "example" {
+  pullzone = bunnynet_pullzone.example.id
+
+  edgerules = [
+    bunnynet_pullzone_edgerule.force_ssl.id,
+    bunnynet_pullzone_edgerule.redirect_admin.id,
+  ]
+}
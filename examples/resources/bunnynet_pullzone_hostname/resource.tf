@@ -14,3 +14,18 @@ resource "bunnynet_pullzone_hostname" "custom" {
   certificate     = file("cdn.example.com.cert")
   certificate_key = file("cdn.example.com.key")
 }
+
+resource "bunnynet_pullzone_hostname" "managed" {
+  pullzone    = bunnynet_pullzone.example.id
+  name        = "static.example.com"
+  tls_enabled = true
+  force_ssl   = true
+
+  # Requests a managed certificate and waits until it is issued, retrying while the DNS record propagates.
+  managed_certificate {
+    timeout       = 900
+    poll_interval = 30
+  }
+
+  depends_on = [bunnynet_dns_record.static]
+}
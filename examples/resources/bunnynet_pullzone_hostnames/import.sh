@@ -0,0 +1 @@
+terraform import bunnynet_pullzone_hostnames.example "$PULLZONE_ID"
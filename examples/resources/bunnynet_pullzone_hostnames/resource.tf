@@ -0,0 +1,8 @@
+resource "bunnynet_pullzone_hostnames" "example" {
+  pullzone = bunnynet_pullzone.example.id
+
+  hostnames = [
+    bunnynet_pullzone_hostname.cdn.name,
+    bunnynet_pullzone_hostname.assets.name,
+  ]
+}
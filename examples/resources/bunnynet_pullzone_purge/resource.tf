@@ -0,0 +1,18 @@
+# purge the files whenever their content changes
+resource "bunnynet_pullzone_purge" "assets" {
+  pullzone = bunnynet_pullzone.example.id
+  paths    = [for file in bunnynet_storage_file.assets : file.path]
+
+  triggers = {
+    for file in bunnynet_storage_file.assets : file.path => file.checksum
+  }
+}
+
+# purge the whole pullzone whenever the origin changes
+resource "bunnynet_pullzone_purge" "origin" {
+  pullzone = bunnynet_pullzone.example.id
+
+  triggers = {
+    origin = bunnynet_pullzone.example.origin.url
+  }
+}
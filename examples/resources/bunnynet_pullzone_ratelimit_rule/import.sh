@@ -1 +1,7 @@
 terraform import bunnynet_pullzone_ratelimit_rule.test "$PULLZONE_ID|$RULE_ID"
+
+# or by rule name
+terraform import bunnynet_pullzone_ratelimit_rule.test "$PULLZONE_ID|$RULE_NAME"
+
+# prefix the rule with "id:" or "name:" when a rule name is all digits
+terraform import bunnynet_pullzone_ratelimit_rule.test "$PULLZONE_ID|name:$RULE_NAME"
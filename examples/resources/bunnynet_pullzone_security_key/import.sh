@@ -0,0 +1 @@
+terraform import bunnynet_pullzone_security_key.example "$PULLZONE_ID"
@@ -0,0 +1,10 @@
+resource "bunnynet_pullzone_security_key" "example" {
+  pullzone     = bunnynet_pullzone.example.id
+  rotate_after = "720h"
+  grace_period = "48h"
+}
+
+output "signing_keys" {
+  value     = compact([bunnynet_pullzone_security_key.example.key, bunnynet_pullzone_security_key.example.previous_key])
+  sensitive = true
+}
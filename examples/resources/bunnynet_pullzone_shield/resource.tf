@@ -1,14 +1,25 @@
 resource "bunnynet_pullzone_shield" "test" {
   pullzone = bunnynet_pullzone.test.id
   tier     = "Standard"
+  preset   = "ecommerce"
 
   ddos {
-    level = "Medium"
-    mode  = "Block"
+    mode = "Block"
   }
 
   waf {
     enabled = true
     mode    = "Block"
   }
+
+  # changes to ddos, waf and bot_detection run in log-only mode for a week before blocking
+  staging {
+    period = 604800
+  }
+
+  access_list {
+    name     = "TOR Exit Nodes"
+    action   = "Challenge"
+    priority = 1
+  }
 }
@@ -0,0 +1 @@
+terraform import bunnynet_pullzone_shield_policy.example "$PULLZONE_ID"
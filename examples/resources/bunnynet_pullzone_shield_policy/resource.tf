@@ -0,0 +1,20 @@
+# copies the shield configuration of the production pullzone to staging
+data "bunnynet_pullzone_shield_policy" "production" {
+  pullzone = bunnynet_pullzone.production.id
+}
+
+resource "bunnynet_pullzone_shield_policy" "staging" {
+  pullzone = bunnynet_pullzone.staging.id
+  document = data.bunnynet_pullzone_shield_policy.production.document
+
+  # staging rules and access lists not found in production are deleted
+  prune = true
+
+  depends_on = [bunnynet_pullzone_shield.staging]
+}
+
+# applies a policy kept in the repository
+resource "bunnynet_pullzone_shield_policy" "example" {
+  pullzone = bunnynet_pullzone.example.id
+  document = file("${path.module}/shield-policy.json")
+}
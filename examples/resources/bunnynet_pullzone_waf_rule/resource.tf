@@ -14,3 +14,13 @@ resource "bunnynet_pullzone_waf_rule" "test" {
     action = "Challenge"
   }
 }
+
+resource "bunnynet_pullzone_waf_rule" "block_scanners" {
+  pullzone   = bunnynet_pullzone.test.id
+  name       = "Block scanners"
+  expression = "lowercase(request_headers[\"User-Agent\"]) matches \"(sqlmap|nikto)\" and lowercase(request_method) in [\"get\", \"post\"]"
+
+  response {
+    action = "Block"
+  }
+}
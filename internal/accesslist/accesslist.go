@@ -0,0 +1,312 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package accesslist parses and normalizes the entries of shield access lists.
+package accesslist
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Type uint8
+
+// The values match the access list types in the shield API.
+const (
+	TypeIp           Type = 0
+	TypeCidr         Type = 1
+	TypeAsn          Type = 2
+	TypeCountry      Type = 3
+	TypeOrganization Type = 4
+	TypeJa4          Type = 5
+)
+
+type Format string
+
+const (
+	// FormatLines has one entry per line.
+	FormatLines Format = "lines"
+	// FormatCsv uses the first column of each record.
+	FormatCsv Format = "csv"
+	// FormatCsvHeader uses the first column of each record, skipping the first record.
+	FormatCsvHeader Format = "csv_header"
+)
+
+var ja4Regex = regexp.MustCompile(`^[tqd][0-9a-z]{2}[di][0-9]{4}[0-9a-z]{2}_[0-9a-f]{12}_[0-9a-f]{12}$`)
+
+type entry struct {
+	value string
+	line  int
+}
+
+// Load parses the content of a file, strips comments and normalizes the entries.
+// Comments start with "#", ";" or "//" at the beginning of the line or after whitespace, and go until the end of the line.
+// Organization names may contain these characters, so organization lists have no comments.
+func Load(content string, format Format, listType Type) ([]string, error) {
+	var entries []entry
+	var err error
+
+	switch format {
+	case FormatLines:
+		entries = parseLines(content, listType)
+	case FormatCsv, FormatCsvHeader:
+		entries, err = parseCsv(content, listType, format == FormatCsvHeader)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format \"%s\"", format)
+	}
+
+	values := make([]string, 0, len(entries))
+	for _, e := range entries {
+		value, err := normalizeEntry(listType, e.value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", e.line, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return normalized(listType, values), nil
+}
+
+// Normalize validates the entries and returns them in canonical form, sorted and without duplicates.
+// CIDR entries are collapsed into the smallest set of prefixes covering the same addresses.
+func Normalize(listType Type, entries []string) ([]string, error) {
+	values := make([]string, 0, len(entries))
+	for _, e := range entries {
+		value, err := normalizeEntry(listType, strings.TrimSpace(e))
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return normalized(listType, values), nil
+}
+
+// Hash returns the SHA-256 of the entries, which must already be normalized.
+func Hash(entries []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func stripComment(line string, listType Type) string {
+	if listType == TypeOrganization {
+		return strings.TrimSpace(line)
+	}
+
+	for i := 0; i < len(line); i++ {
+		if i > 0 && line[i-1] != ' ' && line[i-1] != '\t' {
+			continue
+		}
+
+		if line[i] == '#' || line[i] == ';' || strings.HasPrefix(line[i:], "//") {
+			line = line[:i]
+			break
+		}
+	}
+
+	return strings.TrimSpace(line)
+}
+
+func parseLines(content string, listType Type) []entry {
+	var entries []entry
+
+	for i, line := range strings.Split(content, "\n") {
+		value := stripComment(line, listType)
+		if value == "" {
+			continue
+		}
+
+		entries = append(entries, entry{value: value, line: i + 1})
+	}
+
+	return entries
+}
+
+func parseCsv(content string, listType Type, header bool) ([]entry, error) {
+	var entries []entry
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header {
+			header = false
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		value := stripComment(record[0], listType)
+		if value == "" {
+			continue
+		}
+
+		entries = append(entries, entry{value: value, line: line})
+	}
+
+	return entries, nil
+}
+
+func normalizeEntry(listType Type, value string) (string, error) {
+	switch listType {
+	case TypeIp:
+		addr, err := netip.ParseAddr(value)
+		if err != nil || addr.Zone() != "" {
+			return "", fmt.Errorf("\"%s\" is not a valid IP address", value)
+		}
+
+		return addr.Unmap().String(), nil
+
+	case TypeCidr:
+		prefix, err := parsePrefix(value)
+		if err != nil {
+			return "", fmt.Errorf("\"%s\" is not a valid CIDR", value)
+		}
+
+		return prefix.String(), nil
+
+	case TypeAsn:
+		number := value
+		if len(number) > 2 && strings.EqualFold(number[:2], "AS") {
+			number = number[2:]
+		}
+
+		asn, err := strconv.ParseUint(number, 10, 32)
+		if err != nil || asn == 0 {
+			return "", fmt.Errorf("\"%s\" is not a valid ASN", value)
+		}
+
+		return strconv.FormatUint(asn, 10), nil
+
+	case TypeCountry:
+		code := strings.ToUpper(value)
+		if _, ok := countries[code]; !ok {
+			return "", fmt.Errorf("\"%s\" is not an ISO 3166-1 alpha-2 country code", value)
+		}
+
+		return code, nil
+
+	case TypeOrganization:
+		name := strings.Join(strings.Fields(value), " ")
+		if name == "" {
+			return "", fmt.Errorf("organization must not be empty")
+		}
+
+		return name, nil
+
+	case TypeJa4:
+		fingerprint := strings.ToLower(value)
+		if !ja4Regex.MatchString(fingerprint) {
+			return "", fmt.Errorf("\"%s\" is not a valid JA4 fingerprint", value)
+		}
+
+		return fingerprint, nil
+	}
+
+	return "", fmt.Errorf("unsupported access list type %d", listType)
+}
+
+// parsePrefix accepts a CIDR or a single address, and clears the host bits.
+func parsePrefix(value string) (netip.Prefix, error) {
+	if !strings.Contains(value, "/") {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || addr.Zone() != "" {
+			return netip.Prefix{}, errors.New("invalid address")
+		}
+
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return prefix, err
+	}
+
+	if prefix.Addr().Is4In6() {
+		bits := prefix.Bits() - 96
+		if bits < 0 {
+			return prefix, errors.New("invalid prefix")
+		}
+
+		prefix = netip.PrefixFrom(prefix.Addr().Unmap(), bits)
+	}
+
+	return prefix.Masked(), nil
+}
+
+func normalized(listType Type, values []string) []string {
+	switch listType {
+	case TypeIp:
+		addrs := make([]netip.Addr, 0, len(values))
+		for _, value := range values {
+			addrs = append(addrs, netip.MustParseAddr(value))
+		}
+
+		sort.Slice(addrs, func(i, j int) bool {
+			return addrs[i].Less(addrs[j])
+		})
+
+		result := make([]string, 0, len(addrs))
+		for i, addr := range addrs {
+			if i > 0 && addr == addrs[i-1] {
+				continue
+			}
+
+			result = append(result, addr.String())
+		}
+
+		return result
+
+	case TypeCidr:
+		prefixes := make([]netip.Prefix, 0, len(values))
+		for _, value := range values {
+			prefixes = append(prefixes, netip.MustParsePrefix(value))
+		}
+
+		collapsed := CollapsePrefixes(prefixes)
+		result := make([]string, 0, len(collapsed))
+		for _, prefix := range collapsed {
+			result = append(result, prefix.String())
+		}
+
+		return result
+	}
+
+	sort.Strings(values)
+
+	result := make([]string, 0, len(values))
+	for i, value := range values {
+		if i > 0 && value == values[i-1] {
+			continue
+		}
+
+		result = append(result, value)
+	}
+
+	return result
+}
@@ -0,0 +1,136 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package accesslist
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	type testCase struct {
+		Content  string
+		Format   Format
+		Type     Type
+		Expected []string
+	}
+
+	testCases := []testCase{
+		{
+			Content:  "# feed\n192.0.2.1\n\n192.0.2.1 ; duplicate\n::ffff:198.51.100.7\n2001:DB8::0001 // comment\n",
+			Format:   FormatLines,
+			Type:     TypeIp,
+			Expected: []string{"192.0.2.1", "198.51.100.7", "2001:db8::1"},
+		},
+		{
+			Content:  "network,reason\n192.0.2.0/25,scanner\n192.0.2.128/25,scanner\n192.0.2.10/32,spam\n\"198.51.100.0/24\",botnet\n",
+			Format:   FormatCsvHeader,
+			Type:     TypeCidr,
+			Expected: []string{"192.0.2.0/24", "198.51.100.0/24"},
+		},
+		{
+			Content:  "192.0.2.0/24,scanner\n198.51.100.0/24,botnet\n",
+			Format:   FormatCsv,
+			Type:     TypeCidr,
+			Expected: []string{"192.0.2.0/24", "198.51.100.0/24"},
+		},
+		{
+			Content:  "192.0.2.0/24\n",
+			Format:   FormatCsvHeader,
+			Type:     TypeCidr,
+			Expected: []string{},
+		},
+		{
+			Content:  "AS13335\nas15169\n13335\n",
+			Format:   FormatLines,
+			Type:     TypeAsn,
+			Expected: []string{"13335", "15169"},
+		},
+		{
+			Content:  "si\nDE\nSI\n",
+			Format:   FormatLines,
+			Type:     TypeCountry,
+			Expected: []string{"DE", "SI"},
+		},
+		{
+			Content:  "T13D1516H2_8DAAF6152771_02713D6AF862\n",
+			Format:   FormatLines,
+			Type:     TypeJa4,
+			Expected: []string{"t13d1516h2_8daaf6152771_02713d6af862"},
+		},
+		{
+			Content:  "",
+			Format:   FormatLines,
+			Type:     TypeOrganization,
+			Expected: []string{},
+		},
+		{
+			Content:  "Example #1 Hosting\nExample; Inc. // EU\n",
+			Format:   FormatLines,
+			Type:     TypeOrganization,
+			Expected: []string{"Example #1 Hosting", "Example; Inc. // EU"},
+		},
+	}
+
+	for _, data := range testCases {
+		result, err := Load(data.Content, data.Format, data.Type)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", data.Content, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, data.Expected) {
+			t.Errorf("%q: expected %v, got %v", data.Content, data.Expected, result)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	type testCase struct {
+		Content string
+		Format  Format
+		Type    Type
+		Error   string
+	}
+
+	testCases := []testCase{
+		{Content: "192.0.2.1\n192.0.2.300\n", Format: FormatLines, Type: TypeIp, Error: "line 2: \"192.0.2.300\" is not a valid IP address"},
+		{Content: "fe80::1%eth0\n", Format: FormatLines, Type: TypeIp, Error: "line 1: \"fe80::1%eth0\" is not a valid IP address"},
+		{Content: "network\n192.0.2.0/24\n192.0.2.0/33\n", Format: FormatCsvHeader, Type: TypeCidr, Error: "line 3: \"192.0.2.0/33\" is not a valid CIDR"},
+		{Content: "network,reason\n192.0.2.0/24,scanner\n", Format: FormatCsv, Type: TypeCidr, Error: "line 1: \"network\" is not a valid CIDR"},
+		{Content: "192.0.2.1#feed\n", Format: FormatLines, Type: TypeIp, Error: "line 1: \"192.0.2.1#feed\" is not a valid IP address"},
+		{Content: "AS0\n", Format: FormatLines, Type: TypeAsn, Error: "line 1: \"AS0\" is not a valid ASN"},
+		{Content: "XX\n", Format: FormatLines, Type: TypeCountry, Error: "line 1: \"XX\" is not an ISO 3166-1 alpha-2 country code"},
+		{Content: "t13d1516h2_8daaf6152771\n", Format: FormatLines, Type: TypeJa4, Error: "line 1: \"t13d1516h2_8daaf6152771\" is not a valid JA4 fingerprint"},
+		{Content: "192.0.2.1\n", Format: "json", Type: TypeIp, Error: "unsupported format \"json\""},
+	}
+
+	for _, data := range testCases {
+		_, err := Load(data.Content, data.Format, data.Type)
+		if err == nil || err.Error() != data.Error {
+			t.Errorf("%q: expected error %q, got %v", data.Content, data.Error, err)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	result, err := Normalize(TypeCidr, []string{" 192.0.2.7/24", "::ffff:192.0.2.0/120", "2001:db8::/33", "2001:db8:8000::/33"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"192.0.2.0/24", "2001:db8::/32"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	if Hash(result) != Hash([]string{"192.0.2.0/24", "2001:db8::/32"}) || Hash(result) == Hash(expected[:1]) {
+		t.Errorf("unexpected hash")
+	}
+
+	if _, err := Normalize(TypeCountry, []string{"DE", "Germany"}); err == nil || !strings.Contains(err.Error(), "Germany") {
+		t.Errorf("expected an error, got %v", err)
+	}
+}
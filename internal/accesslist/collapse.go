@@ -0,0 +1,90 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package accesslist
+
+import (
+	"net/netip"
+	"sort"
+)
+
+type addrRange struct {
+	first netip.Addr
+	last  netip.Addr
+}
+
+// CollapsePrefixes merges overlapping and adjacent prefixes, returning the smallest set of prefixes covering the same addresses.
+func CollapsePrefixes(prefixes []netip.Prefix) []netip.Prefix {
+	ranges := make([]addrRange, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		prefix = prefix.Masked()
+		ranges = append(ranges, addrRange{first: prefix.Addr(), last: lastAddr(prefix)})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].first.Less(ranges[j].first)
+	})
+
+	merged := make([]addrRange, 0, len(ranges))
+	for _, r := range ranges {
+		if len(merged) > 0 {
+			current := &merged[len(merged)-1]
+			next := current.last.Next()
+
+			// IPv4 and IPv6 ranges are never merged
+			if current.first.BitLen() == r.first.BitLen() && (!next.IsValid() || r.first.Compare(next) <= 0) {
+				if r.last.Compare(current.last) > 0 {
+					current.last = r.last
+				}
+
+				continue
+			}
+		}
+
+		merged = append(merged, r)
+	}
+
+	result := make([]netip.Prefix, 0, len(merged))
+	for _, r := range merged {
+		result = append(result, rangeToPrefixes(r)...)
+	}
+
+	return result
+}
+
+// lastAddr returns the last address of the prefix, with all host bits set.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+func rangeToPrefixes(r addrRange) []netip.Prefix {
+	var result []netip.Prefix
+
+	start := r.first
+	for {
+		// the largest prefix starting at start that does not go beyond the range
+		var prefix netip.Prefix
+		for bits := 0; bits <= start.BitLen(); bits++ {
+			candidate := netip.PrefixFrom(start, bits)
+			if candidate.Masked().Addr() == start && lastAddr(candidate).Compare(r.last) <= 0 {
+				prefix = candidate
+				break
+			}
+		}
+
+		result = append(result, prefix)
+
+		last := lastAddr(prefix)
+		if last == r.last {
+			return result
+		}
+
+		start = last.Next()
+	}
+}
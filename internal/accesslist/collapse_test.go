@@ -0,0 +1,64 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package accesslist
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestCollapsePrefixes(t *testing.T) {
+	type testCase struct {
+		Prefixes []string
+		Expected []string
+	}
+
+	testCases := []testCase{
+		{
+			Prefixes: []string{"10.0.0.0/24", "10.0.1.0/24"},
+			Expected: []string{"10.0.0.0/23"},
+		},
+		{
+			Prefixes: []string{"10.0.0.0/8", "10.1.2.0/24", "10.255.255.255/32"},
+			Expected: []string{"10.0.0.0/8"},
+		},
+		{
+			Prefixes: []string{"10.0.1.0/24", "10.0.2.0/24"},
+			Expected: []string{"10.0.1.0/24", "10.0.2.0/24"},
+		},
+		{
+			Prefixes: []string{"10.0.0.1/32", "10.0.0.2/32", "10.0.0.3/32", "10.0.0.4/32"},
+			Expected: []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/32"},
+		},
+		{
+			Prefixes: []string{"255.255.255.255/32", "255.255.255.254/32", "0.0.0.0/32"},
+			Expected: []string{"0.0.0.0/32", "255.255.255.254/31"},
+		},
+		{
+			Prefixes: []string{"2001:db8::/48", "2001:db8:1::/48", "192.0.2.0/24"},
+			Expected: []string{"192.0.2.0/24", "2001:db8::/47"},
+		},
+		{
+			Prefixes: []string{"0.0.0.0/1", "128.0.0.0/1", "::/0"},
+			Expected: []string{"0.0.0.0/0", "::/0"},
+		},
+	}
+
+	for _, data := range testCases {
+		prefixes := make([]netip.Prefix, 0, len(data.Prefixes))
+		for _, p := range data.Prefixes {
+			prefixes = append(prefixes, netip.MustParsePrefix(p))
+		}
+
+		result := []string{}
+		for _, p := range CollapsePrefixes(prefixes) {
+			result = append(result, p.String())
+		}
+
+		if !reflect.DeepEqual(result, data.Expected) {
+			t.Errorf("%v: expected %v, got %v", data.Prefixes, data.Expected, result)
+		}
+	}
+}
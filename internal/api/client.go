@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 )
 
 var httpClient = &http.Client{
@@ -24,6 +25,10 @@ type Client struct {
 	apiUrl       string
 	streamApiUrl string
 	userAgent    string
+
+	// the managed WAF rules catalogue is the same for every shield zone, so it is fetched once per client
+	wafManagedRulesMutex sync.Mutex
+	wafManagedRules      []PullzoneShieldWafManagedRuleGroup
 }
 
 func (c *Client) doRequest(method string, url string, body io.Reader) (*http.Response, error) {
@@ -17,6 +17,7 @@ import (
 
 const DnsRecordTypeA = 0
 const DnsRecordTypeAAAA = 1
+const DnsRecordTypeTXT = 3
 const DnsRecordTypePZ = 7
 const DnsRecordTypeSRV = 8
 
@@ -37,6 +38,7 @@ type DnsRecord struct {
 	AcceleratedPullZoneId int64   `json:"AcceleratedPullZoneId"`
 	LinkName              string  `json:"LinkName,omitempty"`
 	MonitorType           uint8   `json:"MonitorType"`
+	MonitorStatus         uint8   `json:"-"`
 	GeolocationLatitude   float64 `json:"GeolocationLatitude"`
 	GeolocationLongitude  float64 `json:"GeolocationLongitude"`
 	LatencyZone           string  `json:"LatencyZone"`
@@ -46,6 +48,25 @@ type DnsRecord struct {
 	ScriptId              int64   `json:"ScriptId,omitempty"`
 }
 
+// UnmarshalJSON decodes the read-only fields, which are not sent when creating or updating a record.
+func (r *DnsRecord) UnmarshalJSON(data []byte) error {
+	type dnsRecord DnsRecord
+	var result struct {
+		dnsRecord
+		MonitorStatus uint8 `json:"MonitorStatus"`
+	}
+
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return err
+	}
+
+	*r = DnsRecord(result.dnsRecord)
+	r.MonitorStatus = result.MonitorStatus
+
+	return nil
+}
+
 func (c *Client) GetDnsRecord(ctx context.Context, zoneId int64, id int64) (DnsRecord, error) {
 	zone, err := c.GetDnsZone(ctx, zoneId)
 	if err != nil {
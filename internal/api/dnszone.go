@@ -33,6 +33,8 @@ type DnsZone struct {
 	DnssecPublicKey               string      `json:"-"`
 	DnssecKeyTag                  uint16      `json:"-"`
 	DnssecFlags                   uint16      `json:"-"`
+	DnssecDsRecord                string      `json:"-"`
+	DnssecDsConfigured            bool        `json:"-"`
 }
 
 type dnssecInfo struct {
@@ -87,38 +89,58 @@ func (c *Client) GetDnsZone(ctx context.Context, id int64) (DnsZone, error) {
 	return data, nil
 }
 
-func (c *Client) GetDnsZoneByDomain(ctx context.Context, domain string) (DnsZone, error) {
-	var data DnsZone
-	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/dnszone", c.apiUrl), nil)
-	if err != nil {
-		return data, err
-	}
+func (c *Client) GetDnsZones(ctx context.Context) ([]DnsZone, error) {
+	var zones []DnsZone
 
-	if resp.StatusCode != http.StatusOK {
-		return data, errors.New(resp.Status)
-	}
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/dnszone?page=%d&perPage=1000", c.apiUrl, page), nil)
+		if err != nil {
+			return nil, err
+		}
 
-	bodyResp, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return data, err
-	}
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.New(resp.Status)
+		}
 
-	_ = resp.Body.Close()
-	var result struct {
-		Items []DnsZone `json:"Items"`
+		bodyResp, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = resp.Body.Close()
+		var result struct {
+			Items        []DnsZone `json:"Items"`
+			HasMoreItems bool      `json:"HasMoreItems"`
+		}
+
+		err = json.Unmarshal(bodyResp, &result)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("GET /dnszone?page=%d: %d items", page, len(result.Items)))
+		zones = append(zones, result.Items...)
+
+		if !result.HasMoreItems || len(result.Items) == 0 {
+			break
+		}
 	}
 
-	err = json.Unmarshal(bodyResp, &result)
+	return zones, nil
+}
+
+func (c *Client) GetDnsZoneByDomain(ctx context.Context, domain string) (DnsZone, error) {
+	zones, err := c.GetDnsZones(ctx)
 	if err != nil {
-		return data, err
+		return DnsZone{}, err
 	}
 
-	for _, record := range result.Items {
+	for _, record := range zones {
 		if record.Domain == domain {
 			if record.DnssecEnabled {
 				info, err := c.postDnssec(ctx, record.Id)
 				if err != nil {
-					return data, err
+					return DnsZone{}, err
 				}
 
 				hydrateDnsZoneWithDnssec(&record, &info)
@@ -128,7 +150,7 @@ func (c *Client) GetDnsZoneByDomain(ctx context.Context, domain string) (DnsZone
 		}
 	}
 
-	return data, fmt.Errorf("DNS zone \"%s\" not found", domain)
+	return DnsZone{}, fmt.Errorf("DNS zone \"%s\" not found", domain)
 }
 
 func (c *Client) CreateDnsZone(ctx context.Context, data DnsZone) (DnsZone, error) {
@@ -315,6 +337,8 @@ func hydrateDnsZoneWithDnssec(data *DnsZone, info *dnssecInfo) {
 	data.DnssecPublicKey = info.PublicKey
 	data.DnssecKeyTag = uint16(info.KeyTag)
 	data.DnssecFlags = uint16(info.Flags)
+	data.DnssecDsRecord = info.DsRecord
+	data.DnssecDsConfigured = info.DsConfigured
 
 	switch info.DigestType {
 	case "SHA256 (2)":
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"io"
 	"net/http"
+	"strings"
 )
 
 const PullzoneOriginTypeOriginUrl = 0
@@ -241,6 +242,64 @@ func (c *Client) GetPullzoneByName(ctx context.Context, name string) (Pullzone,
 	return data, errors.New("Pullzone not found")
 }
 
+func (c *Client) GetPullzones(ctx context.Context) ([]Pullzone, error) {
+	var pullzones []Pullzone
+
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/pullzone?page=%d&perPage=1000", c.apiUrl, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.New(resp.Status)
+		}
+
+		bodyResp, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = resp.Body.Close()
+		var result struct {
+			Items        []Pullzone `json:"Items"`
+			HasMoreItems bool       `json:"HasMoreItems"`
+		}
+
+		err = json.Unmarshal(bodyResp, &result)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("GET /pullzone?page=%d: %d items", page, len(result.Items)))
+		pullzones = append(pullzones, result.Items...)
+
+		if !result.HasMoreItems || len(result.Items) == 0 {
+			break
+		}
+	}
+
+	return pullzones, nil
+}
+
+func (c *Client) GetPullzoneByHostname(ctx context.Context, hostname string) (Pullzone, error) {
+	pullzones, err := c.GetPullzones(ctx)
+	if err != nil {
+		return Pullzone{}, err
+	}
+
+	hostname = strings.TrimSuffix(hostname, ".")
+	for _, pullzone := range pullzones {
+		for _, h := range pullzone.Hostnames {
+			if strings.EqualFold(h.Name, hostname) {
+				return c.GetPullzone(pullzone.Id)
+			}
+		}
+	}
+
+	return Pullzone{}, errors.New("Pullzone not found")
+}
+
 func (c *Client) CreatePullzone(data Pullzone) (Pullzone, error) {
 	switch data.OriginType {
 	case PullzoneOriginTypeComputeScript:
@@ -324,6 +383,53 @@ func (c *Client) UpdatePullzoneWithBody(id int64, body []byte) (Pullzone, error)
 	return dataApiResult, nil
 }
 
+func (c *Client) UpdatePullzoneSecurityKey(id int64, key string) (Pullzone, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"ZoneSecurityKey": key,
+	})
+
+	if err != nil {
+		return Pullzone{}, err
+	}
+
+	return c.UpdatePullzoneWithBody(id, body)
+}
+
+// CopyPullzoneRules copies the edge rules and optimizer classes from the template pullzone into another pullzone.
+func (c *Client) CopyPullzoneRules(ctx context.Context, templateId int64, pullzoneId int64) (Pullzone, error) {
+	template, err := c.GetPullzone(templateId)
+	if err != nil {
+		return Pullzone{}, err
+	}
+
+	for _, edgerule := range sortPullzoneEdgerules(template) {
+		edgerule.Id = ""
+		edgerule.OrderIndex = 0
+		edgerule.PullzoneId = pullzoneId
+
+		_, err := c.CreatePullzoneEdgerule(ctx, edgerule)
+		if err != nil {
+			return Pullzone{}, fmt.Errorf("copying edgerule \"%s\": %w", edgerule.Description, err)
+		}
+	}
+
+	if len(template.OptimizerClasses) == 0 {
+		return c.GetPullzone(pullzoneId)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"OptimizerClasses": template.OptimizerClasses,
+	})
+
+	if err != nil {
+		return Pullzone{}, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("POST /pullzone/%d: %s", pullzoneId, string(body)))
+
+	return c.UpdatePullzoneWithBody(pullzoneId, body)
+}
+
 func (c *Client) DeletePullzone(id int64) error {
 	resp, err := c.doRequest(http.MethodDelete, fmt.Sprintf("%s/pullzone/%d", c.apiUrl, id), nil)
 	if err != nil {
@@ -150,7 +150,7 @@ func (c *Client) CreatePullzoneAccessList(ctx context.Context, data PullzoneAcce
 
 	// save action
 	{
-		err := c.updatePullzoneAccessListConfiguration(ctx, shieldZoneId, httpResult.Data.Id, data.IsEnabled, data.Action)
+		err := c.updatePullzoneAccessListConfiguration(ctx, shieldZoneId, httpResult.Data.Id, data.IsEnabled, data.Action, 0)
 		if err != nil {
 			return result, err
 		}
@@ -203,7 +203,7 @@ func (c *Client) UpdatePullzoneAccessList(ctx context.Context, data PullzoneAcce
 
 	// save action
 	{
-		err := c.updatePullzoneAccessListConfiguration(ctx, shieldZoneId, httpResult.Data.Id, data.IsEnabled, data.Action)
+		err := c.updatePullzoneAccessListConfiguration(ctx, shieldZoneId, httpResult.Data.Id, data.IsEnabled, data.Action, 0)
 		if err != nil {
 			return result, err
 		}
@@ -243,12 +243,30 @@ type pullzoneAccessListInfo struct {
 	ListId          int64  `json:"listId"`
 	ConfigurationId int64  `json:"configurationId"`
 	Name            string `json:"name"`
+	Description     string `json:"description"`
 	Type            uint8  `json:"type"`
 	Action          uint8  `json:"action"`
 	IsEnabled       bool   `json:"isEnabled"`
+	Priority        int64  `json:"priority"`
+	EntryCount      int64  `json:"entryCount"`
+	LastUpdated     string `json:"lastUpdated"`
 	RequiredPlan    uint8  `json:"requiredPlan"`
 }
 
+// PullzoneCuratedAccessList is an access list maintained by bunny.net, and its configuration in a shield zone.
+type PullzoneCuratedAccessList struct {
+	Id           int64
+	Name         string
+	Description  string
+	Type         uint8
+	EntryCount   int64
+	LastUpdated  string
+	RequiredPlan uint8
+	IsEnabled    bool
+	Action       uint8
+	Priority     int64
+}
+
 func (c *Client) GetPullzoneAccessLists(ctx context.Context, pullzoneId int64, query PullzoneAccessListQuery) ([]PullzoneAccessList, error) {
 	var result []PullzoneAccessList
 
@@ -277,6 +295,36 @@ func (c *Client) GetPullzoneAccessLists(ctx context.Context, pullzoneId int64, q
 	return result, nil
 }
 
+func (c *Client) GetPullzoneCuratedAccessLists(ctx context.Context, pullzoneId int64) ([]PullzoneCuratedAccessList, error) {
+	shieldZoneId, err := c.GetPullzoneShieldIdByPullzone(pullzoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	accessLists, err := c.getPullzoneAccessLists(ctx, shieldZoneId, PullzoneAccessListQueryCurated)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PullzoneCuratedAccessList, 0, len(accessLists))
+	for _, list := range accessLists {
+		result = append(result, PullzoneCuratedAccessList{
+			Id:           list.ListId,
+			Name:         list.Name,
+			Description:  list.Description,
+			Type:         list.Type,
+			EntryCount:   list.EntryCount,
+			LastUpdated:  list.LastUpdated,
+			RequiredPlan: list.RequiredPlan,
+			IsEnabled:    list.IsEnabled,
+			Action:       list.Action,
+			Priority:     list.Priority,
+		})
+	}
+
+	return result, nil
+}
+
 func (c *Client) getPullzoneAccessLists(ctx context.Context, shieldZoneId int64, query PullzoneAccessListQuery) ([]pullzoneAccessListInfo, error) {
 	var result []pullzoneAccessListInfo
 
@@ -348,11 +396,18 @@ func (c *Client) getPullzoneAccessListInfo(ctx context.Context, shieldZoneId int
 	return result, errors.New("access list not found")
 }
 
-func (c *Client) updatePullzoneAccessListConfiguration(ctx context.Context, shieldZoneId int64, listId int64, isEnabled bool, action uint8) error {
-	body, err := json.Marshal(map[string]interface{}{
+// updatePullzoneAccessListConfiguration saves the action of an access list. A priority of 0 keeps the current priority.
+func (c *Client) updatePullzoneAccessListConfiguration(ctx context.Context, shieldZoneId int64, listId int64, isEnabled bool, action uint8, priority int64) error {
+	configuration := map[string]interface{}{
 		"action":    action,
 		"isEnabled": isEnabled,
-	})
+	}
+
+	if priority > 0 {
+		configuration["priority"] = priority
+	}
+
+	body, err := json.Marshal(configuration)
 
 	if err != nil {
 		return err
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"io"
 	"net/http"
+	"sort"
 )
 
 type PullzoneEdgerule struct {
@@ -104,6 +105,85 @@ func (c *Client) GetPullzoneEdgerule(pullzoneId int64, guid string) (PullzoneEdg
 	return PullzoneEdgerule{}, ErrNotFound
 }
 
+// GetPullzoneEdgerules returns the edge rules of a pullzone, in execution order.
+func (c *Client) GetPullzoneEdgerules(pullzoneId int64) ([]PullzoneEdgerule, error) {
+	pullzone, err := c.GetPullzone(pullzoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortPullzoneEdgerules(pullzone), nil
+}
+
+// UpdatePullzoneEdgeruleOrder reorders the edge rules of a pullzone in a single update.
+// The rules listed in guids are placed first, in the given order, followed by the remaining rules in their current order.
+func (c *Client) UpdatePullzoneEdgeruleOrder(ctx context.Context, pullzoneId int64, guids []string) ([]PullzoneEdgerule, error) {
+	pullzone, err := c.GetPullzone(pullzoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	current := sortPullzoneEdgerules(pullzone)
+	byId := make(map[string]PullzoneEdgerule, len(current))
+	for _, edgerule := range current {
+		byId[edgerule.Id] = edgerule
+	}
+
+	edgerules := make([]PullzoneEdgerule, 0, len(current))
+	for _, guid := range guids {
+		edgerule, ok := byId[guid]
+		if !ok {
+			return nil, fmt.Errorf("edgerule %s not found", guid)
+		}
+
+		edgerules = append(edgerules, edgerule)
+		delete(byId, guid)
+	}
+
+	for _, edgerule := range current {
+		if _, ok := byId[edgerule.Id]; ok {
+			edgerules = append(edgerules, edgerule)
+		}
+	}
+
+	// OrderIndex is omitted from the request body when zero, so it starts at 1
+	for i := range edgerules {
+		edgerules[i].OrderIndex = int64(i + 1)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"Edgerules": edgerules,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("POST /pullzone/%d: %+v", pullzoneId, string(body)))
+
+	pullzoneResult, err := c.UpdatePullzoneWithBody(pullzoneId, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortPullzoneEdgerules(pullzoneResult), nil
+}
+
+func sortPullzoneEdgerules(pullzone Pullzone) []PullzoneEdgerule {
+	edgerules := make([]PullzoneEdgerule, len(pullzone.Edgerules))
+	copy(edgerules, pullzone.Edgerules)
+
+	sort.SliceStable(edgerules, func(i, j int) bool {
+		return edgerules[i].OrderIndex < edgerules[j].OrderIndex
+	})
+
+	for i := range edgerules {
+		edgerules[i].PullzoneId = pullzone.Id
+	}
+
+	return edgerules
+}
+
 func (c *Client) DeletePullzoneEdgerule(pullzoneId int64, guid string) error {
 	resp, err := c.doRequest(http.MethodDelete, fmt.Sprintf("%s/pullzone/%d/edgerules/%s", c.apiUrl, pullzoneId, guid), nil)
 	if err != nil {
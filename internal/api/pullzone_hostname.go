@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
 	"net/http"
+	"net/url"
 )
 
 type PullzoneHostname struct {
@@ -170,19 +171,10 @@ func (c *Client) UpdatePullzoneHostname(data PullzoneHostname, previousData Pull
 	}
 
 	if shouldAddManagedCertificate {
-		resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/pullzone/loadFreeCertificate?hostname=%s", c.apiUrl, data.Name), nil)
+		err := c.LoadFreeCertificate(data.Name)
 		if err != nil {
 			return PullzoneHostname{}, err
 		}
-
-		if resp.StatusCode != http.StatusOK {
-			err := utils.ExtractErrorMessage(resp)
-			if err != nil {
-				return PullzoneHostname{}, errors.New("loadFreeCertificate failed: " + err.Error())
-			}
-
-			return PullzoneHostname{}, errors.New("loadFreeCertificate failed with " + resp.Status)
-		}
 	}
 
 	if shouldSetForceSsl {
@@ -213,6 +205,27 @@ func (c *Client) UpdatePullzoneHostname(data PullzoneHostname, previousData Pull
 	return c.GetPullzoneHostname(pullzoneId, data.Id)
 }
 
+// LoadFreeCertificate requests a free, Domain-validated certificate for the hostname.
+func (c *Client) LoadFreeCertificate(hostname string) error {
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/pullzone/loadFreeCertificate?hostname=%s", c.apiUrl, url.QueryEscape(hostname)), nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err := utils.ExtractErrorMessage(resp)
+		if err != nil {
+			return errors.New("loadFreeCertificate failed: " + err.Error())
+		}
+
+		return errors.New("loadFreeCertificate failed with " + resp.Status)
+	}
+
+	_ = resp.Body.Close()
+
+	return nil
+}
+
 func (c *Client) GetPullzoneHostname(pullzoneId int64, id int64) (PullzoneHostname, error) {
 	pullzone, err := c.GetPullzone(pullzoneId)
 	if err != nil {
@@ -245,6 +258,22 @@ func (c *Client) GetPullzoneHostnameByName(pullzoneId int64, hostname string) (P
 	return PullzoneHostname{}, errors.New("Hostname not found")
 }
 
+// GetPullzoneHostnames returns the hostnames of a pullzone, including system and managed hostnames.
+func (c *Client) GetPullzoneHostnames(pullzoneId int64) ([]PullzoneHostname, error) {
+	pullzone, err := c.GetPullzone(pullzoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := make([]PullzoneHostname, len(pullzone.Hostnames))
+	for i, hostname := range pullzone.Hostnames {
+		hostname.PullzoneId = pullzoneId
+		hostnames[i] = hostname
+	}
+
+	return hostnames, nil
+}
+
 func (c *Client) DeletePullzoneHostname(pullzoneId int64, hostname string) error {
 	body, err := json.Marshal(map[string]interface{}{
 		"Hostname": hostname,
@@ -0,0 +1,77 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// PurgePullzoneCache purges the cache for a pullzone. If cacheTag is not empty, only the files tagged with it are purged.
+func (c *Client) PurgePullzoneCache(ctx context.Context, pullzoneId int64, cacheTag string) error {
+	var body io.Reader
+	if cacheTag != "" {
+		bodyJson, err := json.Marshal(map[string]string{"CacheTag": cacheTag})
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(bodyJson)
+	}
+
+	resp, err := c.doRequest(http.MethodPost, fmt.Sprintf("%s/pullzone/%d/purgeCache", c.apiUrl, pullzoneId), body)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("POST /pullzone/%d/purgeCache: %s", pullzoneId, resp.Status))
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		err := utils.ExtractErrorMessage(resp)
+		if err != nil {
+			return err
+		}
+
+		return errors.New("purge pullzone cache failed with " + resp.Status)
+	}
+
+	_ = resp.Body.Close()
+
+	return nil
+}
+
+// PurgeUrl purges a single URL from the cache. The URL can end with a wildcard (i.e. https://example.b-cdn.net/images/*).
+func (c *Client) PurgeUrl(ctx context.Context, purgeUrl string) error {
+	resp, err := c.doRequest(http.MethodPost, fmt.Sprintf("%s/purge?url=%s&async=false", c.apiUrl, url.QueryEscape(purgeUrl)), nil)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("POST /purge?url=%s: %s", purgeUrl, resp.Status))
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		err := utils.ExtractErrorMessage(resp)
+		if err != nil {
+			return err
+		}
+
+		return errors.New("purge URL failed with " + resp.Status)
+	}
+
+	_ = resp.Body.Close()
+
+	return nil
+}
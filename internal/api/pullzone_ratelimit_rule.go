@@ -181,3 +181,55 @@ func (c *Client) DeletePullzoneRatelimitRule(ctx context.Context, ruleId int64)
 
 	return nil
 }
+
+func (c *Client) GetPullzoneRatelimitRules(ctx context.Context, pullzoneId int64) ([]PullzoneRatelimitRule, error) {
+	shieldZoneId, err := c.GetPullzoneShieldIdByPullzone(pullzoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []PullzoneRatelimitRule
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/shield/rate-limits/%d?page=%d&perPage=%d", c.apiUrl, shieldZoneId, page, pullzoneShieldRulesPerPage), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := utils.ExtractErrorMessage(resp)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, errors.New("get ratelimit rules failed with " + resp.Status)
+		}
+
+		bodyResp, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = resp.Body.Close()
+		var result struct {
+			Data []PullzoneRatelimitRule `json:"data"`
+		}
+
+		err = json.Unmarshal(bodyResp, &result)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("GET /shield/rate-limits/%d?page=%d: %d items", shieldZoneId, page, len(result.Data)))
+
+		for _, rule := range result.Data {
+			rule.PullzoneId = pullzoneId
+			rules = append(rules, rule)
+		}
+
+		if len(result.Data) < pullzoneShieldRulesPerPage {
+			break
+		}
+	}
+
+	return rules, nil
+}
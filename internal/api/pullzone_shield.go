@@ -29,6 +29,7 @@ type PullzoneShieldAccessList struct {
 	Name      string `json:"-"`
 	Action    uint8  `json:"-"`
 	IsEnabled bool   `json:"-"`
+	Priority  int64  `json:"-"`
 }
 
 type PullzoneShield struct {
@@ -239,52 +240,9 @@ func (c *Client) GetPullzoneShield(ctx context.Context, id int64) (PullzoneShiel
 		result.Data.WafAllowedRequestContentTypes = utils.SetToSlice(engineConfigDefaults.AllowedRequestContentTypes)
 
 		// override defaults with pullzone data
-		for _, item := range result.Data.WafEngineConfig {
-			if item.Name == "allowed_http_versions" {
-				result.Data.WafAllowedHttpVersions = strings.Split(item.ValueEncoded, " ")
-			}
-
-			if item.Name == "allowed_methods" {
-				result.Data.WafAllowedHttpMethods = strings.Split(item.ValueEncoded, " ")
-			}
-
-			if item.Name == "allowed_request_content_type" {
-				var items []string
-				re := regexp.MustCompile(`(\|([^\|]+)\|)`)
-				matches := re.FindAllStringSubmatch(item.ValueEncoded, -1)
-				for _, item := range matches {
-					items = append(items, item[2])
-				}
-
-				result.Data.WafAllowedRequestContentTypes = items
-			}
-
-			if item.Name == "detection_paranoia_level" {
-				level, err := strconv.ParseInt(item.ValueEncoded, 10, 64)
-				if err != nil {
-					return PullzoneShield{}, err
-				}
-
-				result.Data.WafRuleSensitivityDetection = uint8(level)
-			}
-
-			if item.Name == "executing_paranoia_level" {
-				level, err := strconv.ParseInt(item.ValueEncoded, 10, 64)
-				if err != nil {
-					return PullzoneShield{}, err
-				}
-
-				result.Data.WafRuleSensitivityExecution = uint8(level)
-			}
-
-			if item.Name == "blocking_paranoia_level" {
-				level, err := strconv.ParseInt(item.ValueEncoded, 10, 64)
-				if err != nil {
-					return PullzoneShield{}, err
-				}
-
-				result.Data.WafRuleSensitivityBlocking = uint8(level)
-			}
+		err = result.Data.SetWafEngineConfig(result.Data.WafEngineConfig)
+		if err != nil {
+			return PullzoneShield{}, err
 		}
 	}
 
@@ -306,6 +264,7 @@ func (c *Client) GetPullzoneShield(ctx context.Context, id int64) (PullzoneShiel
 				Action:    list.Action,
 				Name:      list.Name,
 				IsEnabled: list.IsEnabled,
+				Priority:  list.Priority,
 			})
 		}
 
@@ -417,10 +376,7 @@ func (c *Client) CreatePullzoneShield(ctx context.Context, data PullzoneShield)
 		return c.UpdatePullzoneShield(ctx, data)
 	}
 
-	wafEngineConfig, err := c.convertPullzoneShieldWafEngineConfigToBody(data)
-	if err != nil {
-		return PullzoneShield{}, err
-	}
+	wafEngineConfig := convertPullzoneShieldWafEngineConfigToBody(data)
 
 	body, err := json.Marshal(map[string]interface{}{
 		"pullZoneId": data.PullzoneId,
@@ -490,10 +446,7 @@ func (c *Client) CreatePullzoneShield(ctx context.Context, data PullzoneShield)
 func (c *Client) UpdatePullzoneShield(ctx context.Context, data PullzoneShield) (PullzoneShield, error) {
 	// general settings
 	{
-		wafEngineConfig, err := c.convertPullzoneShieldWafEngineConfigToBody(data)
-		if err != nil {
-			return PullzoneShield{}, err
-		}
+		wafEngineConfig := convertPullzoneShieldWafEngineConfigToBody(data)
 
 		body, err := json.Marshal(map[string]interface{}{
 			"shieldZoneId": data.Id,
@@ -548,6 +501,7 @@ func (c *Client) UpdatePullzoneShield(ctx context.Context, data PullzoneShield)
 		type accessListConfig struct {
 			Action    uint8
 			IsEnabled bool
+			Priority  int64
 		}
 
 		listConfigMap := make(map[int64]accessListConfig, len(data.AccessLists))
@@ -583,9 +537,14 @@ func (c *Client) UpdatePullzoneShield(ctx context.Context, data PullzoneShield)
 				}
 			}
 
+			if _, ok := listConfigMap[listId]; ok {
+				return PullzoneShield{}, fmt.Errorf("access list \"%s\" is configured more than once", managedListsMap[listId].Name)
+			}
+
 			listConfigMap[listId] = accessListConfig{
 				Action:    list.Action,
 				IsEnabled: list.IsEnabled,
+				Priority:  list.Priority,
 			}
 		}
 
@@ -593,7 +552,7 @@ func (c *Client) UpdatePullzoneShield(ctx context.Context, data PullzoneShield)
 			listConfig, ok := listConfigMap[list.ListId]
 			if !ok {
 				if list.IsEnabled {
-					err := c.updatePullzoneAccessListConfiguration(ctx, data.Id, list.ListId, false, list.Action)
+					err := c.updatePullzoneAccessListConfiguration(ctx, data.Id, list.ListId, false, list.Action, 0)
 					if err != nil {
 						if err.Error() == "not_available.access_list" {
 							continue
@@ -606,12 +565,12 @@ func (c *Client) UpdatePullzoneShield(ctx context.Context, data PullzoneShield)
 				continue
 			}
 
-			if listConfig.Action == list.Action && listConfig.IsEnabled == list.IsEnabled {
+			if listConfig.Action == list.Action && listConfig.IsEnabled == list.IsEnabled && (listConfig.Priority == 0 || listConfig.Priority == list.Priority) {
 				// no changes
 				continue
 			}
 
-			err := c.updatePullzoneAccessListConfiguration(ctx, data.Id, list.ListId, listConfig.IsEnabled, listConfig.Action)
+			err := c.updatePullzoneAccessListConfiguration(ctx, data.Id, list.ListId, listConfig.IsEnabled, listConfig.Action, listConfig.Priority)
 			if err != nil {
 				if err.Error() == "not_available.access_list" {
 					continue
@@ -708,7 +667,7 @@ func (c *Client) DeletePullzoneShield(ctx context.Context, id int64) error {
 	return err
 }
 
-func (c *Client) convertPullzoneShieldWafEngineConfigToBody(data PullzoneShield) ([]map[string]string, error) {
+func convertPullzoneShieldWafEngineConfigToBody(data PullzoneShield) []map[string]string {
 	result := []map[string]string{
 		{
 			"name":         "detection_paranoia_level",
@@ -740,7 +699,7 @@ func (c *Client) convertPullzoneShieldWafEngineConfigToBody(data PullzoneShield)
 		}
 
 		// removes trailing space
-		allowedRequestContentTypeStr = allowedRequestContentTypeStr[0 : len(allowedRequestContentTypeStr)-1]
+		allowedRequestContentTypeStr = strings.TrimSuffix(allowedRequestContentTypeStr, " ")
 
 		result = append(result, map[string]string{
 			"name":         "allowed_request_content_type",
@@ -748,5 +707,72 @@ func (c *Client) convertPullzoneShieldWafEngineConfigToBody(data PullzoneShield)
 		})
 	}
 
-	return result, nil
+	return result
+}
+
+// SetWafEngineConfig overrides the engine config fields with the items stored in the shield zone.
+func (s *PullzoneShield) SetWafEngineConfig(items []PullzoneShieldWafEngineConfigItem) error {
+	for _, item := range items {
+		if item.Name == "allowed_http_versions" {
+			s.WafAllowedHttpVersions = strings.Split(item.ValueEncoded, " ")
+		}
+
+		if item.Name == "allowed_methods" {
+			s.WafAllowedHttpMethods = strings.Split(item.ValueEncoded, " ")
+		}
+
+		if item.Name == "allowed_request_content_type" {
+			var contentTypes []string
+			re := regexp.MustCompile(`(\|([^\|]+)\|)`)
+			matches := re.FindAllStringSubmatch(item.ValueEncoded, -1)
+			for _, item := range matches {
+				contentTypes = append(contentTypes, item[2])
+			}
+
+			s.WafAllowedRequestContentTypes = contentTypes
+		}
+
+		if item.Name == "detection_paranoia_level" {
+			level, err := strconv.ParseInt(item.ValueEncoded, 10, 64)
+			if err != nil {
+				return err
+			}
+
+			s.WafRuleSensitivityDetection = uint8(level)
+		}
+
+		if item.Name == "executing_paranoia_level" {
+			level, err := strconv.ParseInt(item.ValueEncoded, 10, 64)
+			if err != nil {
+				return err
+			}
+
+			s.WafRuleSensitivityExecution = uint8(level)
+		}
+
+		if item.Name == "blocking_paranoia_level" {
+			level, err := strconv.ParseInt(item.ValueEncoded, 10, 64)
+			if err != nil {
+				return err
+			}
+
+			s.WafRuleSensitivityBlocking = uint8(level)
+		}
+	}
+
+	return nil
+}
+
+// WafEngineConfigItems returns the engine config fields as stored in the shield zone.
+func (s PullzoneShield) WafEngineConfigItems() []PullzoneShieldWafEngineConfigItem {
+	body := convertPullzoneShieldWafEngineConfigToBody(s)
+	result := make([]PullzoneShieldWafEngineConfigItem, 0, len(body))
+	for _, item := range body {
+		result = append(result, PullzoneShieldWafEngineConfigItem{
+			Name:         item["name"],
+			ValueEncoded: item["valueEncoded"],
+		})
+	}
+
+	return result
 }
@@ -0,0 +1,164 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type PullzoneShieldEvent struct {
+	Id        string
+	Timestamp time.Time
+	RuleId    string
+	Action    string
+	Country   string
+	RemoteIp  string
+	Method    string
+	Url       string
+	Message   string
+}
+
+type PullzoneShieldEventFilter struct {
+	From      time.Time
+	To        time.Time
+	RuleIds   []string
+	Actions   []string
+	Countries []string
+}
+
+type pullzoneShieldEventLogHttp struct {
+	Logs []struct {
+		LogId     string            `json:"logId"`
+		Timestamp int64             `json:"timestamp"`
+		Log       string            `json:"log"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"logs"`
+	HasMoreData       bool   `json:"hasMoreData"`
+	ContinuationToken string `json:"continuationToken"`
+}
+
+// GetPullzoneShieldEvents returns the shield events of a pullzone that match the filter, oldest first.
+// The event log is stored per day, so every day in the time window is fetched, following the continuation tokens.
+func (c *Client) GetPullzoneShieldEvents(ctx context.Context, pullzoneId int64, filter PullzoneShieldEventFilter) ([]PullzoneShieldEvent, error) {
+	shieldZoneId, err := c.GetPullzoneShieldIdByPullzone(pullzoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	from := filter.From.UTC()
+	to := filter.To.UTC()
+	if to.Before(from) {
+		return nil, errors.New("the end of the time window must not be before its start")
+	}
+
+	var result []PullzoneShieldEvent
+	for day := from.Truncate(24 * time.Hour); !day.After(to); day = day.AddDate(0, 0, 1) {
+		events, err := c.getPullzoneShieldEventsByDay(ctx, shieldZoneId, day)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			if event.Timestamp.Before(from) || event.Timestamp.After(to) || !event.matches(filter) {
+				continue
+			}
+
+			result = append(result, event)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+
+	return result, nil
+}
+
+func (c *Client) getPullzoneShieldEventsByDay(ctx context.Context, shieldZoneId int64, day time.Time) ([]PullzoneShieldEvent, error) {
+	var result []PullzoneShieldEvent
+	continuationToken := ""
+
+	for {
+		endpoint := fmt.Sprintf("%s/shield/event-logs/%d/%s", c.apiUrl, shieldZoneId, day.Format("01-02-2006"))
+		if continuationToken != "" {
+			endpoint += "/" + url.PathEscape(continuationToken)
+		}
+
+		resp, err := c.doRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := utils.ExtractShieldErrorMessage(resp)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, errors.New("get shield event logs failed with " + resp.Status)
+		}
+
+		bodyResp, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("GET /shield/event-logs/%d/%s: %d bytes", shieldZoneId, day.Format("01-02-2006"), len(bodyResp)))
+
+		var httpResult pullzoneShieldEventLogHttp
+		err = json.Unmarshal(bodyResp, &httpResult)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, log := range httpResult.Logs {
+			result = append(result, PullzoneShieldEvent{
+				Id:        log.LogId,
+				Timestamp: time.UnixMilli(log.Timestamp).UTC(),
+				RuleId:    log.Labels["ruleId"],
+				Action:    strings.ToLower(log.Labels["action"]),
+				Country:   strings.ToUpper(log.Labels["countryCode"]),
+				RemoteIp:  log.Labels["remoteIp"],
+				Method:    log.Labels["method"],
+				Url:       log.Labels["url"],
+				Message:   log.Log,
+			})
+		}
+
+		if !httpResult.HasMoreData || httpResult.ContinuationToken == "" {
+			return result, nil
+		}
+
+		continuationToken = httpResult.ContinuationToken
+	}
+}
+
+func (e PullzoneShieldEvent) matches(filter PullzoneShieldEventFilter) bool {
+	contains := func(values []string, value string) bool {
+		if len(values) == 0 {
+			return true
+		}
+
+		for _, v := range values {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return contains(filter.RuleIds, e.RuleId) && contains(filter.Actions, e.Action) && contains(filter.Countries, e.Country)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+)
+
+// PullzoneShieldWafManagedRule has no default action, as the API does not expose one.
+type PullzoneShieldWafManagedRule struct {
+	Id          string `json:"ruleId"`
+	Description string `json:"description"`
+	Category    string `json:"-"`
+}
+
+type PullzoneShieldWafManagedRuleGroup struct {
+	Id          string                         `json:"code"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Rules       []PullzoneShieldWafManagedRule `json:"rules"`
+}
+
+// GetPullzoneShieldWafManagedRules returns the catalogue of managed WAF rules, which is the same for every shield zone.
+// The catalogue is fetched once and reused for the lifetime of the client.
+func (c *Client) GetPullzoneShieldWafManagedRules(ctx context.Context) ([]PullzoneShieldWafManagedRuleGroup, error) {
+	c.wafManagedRulesMutex.Lock()
+	defer c.wafManagedRulesMutex.Unlock()
+
+	if c.wafManagedRules != nil {
+		return c.wafManagedRules, nil
+	}
+
+	tflog.Info(ctx, "GET /shield/waf/rules")
+
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/shield/waf/rules", c.apiUrl), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err := utils.ExtractErrorMessage(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, errors.New("get waf rules failed with " + resp.Status)
+	}
+
+	bodyResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("GET /shield/waf/rules: %s", string(bodyResp)))
+
+	var result []struct {
+		Name       string                              `json:"name"`
+		RuleGroups []PullzoneShieldWafManagedRuleGroup `json:"ruleGroups"`
+	}
+
+	err = json.Unmarshal(bodyResp, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	// rules are categorized by the main group their rule group belongs to
+	groups := []PullzoneShieldWafManagedRuleGroup{}
+	for _, mainGroup := range result {
+		for _, group := range mainGroup.RuleGroups {
+			for i := range group.Rules {
+				group.Rules[i].Category = mainGroup.Name
+			}
+
+			groups = append(groups, group)
+		}
+	}
+
+	c.wafManagedRules = groups
+
+	return groups, nil
+}
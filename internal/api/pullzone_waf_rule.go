@@ -43,6 +43,9 @@ type PullzoneWafRule struct {
 	RuleConfiguration PullzoneWafRuleConfiguration `json:"ruleConfiguration"`
 }
 
+// pullzoneShieldRulesPerPage is the page size used when listing WAF and ratelimit rules.
+const pullzoneShieldRulesPerPage = 100
+
 func (c *Client) GetPullzoneWafRule(ctx context.Context, pullzoneId int64, ruleId int64) (PullzoneWafRule, error) {
 	tflog.Info(ctx, fmt.Sprintf("GET /shield/waf/custom-rule/%d", ruleId))
 
@@ -181,3 +184,55 @@ func (c *Client) DeletePullzoneWafRule(ctx context.Context, ruleId int64) error
 
 	return nil
 }
+
+func (c *Client) GetPullzoneWafRules(ctx context.Context, pullzoneId int64) ([]PullzoneWafRule, error) {
+	shieldZoneId, err := c.GetPullzoneShieldIdByPullzone(pullzoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []PullzoneWafRule
+	for page := 1; ; page++ {
+		resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("%s/shield/waf/custom-rules/%d?page=%d&perPage=%d", c.apiUrl, shieldZoneId, page, pullzoneShieldRulesPerPage), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := utils.ExtractErrorMessage(resp)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, errors.New("get WAF rules failed with " + resp.Status)
+		}
+
+		bodyResp, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = resp.Body.Close()
+		var result struct {
+			Data []PullzoneWafRule `json:"data"`
+		}
+
+		err = json.Unmarshal(bodyResp, &result)
+		if err != nil {
+			return nil, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("GET /shield/waf/custom-rules/%d?page=%d: %d items", shieldZoneId, page, len(result.Data)))
+
+		for _, rule := range result.Data {
+			rule.PullzoneId = pullzoneId
+			rules = append(rules, rule)
+		}
+
+		if len(result.Data) < pullzoneShieldRulesPerPage {
+			break
+		}
+	}
+
+	return rules, nil
+}
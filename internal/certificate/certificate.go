@@ -0,0 +1,173 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseChain parses a PEM bundle, returning the certificates in the order they appear.
+func ParseChain(certificatePem string) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	rest := []byte(certificatePem)
+
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block \"%s\", only certificates are allowed", block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate #%d: %w", len(chain)+1, err)
+		}
+
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return nil, errors.New("unexpected data after the last certificate")
+	}
+
+	return chain, nil
+}
+
+// VerifyKeyPair checks that the private key matches the leaf certificate.
+func VerifyKeyPair(certificatePem string, keyPem string) error {
+	_, err := tls.X509KeyPair([]byte(certificatePem), []byte(keyPem))
+	if err == nil {
+		return nil
+	}
+
+	// tls.X509KeyPair errors are generic, so report a mismatch explicitly when both parts parse
+	chain, chainErr := ParseChain(certificatePem)
+	key, keyErr := parsePrivateKey(keyPem)
+	if chainErr != nil || keyErr != nil {
+		return err
+	}
+
+	if !publicKeyEqual(chain[0].PublicKey, key.Public()) {
+		return errors.New("the private key does not match the certificate")
+	}
+
+	return err
+}
+
+// VerifyValidity checks that the certificate is valid at the given time.
+func VerifyValidity(cert *x509.Certificate, now time.Time) error {
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("the certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("the certificate expired on %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// ExpiresWithin reports whether the certificate expires within the given duration.
+func ExpiresWithin(cert *x509.Certificate, now time.Time, d time.Duration) bool {
+	return now.Add(d).After(cert.NotAfter)
+}
+
+// VerifyHostname checks that the certificate SANs cover the hostname, including wildcard SANs.
+func VerifyHostname(cert *x509.Certificate, hostname string) error {
+	err := cert.VerifyHostname(hostname)
+	if err != nil {
+		return fmt.Errorf("the certificate is not valid for %s, it covers: %s", hostname, strings.Join(Sans(cert), ", "))
+	}
+
+	return nil
+}
+
+// VerifyChainOrder checks that every certificate is issued by the one that follows it.
+func VerifyChainOrder(chain []*x509.Certificate) error {
+	for i := 0; i < len(chain)-1; i++ {
+		err := chain[i].CheckSignatureFrom(chain[i+1])
+		if err != nil {
+			return fmt.Errorf("certificate #%d (%s) is not issued by certificate #%d (%s): the chain must start with the leaf certificate, followed by its intermediates in order", i+1, chain[i].Subject.String(), i+2, chain[i+1].Subject.String())
+		}
+	}
+
+	return nil
+}
+
+// IsMissingIntermediates reports whether the chain only contains a leaf certificate issued by a different entity.
+func IsMissingIntermediates(chain []*x509.Certificate) bool {
+	if len(chain) != 1 {
+		return false
+	}
+
+	return chain[0].Issuer.String() != chain[0].Subject.String()
+}
+
+// Sans returns the DNS names and IP addresses in the certificate.
+func Sans(cert *x509.Certificate) []string {
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return sans
+}
+
+func parsePrivateKey(keyPem string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPem))
+	if block == nil {
+		return nil, errors.New("no PEM-encoded private key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+
+	return signer, nil
+}
+
+func publicKeyEqual(a crypto.PublicKey, b crypto.PublicKey) bool {
+	switch key := a.(type) {
+	case *rsa.PublicKey:
+		return key.Equal(b)
+	case *ecdsa.PublicKey:
+		return key.Equal(b)
+	case ed25519.PublicKey:
+		return key.Equal(b)
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package customtype
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = DnsRecordValueType{}
+var _ basetypes.StringValuable = DnsRecordValueValue{}
+var _ basetypes.StringValuableWithSemanticEquals = DnsRecordValueValue{}
+
+type DnsRecordValueType struct {
+	basetypes.StringType
+}
+
+func (t DnsRecordValueType) Equal(o attr.Type) bool {
+	other, ok := o.(DnsRecordValueType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t DnsRecordValueType) String() string {
+	return "DnsRecordValueType"
+}
+
+func (t DnsRecordValueType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	value := DnsRecordValueValue{
+		StringValue: in,
+	}
+
+	return value, nil
+}
+
+func (t DnsRecordValueType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t DnsRecordValueType) ValueType(ctx context.Context) attr.Value {
+	return DnsRecordValueValue{}
+}
+
+type DnsRecordValueValue struct {
+	basetypes.StringValue
+}
+
+func (v DnsRecordValueValue) Equal(o attr.Value) bool {
+	other, ok := o.(DnsRecordValueValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v DnsRecordValueValue) Type(ctx context.Context) attr.Type {
+	return DnsRecordValueType{}
+}
+
+// StringSemanticEquals ignores differences in quoting, escaping, whitespace and character-string splitting, as found in TXT values.
+// Values do not know their record type, so the dns_record resource keeps the configured value for other record types.
+func (v DnsRecordValueValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(DnsRecordValueValue)
+
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	if v.ValueString() == newValue.ValueString() {
+		return true, nil
+	}
+
+	return utils.NormalizeTxtValue(v.ValueString()) == utils.NormalizeTxtValue(newValue.ValueString()), nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package customtype
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+	"testing"
+)
+
+func TestDnsRecordValue(t *testing.T) {
+	type testCase struct {
+		ExpectedResult bool
+		VOld           string
+		VNew           string
+	}
+
+	longValue := strings.Repeat("a", 300)
+
+	dataProvider := []testCase{
+		{true, "v=spf1 -all", "v=spf1 -all"},
+		{false, "v=spf1 -all", "v=spf1 ~all"},
+		{true, "v=spf1 include:bunny.net ~all", `"v=spf1 include:bunny.net ~all"`},
+		{true, "v=spf1 include:bunny.net ~all", "v=spf1  include:bunny.net ~all\n"},
+		{true, longValue, `"` + longValue[:255] + `" "` + longValue[255:] + `"`},
+		{false, longValue, `"` + longValue[:255] + `" "` + longValue[256:] + `"`},
+	}
+
+	for _, tc := range dataProvider {
+		newValue := DnsRecordValueValue{StringValue: types.StringValue(tc.VOld)}
+
+		result, diags := DnsRecordValueValue{
+			StringValue: types.StringValue(tc.VNew),
+		}.StringSemanticEquals(context.Background(), newValue)
+
+		if diags.HasError() {
+			t.Errorf("expected no error, got %s", diags.Errors())
+		}
+
+		if tc.ExpectedResult != result {
+			t.Errorf("expected %s == %s to be %t, got %t", tc.VOld, tc.VNew, tc.ExpectedResult, result)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package customtype
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/shieldpolicy"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = ShieldPolicyDocumentType{}
+var _ basetypes.StringValuable = ShieldPolicyDocumentValue{}
+var _ basetypes.StringValuableWithSemanticEquals = ShieldPolicyDocumentValue{}
+
+type ShieldPolicyDocumentType struct {
+	basetypes.StringType
+}
+
+func (t ShieldPolicyDocumentType) Equal(o attr.Type) bool {
+	other, ok := o.(ShieldPolicyDocumentType)
+
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t ShieldPolicyDocumentType) String() string {
+	return "ShieldPolicyDocumentType"
+}
+
+func (t ShieldPolicyDocumentType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	value := ShieldPolicyDocumentValue{
+		StringValue: in,
+	}
+
+	return value, nil
+}
+
+func (t ShieldPolicyDocumentType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+func (t ShieldPolicyDocumentType) ValueType(ctx context.Context) attr.Value {
+	return ShieldPolicyDocumentValue{}
+}
+
+type ShieldPolicyDocumentValue struct {
+	basetypes.StringValue
+}
+
+func (v ShieldPolicyDocumentValue) Equal(o attr.Value) bool {
+	other, ok := o.(ShieldPolicyDocumentValue)
+
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v ShieldPolicyDocumentValue) Type(ctx context.Context) attr.Type {
+	return ShieldPolicyDocumentType{}
+}
+
+func (v ShieldPolicyDocumentValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(ShieldPolicyDocumentValue)
+
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	if v.ValueString() == newValue.ValueString() {
+		return true, nil
+	}
+
+	// invalid documents are reported by the attribute validator
+	oldDoc, err := shieldpolicy.Parse(v.ValueString())
+	if err != nil {
+		return false, nil
+	}
+
+	newDoc, err := shieldpolicy.Parse(newValue.ValueString())
+	if err != nil {
+		return false, nil
+	}
+
+	return shieldpolicy.Equal(oldDoc, newDoc), nil
+}
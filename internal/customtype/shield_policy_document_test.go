@@ -0,0 +1,45 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package customtype
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"testing"
+)
+
+func TestShieldPolicyDocument(t *testing.T) {
+	type testCase struct {
+		ExpectedResult bool
+		VOld           string
+		VNew           string
+	}
+
+	dataProvider := []testCase{
+		{true, `{"version": 1}`, `{"version": 1}`},
+		{true, `{"version": 1}`, "{\n  \"version\": 1,\n  \"wafRules\": []\n}"},
+		{true, `{"version": 1, "wafRules": [{"name": "a"}, {"name": "b"}]}`, `{"version": 1, "wafRules": [{"name": "b"}, {"name": "a"}]}`},
+		{false, `{"version": 1, "wafRules": [{"name": "a"}]}`, `{"version": 1}`},
+
+		// invalid documents
+		{false, `{"version": 1}`, `{"version": 1`},
+		{false, `{}`, `{"version": 1}`},
+	}
+
+	for _, tc := range dataProvider {
+		oldValue := ShieldPolicyDocumentValue{StringValue: types.StringValue(tc.VOld)}
+
+		result, diags := ShieldPolicyDocumentValue{
+			StringValue: types.StringValue(tc.VNew),
+		}.StringSemanticEquals(context.Background(), oldValue)
+
+		if diags.HasError() {
+			t.Errorf("expected no error, got %s", diags.Errors())
+		}
+
+		if tc.ExpectedResult != result {
+			t.Errorf("expected %s == %s to be %t, got %t", tc.VOld, tc.VNew, tc.ExpectedResult, result)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package dnsrecordresourcevalidator
 
 import (
 	"context"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/customtype"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -29,7 +30,7 @@ func (v hostnameValidator) ValidateResource(ctx context.Context, req resource.Va
 	var planType types.String
 	req.Config.GetAttribute(ctx, typeAttr, &planType)
 
-	var planValue types.String
+	var planValue customtype.DnsRecordValueValue
 	req.Config.GetAttribute(ctx, valueAttr, &planValue)
 
 	if planValue.IsUnknown() {
@@ -2,6 +2,7 @@ package dnsrecordresourcevalidator
 
 import (
 	"context"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/customtype"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -84,7 +85,7 @@ func TestHostname(t *testing.T) {
 	configSchema := schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"type":  schema.StringAttribute{},
-			"value": schema.StringAttribute{},
+			"value": schema.StringAttribute{CustomType: customtype.DnsRecordValueType{}},
 		},
 	}
 
@@ -0,0 +1,130 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package dnssec
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+type DsRecord struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	Digest     string
+}
+
+// ParseDsRecord parses a DS record, either as a full resource record
+// (i.e. "example.com. 3600 IN DS 12345 13 2 ABCD...") or as RDATA only (i.e. "12345 13 2 ABCD...").
+func ParseDsRecord(value string) (DsRecord, error) {
+	value = strings.NewReplacer("(", " ", ")", " ").Replace(value)
+	fields := strings.Fields(value)
+
+	for i, field := range fields {
+		if strings.EqualFold(field, "DS") {
+			fields = fields[i+1:]
+			break
+		}
+	}
+
+	if len(fields) < 4 {
+		return DsRecord{}, errors.New("invalid DS record, expected \"<key tag> <algorithm> <digest type> <digest>\"")
+	}
+
+	keyTag, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return DsRecord{}, fmt.Errorf("invalid DS record key tag: %w", err)
+	}
+
+	algorithm, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return DsRecord{}, fmt.Errorf("invalid DS record algorithm: %w", err)
+	}
+
+	digestType, err := strconv.ParseUint(fields[2], 10, 8)
+	if err != nil {
+		return DsRecord{}, fmt.Errorf("invalid DS record digest type: %w", err)
+	}
+
+	digest := strings.ToUpper(strings.Join(fields[3:], ""))
+	if _, err := hex.DecodeString(digest); err != nil {
+		return DsRecord{}, fmt.Errorf("invalid DS record digest: %w", err)
+	}
+
+	return DsRecord{
+		KeyTag:     uint16(keyTag),
+		Algorithm:  uint8(algorithm),
+		DigestType: uint8(digestType),
+		Digest:     digest,
+	}, nil
+}
+
+// CalculateDigest calculates the DS digest for a DNSKEY, as described in RFC 4034 section 5.1.4.
+func CalculateDigest(domain string, flags uint16, algorithm uint8, publicKey string, digestType uint8) (string, error) {
+	var h hash.Hash
+	switch digestType {
+	case DigestTypeSHA1:
+		h = sha1.New()
+	case DigestTypeSHA256:
+		h = sha256.New()
+	case DigestTypeSHA384:
+		h = sha512.New384()
+	default:
+		return "", fmt.Errorf("unsupported digest type: %d", digestType)
+	}
+
+	owner, err := wireName(domain)
+	if err != nil {
+		return "", err
+	}
+
+	rdata, err := dnskeyRdata(flags, algorithm, publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	h.Write(owner)
+	h.Write(rdata)
+
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil))), nil
+}
+
+// ValidateDsRecord checks whether a DS record matches the DNSKEY of a zone.
+func ValidateDsRecord(value string, domain string, flags uint16, algorithm uint8, publicKey string) error {
+	ds, err := ParseDsRecord(value)
+	if err != nil {
+		return err
+	}
+
+	keyTag, err := KeyTag(flags, algorithm, publicKey)
+	if err != nil {
+		return err
+	}
+
+	if ds.KeyTag != keyTag {
+		return fmt.Errorf("DS record key tag %d does not match the zone key tag %d", ds.KeyTag, keyTag)
+	}
+
+	if ds.Algorithm != algorithm {
+		return fmt.Errorf("DS record algorithm %d does not match the zone algorithm %d", ds.Algorithm, algorithm)
+	}
+
+	digest, err := CalculateDigest(domain, flags, algorithm, publicKey, ds.DigestType)
+	if err != nil {
+		return err
+	}
+
+	if ds.Digest != digest {
+		return errors.New("DS record digest does not match the zone key")
+	}
+
+	return nil
+}
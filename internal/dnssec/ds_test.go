@@ -0,0 +1,114 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package dnssec
+
+import (
+	"testing"
+)
+
+// test vectors from RFC 4034 section 5.4 and RFC 4509 section 2.3
+const testDomain = "dskey.example.com"
+const testFlags = 256
+const testAlgorithm = 5
+const testPublicKey = "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+func TestKeyTag(t *testing.T) {
+	keyTag, err := KeyTag(testFlags, testAlgorithm, testPublicKey)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if keyTag != 60485 {
+		t.Errorf("expected key tag 60485, got %d", keyTag)
+	}
+}
+
+func TestCalculateDigest(t *testing.T) {
+	type testCase struct {
+		DigestType uint8
+		Expected   string
+	}
+
+	dataProvider := []testCase{
+		{DigestTypeSHA1, "2BB183AF5F22588179A53B0A98631FAD1A292118"},
+		{DigestTypeSHA256, "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+	}
+
+	for _, tc := range dataProvider {
+		digest, err := CalculateDigest(testDomain, testFlags, testAlgorithm, testPublicKey, tc.DigestType)
+		if err != nil {
+			t.Errorf("expected no error, got %s", err)
+			continue
+		}
+
+		if digest != tc.Expected {
+			t.Errorf("expected digest %s, got %s", tc.Expected, digest)
+		}
+	}
+}
+
+func TestValidateDsRecord(t *testing.T) {
+	type testCase struct {
+		ExpectedError bool
+		Value         string
+	}
+
+	dataProvider := []testCase{
+		{false, "dskey.example.com. 86400 IN DS 60485 5 1 ( 2BB183AF5F22588179A53B0A 98631FAD1A292118 )"},
+		{false, "dskey.example.com. IN DS 60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+		{false, "60485 5 2 d4b7d520e7bb5f0f67674a0cceb1e3e0614b93c4f9e99b8383f6a1e4469da50a"},
+		{true, "60486 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+		{true, "60485 8 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+		{true, "60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50B"},
+		{true, "60485 5 3 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+		{true, "60485 5 2 not-hex"},
+		{true, "60485 5"},
+		{true, ""},
+	}
+
+	for _, tc := range dataProvider {
+		err := ValidateDsRecord(tc.Value, testDomain, testFlags, testAlgorithm, testPublicKey)
+
+		if tc.ExpectedError && err == nil {
+			t.Errorf("expected error for %q, got none", tc.Value)
+		}
+
+		if !tc.ExpectedError && err != nil {
+			t.Errorf("expected no error for %q, got %s", tc.Value, err)
+		}
+	}
+}
+
+func TestFormatDsRecord(t *testing.T) {
+	result := FormatDsRecord("example.com", 60485, 13, 2, "abcdef")
+	expected := "example.com. IN DS 60485 13 2 ABCDEF"
+
+	if result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestParseDnskeyRecord(t *testing.T) {
+	value := "dskey.example.com. 86400 IN DNSKEY 256 3 5 ( " + testPublicKey[:24] + " " + testPublicKey[24:] + " )"
+
+	record, err := ParseDnskeyRecord(value)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if record.Domain != "dskey.example.com." || record.Flags != testFlags || record.Algorithm != testAlgorithm || record.PublicKey != testPublicKey {
+		t.Errorf("unexpected result: %+v", record)
+	}
+
+	formatted := FormatDnskeyRecord(testDomain, testFlags, testAlgorithm, testPublicKey)
+	if _, err := ParseDnskeyRecord(formatted); err != nil {
+		t.Errorf("expected no error for %q, got %s", formatted, err)
+	}
+
+	for _, invalid := range []string{"", "IN DNSKEY 256 3 5 AAAA", "example.com. IN DNSKEY 256 2 5 AAAA", "example.com. IN DNSKEY 256 3"} {
+		if _, err := ParseDnskeyRecord(invalid); err == nil {
+			t.Errorf("expected error for %q, got none", invalid)
+		}
+	}
+}
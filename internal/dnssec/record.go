@@ -0,0 +1,141 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package dnssec
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DNSKEY protocol field, fixed by RFC 4034 section 2.1.2
+const dnskeyProtocol = 3
+
+const (
+	DigestTypeSHA1   uint8 = 1
+	DigestTypeSHA256 uint8 = 2
+	DigestTypeSHA384 uint8 = 4
+)
+
+// FormatDnskeyRecord returns the DNSKEY record in presentation format.
+func FormatDnskeyRecord(domain string, flags uint16, algorithm uint8, publicKey string) string {
+	return fmt.Sprintf("%s IN DNSKEY %d %d %d %s", fqdn(domain), flags, dnskeyProtocol, algorithm, publicKey)
+}
+
+// FormatDsRecord returns the DS record in presentation format.
+func FormatDsRecord(domain string, keyTag uint16, algorithm uint8, digestType uint8, digest string) string {
+	return fmt.Sprintf("%s IN DS %d %d %d %s", fqdn(domain), keyTag, algorithm, digestType, strings.ToUpper(digest))
+}
+
+// KeyTag calculates the key tag for a DNSKEY, as described in RFC 4034 appendix B.
+func KeyTag(flags uint16, algorithm uint8, publicKey string) (uint16, error) {
+	rdata, err := dnskeyRdata(flags, algorithm, publicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 1 {
+			ac += uint32(b)
+		} else {
+			ac += uint32(b) << 8
+		}
+	}
+
+	ac += (ac >> 16) & 0xFFFF
+	return uint16(ac & 0xFFFF), nil
+}
+
+func dnskeyRdata(flags uint16, algorithm uint8, publicKey string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(publicKey), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	rdata := make([]byte, 4, 4+len(key))
+	binary.BigEndian.PutUint16(rdata, flags)
+	rdata[2] = dnskeyProtocol
+	rdata[3] = algorithm
+
+	return append(rdata, key...), nil
+}
+
+// wireName converts a domain name to its canonical wire format, as described in RFC 4034 section 6.2.
+func wireName(domain string) ([]byte, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if domain == "" {
+		return []byte{0}, nil
+	}
+
+	var result []byte
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, errors.New("invalid domain name: " + domain)
+		}
+
+		result = append(result, byte(len(label)))
+		result = append(result, label...)
+	}
+
+	return append(result, 0), nil
+}
+
+func fqdn(domain string) string {
+	if strings.HasSuffix(domain, ".") {
+		return domain
+	}
+
+	return domain + "."
+}
+
+type DnskeyRecord struct {
+	Domain    string
+	Flags     uint16
+	Algorithm uint8
+	PublicKey string
+}
+
+// ParseDnskeyRecord parses a DNSKEY resource record in presentation format
+// (i.e. "example.com. IN DNSKEY 257 3 13 <public key>").
+func ParseDnskeyRecord(value string) (DnskeyRecord, error) {
+	value = strings.NewReplacer("(", " ", ")", " ").Replace(value)
+	fields := strings.Fields(value)
+
+	idx := -1
+	for i, field := range fields {
+		if strings.EqualFold(field, "DNSKEY") {
+			idx = i
+			break
+		}
+	}
+
+	if idx < 1 || len(fields) < idx+5 || strings.EqualFold(fields[0], "IN") {
+		return DnskeyRecord{}, errors.New("invalid DNSKEY record, expected \"<domain> IN DNSKEY <flags> <protocol> <algorithm> <public key>\"")
+	}
+
+	flags, err := strconv.ParseUint(fields[idx+1], 10, 16)
+	if err != nil {
+		return DnskeyRecord{}, fmt.Errorf("invalid DNSKEY record flags: %w", err)
+	}
+
+	if fields[idx+2] != strconv.Itoa(dnskeyProtocol) {
+		return DnskeyRecord{}, fmt.Errorf("invalid DNSKEY record protocol, expected %d", dnskeyProtocol)
+	}
+
+	algorithm, err := strconv.ParseUint(fields[idx+3], 10, 8)
+	if err != nil {
+		return DnskeyRecord{}, fmt.Errorf("invalid DNSKEY record algorithm: %w", err)
+	}
+
+	return DnskeyRecord{
+		Domain:    fields[0],
+		Flags:     uint16(flags),
+		Algorithm: uint8(algorithm),
+		PublicKey: strings.Join(fields[idx+4:], ""),
+	}, nil
+}
@@ -0,0 +1,349 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package edgerule simulates how bunny.net edge rules apply to a request.
+//
+// Patterns are case-insensitive and support the "*" wildcard, which matches any sequence of characters.
+// Patterns starting with "^" are treated as regular expressions instead.
+package edgerule
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/pullzoneedgeruleresourcevalidator"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Request is the synthetic request the edge rules are evaluated against.
+type Request struct {
+	Url             string
+	Method          string
+	Headers         map[string]string
+	ResponseHeaders map[string]string
+	Query           map[string]string
+	Country         string
+	State           string
+	RemoteIp        string
+	StatusCode      int64
+}
+
+// Action is an action triggered by a matching edge rule.
+type Action struct {
+	RuleId     string
+	Type       uint8
+	Parameter1 string
+	Parameter2 string
+	Parameter3 string
+}
+
+// Result lists the matching rules and their actions, in execution order.
+type Result struct {
+	MatchedRules []string
+	Actions      []Action
+}
+
+// Evaluate applies the enabled edge rules, in OrderIndex order, to the request.
+func Evaluate(rules []api.PullzoneEdgerule, request Request) (Result, error) {
+	result := Result{
+		MatchedRules: []string{},
+		Actions:      []Action{},
+	}
+
+	req, err := newEvaluationRequest(request)
+	if err != nil {
+		return result, err
+	}
+
+	sorted := make([]api.PullzoneEdgerule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OrderIndex < sorted[j].OrderIndex
+	})
+
+	for _, rule := range sorted {
+		if !rule.Enabled {
+			continue
+		}
+
+		ruleId := rule.Id
+		if ruleId == "" {
+			ruleId = rule.Description
+		}
+
+		matched, err := matchRule(rule, req)
+		if err != nil {
+			return result, fmt.Errorf("edge rule \"%s\": %w", ruleId, err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		result.MatchedRules = append(result.MatchedRules, ruleId)
+		result.Actions = append(result.Actions, Action{
+			RuleId:     ruleId,
+			Type:       rule.Action,
+			Parameter1: rule.ActionParameter1,
+			Parameter2: rule.ActionParameter2,
+			Parameter3: rule.ActionParameter3,
+		})
+
+		for _, extra := range rule.ExtraActions {
+			result.Actions = append(result.Actions, Action{
+				RuleId:     ruleId,
+				Type:       extra.ActionType,
+				Parameter1: extra.ActionParameter1,
+				Parameter2: extra.ActionParameter2,
+				Parameter3: extra.ActionParameter3,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+type evaluationRequest struct {
+	Request
+	url             *url.URL
+	headers         http.Header
+	responseHeaders http.Header
+	cookies         map[string]string
+	query           url.Values
+}
+
+func newEvaluationRequest(request Request) (evaluationRequest, error) {
+	u, err := url.Parse(request.Url)
+	if err != nil {
+		return evaluationRequest{}, fmt.Errorf("invalid url: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return evaluationRequest{}, errors.New("invalid url: must be absolute, i.e. https://example.com/path")
+	}
+
+	if request.Method == "" {
+		request.Method = http.MethodGet
+	}
+
+	req := evaluationRequest{
+		Request:         request,
+		url:             u,
+		headers:         http.Header{},
+		responseHeaders: http.Header{},
+		cookies:         map[string]string{},
+		query:           u.Query(),
+	}
+
+	for k, v := range request.Headers {
+		req.headers.Set(k, v)
+	}
+
+	for k, v := range request.ResponseHeaders {
+		req.responseHeaders.Set(k, v)
+	}
+
+	for k, v := range request.Query {
+		req.query.Set(k, v)
+	}
+
+	cookieRequest := http.Request{Header: http.Header{"Cookie": req.headers.Values("Cookie")}}
+	for _, cookie := range cookieRequest.Cookies() {
+		req.cookies[cookie.Name] = cookie.Value
+	}
+
+	return req, nil
+}
+
+func matchRule(rule api.PullzoneEdgerule, req evaluationRequest) (bool, error) {
+	if len(rule.Triggers) == 0 {
+		return false, nil
+	}
+
+	results := make([]bool, len(rule.Triggers))
+	for i, trigger := range rule.Triggers {
+		matched, err := matchTrigger(trigger, req)
+		if err != nil {
+			return false, err
+		}
+
+		results[i] = matched
+	}
+
+	return combine(rule.MatchType, results)
+}
+
+func matchTrigger(trigger api.PullzoneEdgeruleTrigger, req evaluationRequest) (bool, error) {
+	var value string
+	var exists = true
+
+	triggerType := pullzoneedgeruleresourcevalidator.TriggerTypeMap[trigger.Type]
+
+	switch triggerType {
+	case "Url":
+		u := *req.url
+		u.Fragment = ""
+		if !patternsContainQuery(trigger.Patterns) {
+			u.RawQuery = ""
+		}
+		value = u.String()
+	case "RequestHeader":
+		value, exists = headerValue(req.headers, trigger.Parameter1)
+	case "ResponseHeader":
+		value, exists = headerValue(req.responseHeaders, trigger.Parameter1)
+	case "UrlExtension":
+		value = strings.TrimPrefix(path.Ext(req.url.Path), ".")
+	case "CountryCode":
+		value = req.Country
+	case "RemoteIP":
+		value = req.RemoteIp
+	case "UrlQueryString":
+		if trigger.Parameter1 == "" {
+			value = req.query.Encode()
+		} else {
+			exists = req.query.Has(trigger.Parameter1)
+			value = req.query.Get(trigger.Parameter1)
+		}
+	case "StatusCode":
+		if req.StatusCode == 0 {
+			exists = false
+		}
+		value = strconv.FormatInt(req.StatusCode, 10)
+	case "RequestMethod":
+		value = req.Method
+	case "CookieValue":
+		value, exists = req.cookies[trigger.Parameter1]
+	case "CountryStateCode":
+		value = req.State
+	default:
+		if triggerType == "" {
+			return false, fmt.Errorf("invalid trigger type %d", trigger.Type)
+		}
+
+		return false, fmt.Errorf("trigger type %s cannot be simulated", triggerType)
+	}
+
+	results := make([]bool, len(trigger.Patterns))
+	for i, pattern := range trigger.Patterns {
+		if !exists {
+			continue
+		}
+
+		var matched bool
+		var err error
+
+		switch triggerType {
+		case "RemoteIP":
+			matched, err = matchIp(pattern, value)
+		case "UrlExtension":
+			matched, err = MatchPattern(strings.TrimPrefix(strings.TrimPrefix(pattern, "*"), "."), value)
+		default:
+			matched, err = MatchPattern(pattern, value)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		results[i] = matched
+	}
+
+	return combine(trigger.MatchType, results)
+}
+
+func combine(matchType uint8, results []bool) (bool, error) {
+	switch pullzoneedgeruleresourcevalidator.TriggerMatchTypeMap[matchType] {
+	case "MatchAny":
+		for _, r := range results {
+			if r {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "MatchAll":
+		for _, r := range results {
+			if !r {
+				return false, nil
+			}
+		}
+		return len(results) > 0, nil
+	case "MatchNone":
+		for _, r := range results {
+			if r {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	return false, fmt.Errorf("invalid match type %d", matchType)
+}
+
+// MatchPattern matches a value against a wildcard pattern or, if it starts with "^", a regular expression.
+func MatchPattern(pattern string, value string) (bool, error) {
+	if strings.HasPrefix(pattern, "^") {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern \"%s\": %w", pattern, err)
+		}
+
+		return re.MatchString(value), nil
+	}
+
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	re := regexp.MustCompile("(?is)^" + strings.Join(parts, ".*") + "$")
+
+	return re.MatchString(value), nil
+}
+
+func matchIp(pattern string, value string) (bool, error) {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return MatchPattern(pattern, value)
+	}
+
+	if strings.Contains(pattern, "/") {
+		_, ipNet, err := net.ParseCIDR(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern \"%s\": %w", pattern, err)
+		}
+
+		return ipNet.Contains(ip), nil
+	}
+
+	if patternIp := net.ParseIP(pattern); patternIp != nil {
+		return patternIp.Equal(ip), nil
+	}
+
+	return MatchPattern(pattern, value)
+}
+
+func headerValue(headers http.Header, name string) (string, bool) {
+	values := headers.Values(name)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	return strings.Join(values, ", "), true
+}
+
+func patternsContainQuery(patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(pattern, "?") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,172 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package edgerule
+
+import (
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/pullzoneedgeruleresourcevalidator"
+	"reflect"
+	"testing"
+)
+
+func triggerType(name string) uint8 {
+	for k, v := range pullzoneedgeruleresourcevalidator.TriggerTypeMap {
+		if v == name {
+			return k
+		}
+	}
+
+	panic("invalid trigger type " + name)
+}
+
+func matchType(name string) uint8 {
+	for k, v := range pullzoneedgeruleresourcevalidator.TriggerMatchTypeMap {
+		if v == name {
+			return k
+		}
+	}
+
+	panic("invalid match type " + name)
+}
+
+func TestMatchPattern(t *testing.T) {
+	type testCase struct {
+		Pattern  string
+		Value    string
+		Expected bool
+	}
+
+	dataProvider := []testCase{
+		{"https://example.com/*", "https://example.com/images/a.jpg", true},
+		{"https://example.com/*", "https://example.org/", false},
+		{"*/images/*", "https://example.com/images/a.jpg", true},
+		{"*/IMAGES/*", "https://example.com/images/a.jpg", true},
+		{"*.jpg", "https://example.com/a.jpeg", false},
+		{"a.b", "axb", false},
+		{"US", "us", true},
+		{"^/api/v[0-9]+/", "/api/v2/users", true},
+		{"^/api/v[0-9]+/", "/API/v2/users", true},
+		{"^/api/v[0-9]+/", "/api/users", false},
+	}
+
+	for _, tc := range dataProvider {
+		result, err := MatchPattern(tc.Pattern, tc.Value)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tc.Pattern, err)
+		}
+
+		if result != tc.Expected {
+			t.Errorf("expected %s to match %s: %t, got %t", tc.Pattern, tc.Value, tc.Expected, result)
+		}
+	}
+
+	if _, err := MatchPattern("^(", "x"); err == nil {
+		t.Error("expected invalid regex to fail")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := []api.PullzoneEdgerule{
+		{
+			Id:         "block-admin",
+			Enabled:    true,
+			Action:     4,
+			MatchType:  matchType("MatchAll"),
+			OrderIndex: 2,
+			Triggers: []api.PullzoneEdgeruleTrigger{
+				{Type: triggerType("Url"), MatchType: matchType("MatchAny"), Patterns: []string{"*/admin/*"}},
+				{Type: triggerType("RemoteIP"), MatchType: matchType("MatchNone"), Patterns: []string{"10.0.0.0/8", "192.0.2.1"}},
+			},
+		},
+		{
+			Id:               "cache-images",
+			Enabled:          true,
+			Action:           3,
+			ActionParameter1: "86400",
+			MatchType:        matchType("MatchAny"),
+			OrderIndex:       1,
+			Triggers: []api.PullzoneEdgeruleTrigger{
+				{Type: triggerType("UrlExtension"), MatchType: matchType("MatchAny"), Patterns: []string{"jpg", "*.png"}},
+			},
+			ExtraActions: []api.PullzoneEdgeruleExtraAction{
+				{ActionType: 5, ActionParameter1: "X-Cache", ActionParameter2: "images"},
+			},
+		},
+		{
+			Id:        "disabled",
+			Enabled:   false,
+			Action:    4,
+			MatchType: matchType("MatchAny"),
+			Triggers: []api.PullzoneEdgeruleTrigger{
+				{Type: triggerType("Url"), MatchType: matchType("MatchAny"), Patterns: []string{"*"}},
+			},
+		},
+		{
+			Id:         "geo",
+			Enabled:    true,
+			Action:     1,
+			MatchType:  matchType("MatchAll"),
+			OrderIndex: 3,
+			Triggers: []api.PullzoneEdgeruleTrigger{
+				{Type: triggerType("CountryCode"), MatchType: matchType("MatchAny"), Patterns: []string{"DE", "AT"}},
+				{Type: triggerType("RequestHeader"), MatchType: matchType("MatchAny"), Parameter1: "accept-language", Patterns: []string{"de*"}},
+				{Type: triggerType("UrlQueryString"), MatchType: matchType("MatchNone"), Parameter1: "lang", Patterns: []string{"*"}},
+				{Type: triggerType("CookieValue"), MatchType: matchType("MatchAny"), Parameter1: "consent", Patterns: []string{"yes"}},
+			},
+		},
+	}
+
+	type testCase struct {
+		Request  Request
+		Expected []string
+	}
+
+	dataProvider := []testCase{
+		{Request{Url: "https://example.com/index.html"}, []string{}},
+		{Request{Url: "https://example.com/a/b.JPG"}, []string{"cache-images"}},
+		{Request{Url: "https://example.com/admin/a.png", RemoteIp: "203.0.113.1"}, []string{"cache-images", "block-admin"}},
+		{Request{Url: "https://example.com/admin/a.png", RemoteIp: "10.1.2.3"}, []string{"cache-images"}},
+		{Request{Url: "https://example.com/", Country: "de", Headers: map[string]string{"Accept-Language": "de-AT", "Cookie": "consent=yes"}}, []string{"geo"}},
+		{Request{Url: "https://example.com/?lang=en", Country: "DE", Headers: map[string]string{"Accept-Language": "de-AT", "Cookie": "consent=yes"}}, []string{}},
+		{Request{Url: "https://example.com/", Country: "DE", Query: map[string]string{"lang": "en"}, Headers: map[string]string{"Accept-Language": "de-AT", "Cookie": "consent=yes"}}, []string{}},
+		{Request{Url: "https://example.com/", Country: "FR", Headers: map[string]string{"Accept-Language": "de-AT", "Cookie": "consent=yes"}}, []string{}},
+	}
+
+	for _, tc := range dataProvider {
+		result, err := Evaluate(rules, tc.Request)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tc.Request.Url, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result.MatchedRules, tc.Expected) {
+			t.Errorf("expected %s to match %v, got %v", tc.Request.Url, tc.Expected, result.MatchedRules)
+		}
+	}
+
+	result, _ := Evaluate(rules, Request{Url: "https://example.com/a.png"})
+	if len(result.Actions) != 2 || result.Actions[1].Type != 5 || result.Actions[1].Parameter2 != "images" {
+		t.Errorf("expected extra actions to be returned, got %+v", result.Actions)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	rules := []api.PullzoneEdgerule{
+		{
+			Id:      "random",
+			Enabled: true,
+			Triggers: []api.PullzoneEdgeruleTrigger{
+				{Type: triggerType("RandomChance"), Patterns: []string{"50"}},
+			},
+		},
+	}
+
+	if _, err := Evaluate(rules, Request{Url: "https://example.com/"}); err == nil {
+		t.Error("expected unsupported trigger to fail")
+	}
+
+	if _, err := Evaluate(nil, Request{Url: "/relative"}); err == nil {
+		t.Error("expected relative URL to fail")
+	}
+}
@@ -116,6 +116,12 @@ func generateFromOpenApiSchema() []GenResult {
 			SchemaKey: "DnsMonitoringType",
 			Type:      "map[uint8]string",
 		},
+		{
+			File:      FileinfoProvider,
+			Variable:  "dnsRecordMonitorStatusMap",
+			SchemaKey: "DnsMonitoringStatus",
+			Type:      "map[uint8]string",
+		},
 		{
 			File:      FileinfoProvider,
 			Variable:  "dnsRecordSmartRoutingTypeMap",
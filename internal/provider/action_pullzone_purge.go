@@ -0,0 +1,123 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.Action = &PullzonePurgeAction{}
+var _ action.ActionWithConfigure = &PullzonePurgeAction{}
+
+func NewPullzonePurgeAction() action.Action {
+	return &PullzonePurgeAction{}
+}
+
+type PullzonePurgeAction struct {
+	client *api.Client
+}
+
+type PullzonePurgeActionModel struct {
+	PullzoneId types.Int64  `tfsdk:"pullzone"`
+	CacheTag   types.String `tfsdk:"cache_tag"`
+	Paths      types.Set    `tfsdk:"paths"`
+	Urls       types.Set    `tfsdk:"urls"`
+}
+
+func (a *PullzonePurgeAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_purge"
+}
+
+func (a *PullzonePurgeAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This action purges the cache of a bunny.net pullzone. If neither `cache_tag`, `paths` nor `urls` are set, the whole pullzone cache is purged.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required:    true,
+				Description: pullzonePurgeDescription.PullzoneId,
+			},
+			"cache_tag": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("paths"), path.MatchRoot("urls")),
+				},
+				Description: pullzonePurgeDescription.CacheTag,
+			},
+			"paths": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				MarkdownDescription: pullzonePurgeDescription.Paths,
+			},
+			"urls": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(pullzonePurgeUrlRegex, "must be an absolute http(s) URL")),
+				},
+				MarkdownDescription: pullzonePurgeDescription.Urls,
+			},
+		},
+	}
+}
+
+func (a *PullzonePurgeAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = client
+}
+
+func (a *PullzonePurgeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data PullzonePurgeActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var paths []string
+	resp.Diagnostics.Append(data.Paths.ElementsAs(ctx, &paths, true)...)
+
+	var urls []string
+	resp.Diagnostics.Append(data.Urls.ElementsAs(ctx, &urls, true)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Purging pullzone %d", data.PullzoneId.ValueInt64()),
+	})
+
+	err := pullzonePurge(ctx, a.client, data.PullzoneId.ValueInt64(), data.CacheTag.ValueString(), paths, urls)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to purge pullzone cache", err.Error())
+		return
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/customtype"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -58,6 +59,7 @@ func (d *DnsRecordDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Description: dnsRecordDescription.TTL,
 			},
 			"value": schema.StringAttribute{
+				CustomType:  customtype.DnsRecordValueType{},
 				Computed:    true,
 				Description: dnsRecordDescription.Value,
 			},
@@ -101,6 +103,10 @@ func (d *DnsRecordDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Computed:            true,
 				MarkdownDescription: dnsRecordDescription.MonitorType,
 			},
+			"monitor_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsRecordDescription.MonitorStatus,
+			},
 			"geolocation_lat": schema.Float64Attribute{
 				Computed:    true,
 				Description: dnsRecordDescription.GeolocationLat,
@@ -126,6 +132,17 @@ func (d *DnsRecordDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				Description: dnsRecordDescription.PullzoneId,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"monitor": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: dnsRecordDescription.MonitorType,
+					},
+				},
+				Description: dnsRecordDescription.Monitor,
+			},
+		},
 	}
 }
 
@@ -0,0 +1,136 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &DnsRecordHealthDataSource{}
+var _ datasource.DataSourceWithConfigure = &DnsRecordHealthDataSource{}
+
+func NewDnsRecordHealthDataSource() datasource.DataSource {
+	return &DnsRecordHealthDataSource{}
+}
+
+type DnsRecordHealthDataSource struct {
+	client *api.Client
+}
+
+type DnsRecordHealthDataSourceModel struct {
+	Zone types.Int64 `tfsdk:"zone"`
+	Data types.List  `tfsdk:"data"`
+}
+
+var dnsRecordHealthDataSourceType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":             types.Int64Type,
+		"name":           types.StringType,
+		"type":           types.StringType,
+		"value":          types.StringType,
+		"enabled":        types.BoolType,
+		"monitor_type":   types.StringType,
+		"monitor_status": types.StringType,
+	},
+}
+
+func (d *DnsRecordHealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record_health"
+}
+
+func (d *DnsRecordHealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the monitored DNS records of a zone in [Bunny DNS](https://bunny.net/dns/) and their current health status.",
+
+		Attributes: map[string]schema.Attribute{
+			"zone": schema.Int64Attribute{
+				Required:    true,
+				Description: dnsRecordDescription.Zone,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"data": schema.ListAttribute{
+				ElementType: dnsRecordHealthDataSourceType,
+				Computed:    true,
+				Description: "The DNS records with monitoring enabled.",
+			},
+		},
+	}
+}
+
+func (d *DnsRecordHealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DnsRecordHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DnsRecordHealthDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := d.client.GetDnsZone(ctx, data.Zone.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch DNS zone", err.Error())
+		return
+	}
+
+	values := make([]attr.Value, 0, len(zone.Records))
+	for _, record := range zone.Records {
+		if record.MonitorType == 0 {
+			continue
+		}
+
+		obj, diags := types.ObjectValue(dnsRecordHealthDataSourceType.AttrTypes, map[string]attr.Value{
+			"id":             types.Int64Value(record.Id),
+			"name":           types.StringValue(record.Name),
+			"type":           types.StringValue(mapKeyToValue(dnsRecordTypeMap, record.Type)),
+			"value":          types.StringValue(record.Value),
+			"enabled":        types.BoolValue(!record.Disabled),
+			"monitor_type":   types.StringValue(mapKeyToValue(dnsRecordMonitorTypeMap, record.MonitorType)),
+			"monitor_status": types.StringValue(mapKeyToValue(dnsRecordMonitorStatusMap, record.MonitorStatus)),
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		values = append(values, obj)
+	}
+
+	dataList, diags := types.ListValue(dnsRecordHealthDataSourceType, values)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.Data = dataList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -95,6 +95,18 @@ func (d *DnsZoneDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 				MarkdownDescription: dnsZoneDescription.DnssecKeyTag,
 			},
+			"dnssec_ds_record": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsZoneDescription.DnssecDsRecord,
+			},
+			"dnssec_dnskey_record": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsZoneDescription.DnssecDnskeyRecord,
+			},
+			"dnssec_ds_configured": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsZoneDescription.DnssecDsConfigured,
+			},
 		},
 	}
 }
@@ -0,0 +1,202 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/slices"
+	"regexp"
+	"strings"
+)
+
+var _ datasource.DataSource = &DnsZonesDataSource{}
+var _ datasource.DataSourceWithConfigure = &DnsZonesDataSource{}
+
+func NewDnsZonesDataSource() datasource.DataSource {
+	return &DnsZonesDataSource{}
+}
+
+type DnsZonesDataSource struct {
+	client *api.Client
+}
+
+type DnsZonesDataSourceModel struct {
+	DomainSuffix     types.String `tfsdk:"domain_suffix"`
+	DomainRegex      types.String `tfsdk:"domain_regex"`
+	DnssecEnabled    types.Bool   `tfsdk:"dnssec_enabled"`
+	LogEnabled       types.Bool   `tfsdk:"log_enabled"`
+	NameserverCustom types.Bool   `tfsdk:"nameserver_custom"`
+	Ids              types.List   `tfsdk:"ids"`
+	Data             types.List   `tfsdk:"data"`
+}
+
+var dnsZonesDataSourceType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                types.Int64Type,
+		"domain":            types.StringType,
+		"nameserver_custom": types.BoolType,
+		"nameserver1":       types.StringType,
+		"nameserver2":       types.StringType,
+		"log_enabled":       types.BoolType,
+		"dnssec_enabled":    types.BoolType,
+	},
+}
+
+func (d *DnsZonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zones"
+}
+
+func (d *DnsZonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the DNS zones in [Bunny DNS](https://bunny.net/dns/), optionally filtered.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain_suffix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include zones whose domain is, or is a subdomain of, this value.",
+			},
+			"domain_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include zones whose domain matches this regular expression.",
+			},
+			"dnssec_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include zones with DNSSEC enabled or disabled.",
+			},
+			"log_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include zones with logging enabled or disabled.",
+			},
+			"nameserver_custom": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include zones with custom nameservers enabled or disabled.",
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "The IDs of the matching DNS zones.",
+			},
+			"data": schema.ListAttribute{
+				ElementType: dnsZonesDataSourceType,
+				Computed:    true,
+				Description: "The matching DNS zones, ordered by domain.",
+			},
+		},
+	}
+}
+
+func (d *DnsZonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DnsZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DnsZonesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var domainRegex *regexp.Regexp
+	if !data.DomainRegex.IsNull() {
+		var err error
+		domainRegex, err = regexp.Compile(data.DomainRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("domain_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	zones, err := d.client.GetDnsZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch DNS zones", err.Error())
+		return
+	}
+
+	suffix := strings.ToLower(strings.Trim(data.DomainSuffix.ValueString(), "."))
+	ids := []attr.Value{}
+	objs := []attr.Value{}
+
+	slices.SortFunc(zones, func(a, b api.DnsZone) int {
+		return strings.Compare(a.Domain, b.Domain)
+	})
+
+	for _, zone := range zones {
+		domain := strings.ToLower(zone.Domain)
+		if suffix != "" && domain != suffix && !strings.HasSuffix(domain, "."+suffix) {
+			continue
+		}
+
+		if domainRegex != nil && !domainRegex.MatchString(zone.Domain) {
+			continue
+		}
+
+		if !data.DnssecEnabled.IsNull() && data.DnssecEnabled.ValueBool() != zone.DnssecEnabled {
+			continue
+		}
+
+		if !data.LogEnabled.IsNull() && data.LogEnabled.ValueBool() != zone.LoggingEnabled {
+			continue
+		}
+
+		if !data.NameserverCustom.IsNull() && data.NameserverCustom.ValueBool() != zone.CustomNameserversEnabled {
+			continue
+		}
+
+		obj, diags := types.ObjectValue(dnsZonesDataSourceType.AttrTypes, map[string]attr.Value{
+			"id":                types.Int64Value(zone.Id),
+			"domain":            types.StringValue(zone.Domain),
+			"nameserver_custom": types.BoolValue(zone.CustomNameserversEnabled),
+			"nameserver1":       types.StringValue(zone.Nameserver1),
+			"nameserver2":       types.StringValue(zone.Nameserver2),
+			"log_enabled":       types.BoolValue(zone.LoggingEnabled),
+			"dnssec_enabled":    types.BoolValue(zone.DnssecEnabled),
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		ids = append(ids, types.Int64Value(zone.Id))
+		objs = append(objs, obj)
+	}
+
+	idsList, diags := types.ListValue(types.Int64Type, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	dataList, diags := types.ListValue(dnsZonesDataSourceType, objs)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.Ids = idsList
+	data.Data = dataList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const configDnsZonesDataSourceTest = `
+resource "bunnynet_dns_zone" "signed" {
+  domain         = "signed.terraform-acc-%[1]s.internal"
+  dnssec_enabled = true
+}
+
+resource "bunnynet_dns_zone" "unsigned" {
+  domain         = "unsigned.terraform-acc-%[1]s.internal"
+  dnssec_enabled = false
+}
+
+data "bunnynet_dns_zones" "suffix" {
+  domain_suffix = "terraform-acc-%[1]s.internal"
+  depends_on    = [bunnynet_dns_zone.signed, bunnynet_dns_zone.unsigned]
+}
+
+data "bunnynet_dns_zones" "dnssec" {
+  domain_suffix  = "terraform-acc-%[1]s.internal"
+  dnssec_enabled = true
+  depends_on     = [bunnynet_dns_zone.signed, bunnynet_dns_zone.unsigned]
+}
+
+data "bunnynet_dns_zones" "regex" {
+  domain_regex = "^unsigned\\.terraform-acc-%[1]s\\."
+  depends_on   = [bunnynet_dns_zone.signed, bunnynet_dns_zone.unsigned]
+}
+
+data "bunnynet_dns_zones" "none" {
+  domain_suffix = "terraform-acc-%[1]s.internal"
+  log_enabled   = true
+  depends_on    = [bunnynet_dns_zone.signed, bunnynet_dns_zone.unsigned]
+}
+`
+
+func TestAccDnsZonesDataSource(t *testing.T) {
+	testKey := generateRandomString(8)
+	signed := fmt.Sprintf("signed.terraform-acc-%s.internal", testKey)
+	unsigned := fmt.Sprintf("unsigned.terraform-acc-%s.internal", testKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configDnsZonesDataSourceTest, testKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// ordered by domain
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.suffix", "ids.#", "2"),
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.suffix", "data.0.domain", signed),
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.suffix", "data.1.domain", unsigned),
+					resource.TestCheckResourceAttrPair("data.bunnynet_dns_zones.suffix", "ids.0", "bunnynet_dns_zone.signed", "id"),
+
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.dnssec", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.dnssec", "data.0.domain", signed),
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.dnssec", "data.0.dnssec_enabled", "true"),
+
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.regex", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.regex", "data.0.domain", unsigned),
+
+					resource.TestCheckResourceAttr("data.bunnynet_dns_zones.none", "ids.#", "0"),
+				),
+			},
+			{
+				Config:      `data "bunnynet_dns_zones" "test" { domain_regex = "(" }`,
+				ExpectError: regexp.MustCompile("Invalid regular expression"),
+			},
+		},
+	})
+}
@@ -11,6 +11,7 @@ import (
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ datasource.DataSource = &PullzoneDataSource{}
@@ -24,6 +25,11 @@ type PullzoneDataSource struct {
 	client *api.Client
 }
 
+type PullzoneDataSourceModel struct {
+	PullzoneResourceModel
+	Hostname types.String `tfsdk:"hostname"`
+}
+
 func (d *PullzoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_pullzone"
 }
@@ -44,6 +50,10 @@ func (d *PullzoneDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 	schemaBlocks := make(map[string]dschema.Block, len(rResp.Schema.Blocks))
 
 	for k, v := range rResp.Schema.Attributes {
+		if k == "copy_from" {
+			continue
+		}
+
 		if k == "id" {
 			schemaAttributes[k] = dschema.Int64Attribute{
 				Optional:            true,
@@ -67,6 +77,11 @@ func (d *PullzoneDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		schemaAttributes[k] = resourceAttrToDatasourceAttr(v)
 	}
 
+	schemaAttributes["hostname"] = dschema.StringAttribute{
+		Optional:    true,
+		Description: "Find the pullzone by one of its hostnames, instead of by `id` or `name`.",
+	}
+
 	for k, v := range rResp.Schema.Blocks {
 		rBlock := v.(rschema.SingleNestedBlock)
 		blockAttributes := make(map[string]dschema.Attribute, len(rBlock.Attributes))
@@ -107,7 +122,7 @@ func (d *PullzoneDataSource) Configure(ctx context.Context, req datasource.Confi
 }
 
 func (d *PullzoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data PullzoneResourceModel
+	var data PullzoneDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -116,14 +131,22 @@ func (d *PullzoneDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	id := data.Id.ValueInt64()
 	name := data.Name.ValueString()
+	hostname := data.Hostname.ValueString()
+
+	identifiers := 0
+	for _, isSet := range []bool{id > 0, name != "", hostname != ""} {
+		if isSet {
+			identifiers++
+		}
+	}
 
-	if id == 0 && name == "" {
-		resp.Diagnostics.AddError("Missing identifier attribute", "Either `id` or `name` attribute must be specified.")
+	if identifiers == 0 {
+		resp.Diagnostics.AddError("Missing identifier attribute", "Either `id`, `name` or `hostname` attribute must be specified.")
 		return
 	}
 
-	if id > 0 && name != "" {
-		resp.Diagnostics.AddError("Ambiguous identifier attribute", "Only one of `id` or `name` attribute must be specified.")
+	if identifiers > 1 {
+		resp.Diagnostics.AddError("Ambiguous identifier attribute", "Only one of `id`, `name` or `hostname` attribute must be specified.")
 		return
 	}
 
@@ -132,8 +155,10 @@ func (d *PullzoneDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	if id > 0 {
 		zone, err = d.client.GetPullzone(id)
-	} else {
+	} else if name != "" {
 		zone, err = d.client.GetPullzoneByName(ctx, name)
+	} else {
+		zone, err = d.client.GetPullzoneByHostname(ctx, hostname)
 	}
 
 	if err != nil {
@@ -147,5 +172,8 @@ func (d *PullzoneDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataResult)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &PullzoneDataSourceModel{
+		PullzoneResourceModel: dataResult,
+		Hostname:              data.Hostname,
+	})...)
 }
@@ -0,0 +1,149 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &PullzoneRatelimitRulesDataSource{}
+var _ datasource.DataSourceWithConfigure = &PullzoneRatelimitRulesDataSource{}
+
+func NewPullzoneRatelimitRulesDataSource() datasource.DataSource {
+	return &PullzoneRatelimitRulesDataSource{}
+}
+
+type PullzoneRatelimitRulesDataSource struct {
+	client *api.Client
+}
+
+type PullzoneRatelimitRulesDataSourceModel struct {
+	Pullzone types.Int64 `tfsdk:"pullzone"`
+	Rules    types.List  `tfsdk:"rules"`
+}
+
+var pullzoneRatelimitRulesRuleType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                types.Int64Type,
+		"name":              types.StringType,
+		"description":       types.StringType,
+		"limit_requests":    types.Int64Type,
+		"limit_interval":    types.Int64Type,
+		"response_interval": types.Int64Type,
+	},
+}
+
+func (d *PullzoneRatelimitRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_ratelimit_rules"
+}
+
+func (d *PullzoneRatelimitRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the ratelimit rules of a bunny.net pullzone shield, i.e. the rules managed by `bunnynet_pullzone_ratelimit_rule`.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the linked pullzone.",
+			},
+			"rules": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The ratelimit rules.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The ID of the rule.",
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"limit_requests": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of requests allowed within `limit_interval`.",
+						},
+						"limit_interval": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The interval, in seconds, in which requests are counted.",
+						},
+						"response_interval": schema.Int64Attribute{
+							Computed:    true,
+							Description: "For how long, in seconds, a client is blocked once the limit is reached.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PullzoneRatelimitRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PullzoneRatelimitRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PullzoneRatelimitRulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := d.client.GetPullzoneRatelimitRules(ctx, data.Pullzone.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch ratelimit rules", err.Error())
+		return
+	}
+
+	ruleValues := make([]attr.Value, 0, len(rules))
+	for _, rule := range rules {
+		ruleValue, diags := types.ObjectValue(pullzoneRatelimitRulesRuleType.AttrTypes, map[string]attr.Value{
+			"id":                types.Int64Value(rule.Id),
+			"name":              types.StringValue(rule.Name),
+			"description":       types.StringValue(rule.Description),
+			"limit_requests":    types.Int64Value(rule.RuleConfiguration.RequestCount),
+			"limit_interval":    types.Int64Value(rule.RuleConfiguration.Timeframe),
+			"response_interval": types.Int64Value(rule.RuleConfiguration.BlockTime),
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		ruleValues = append(ruleValues, ruleValue)
+	}
+
+	rulesList, diags := types.ListValue(pullzoneRatelimitRulesRuleType, ruleValues)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.Rules = rulesList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
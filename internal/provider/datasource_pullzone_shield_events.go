@@ -0,0 +1,261 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+var _ datasource.DataSource = &PullzoneShieldEventsDataSource{}
+var _ datasource.DataSourceWithConfigure = &PullzoneShieldEventsDataSource{}
+
+func NewPullzoneShieldEventsDataSource() datasource.DataSource {
+	return &PullzoneShieldEventsDataSource{}
+}
+
+type PullzoneShieldEventsDataSource struct {
+	client *api.Client
+}
+
+type PullzoneShieldEventsDataSourceModel struct {
+	Pullzone   types.Int64  `tfsdk:"pullzone"`
+	From       types.String `tfsdk:"from"`
+	To         types.String `tfsdk:"to"`
+	RuleIds    types.Set    `tfsdk:"rule_ids"`
+	Actions    types.Set    `tfsdk:"actions"`
+	Countries  types.Set    `tfsdk:"countries"`
+	Limit      types.Int64  `tfsdk:"limit"`
+	Total      types.Int64  `tfsdk:"total"`
+	RuleCounts types.Map    `tfsdk:"rule_counts"`
+	Events     types.List   `tfsdk:"events"`
+}
+
+const pullzoneShieldEventsDefaultWindow = 24 * time.Hour
+const pullzoneShieldEventsDefaultLimit = 100
+
+var pullzoneShieldEventType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":        types.StringType,
+		"timestamp": types.StringType,
+		"rule_id":   types.StringType,
+		"action":    types.StringType,
+		"country":   types.StringType,
+		"remote_ip": types.StringType,
+		"method":    types.StringType,
+		"url":       types.StringType,
+		"message":   types.StringType,
+	},
+}
+
+func (d *PullzoneShieldEventsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_shield_events"
+}
+
+func (d *PullzoneShieldEventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source reads the security events logged by the shield of a pullzone, optionally filtered, and counts them per rule. Use it with `check` blocks to detect rules matching more traffic than expected. The counts are computed from the event log, not from the shield metrics, so requests that were not logged are not included.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the linked pullzone.",
+			},
+			"from": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The start of the time window, in RFC 3339 format. Defaults to 24 hours before `to`.",
+			},
+			"to": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The end of the time window, in RFC 3339 format. Defaults to the current time.",
+			},
+			"rule_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only include events triggered by these rules.",
+			},
+			"actions": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("block", "challenge", "log")),
+				},
+				MarkdownDescription: "Only include events with these actions. Options: `block`, `challenge`, `log`.",
+			},
+			"countries": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only include events from these countries, as ISO 3166-1 alpha-2 codes.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 10000),
+				},
+				MarkdownDescription: fmt.Sprintf("The maximum number of events in `events`, the most recent ones are kept. It does not affect `total` and `rule_counts`. Defaults to `%d`.", pullzoneShieldEventsDefaultLimit),
+			},
+			"total": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of matching events.",
+			},
+			"rule_counts": schema.MapAttribute{
+				ElementType:         types.Int64Type,
+				Computed:            true,
+				MarkdownDescription: "The number of matching events, indexed by rule ID. Events not attributed to a rule are only included in `total`.",
+			},
+			"events": schema.ListAttribute{
+				ElementType: pullzoneShieldEventType,
+				Computed:    true,
+				Description: "The matching events, oldest first.",
+			},
+		},
+	}
+}
+
+func (d *PullzoneShieldEventsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PullzoneShieldEventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PullzoneShieldEventsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// time window
+	to := time.Now().UTC()
+	if !data.To.IsNull() {
+		var err error
+		to, err = time.Parse(time.RFC3339, data.To.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("to"), "Invalid time", err.Error())
+			return
+		}
+	}
+
+	from := to.Add(-pullzoneShieldEventsDefaultWindow)
+	if !data.From.IsNull() {
+		var err error
+		from, err = time.Parse(time.RFC3339, data.From.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("from"), "Invalid time", err.Error())
+			return
+		}
+	}
+
+	if to.Before(from) {
+		resp.Diagnostics.AddAttributeError(path.Root("to"), "Invalid time window", "to must not be before from")
+		return
+	}
+
+	filter := api.PullzoneShieldEventFilter{
+		From:      from,
+		To:        to,
+		RuleIds:   utils.ConvertSetToStringSlice(data.RuleIds),
+		Actions:   utils.ConvertSetToStringSlice(data.Actions),
+		Countries: utils.ConvertSetToStringSlice(data.Countries),
+	}
+
+	events, err := d.client.GetPullzoneShieldEvents(ctx, data.Pullzone.ValueInt64(), filter)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch shield events", err.Error())
+		return
+	}
+
+	limit := int64(pullzoneShieldEventsDefaultLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	ruleCounts := pullzoneShieldEventsRuleCounts(events)
+
+	limited := events
+	if int64(len(limited)) > limit {
+		limited = limited[int64(len(limited))-limit:]
+	}
+
+	eventValues := make([]attr.Value, 0, len(limited))
+	for _, event := range limited {
+		eventValue, diags := types.ObjectValue(pullzoneShieldEventType.AttrTypes, map[string]attr.Value{
+			"id":        types.StringValue(event.Id),
+			"timestamp": types.StringValue(event.Timestamp.Format(time.RFC3339)),
+			"rule_id":   types.StringValue(event.RuleId),
+			"action":    types.StringValue(event.Action),
+			"country":   types.StringValue(event.Country),
+			"remote_ip": types.StringValue(event.RemoteIp),
+			"method":    types.StringValue(event.Method),
+			"url":       types.StringValue(event.Url),
+			"message":   types.StringValue(event.Message),
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		eventValues = append(eventValues, eventValue)
+	}
+
+	var diags diag.Diagnostics
+	data.From = types.StringValue(from.Format(time.RFC3339))
+	data.To = types.StringValue(to.Format(time.RFC3339))
+	data.Total = types.Int64Value(int64(len(events)))
+
+	data.RuleCounts, diags = types.MapValueFrom(ctx, types.Int64Type, ruleCounts)
+	resp.Diagnostics.Append(diags...)
+
+	data.Events, diags = types.ListValue(pullzoneShieldEventType, eventValues)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// pullzoneShieldEventsRuleCounts counts the events per rule, skipping the events not attributed to a rule.
+func pullzoneShieldEventsRuleCounts(events []api.PullzoneShieldEvent) map[string]int64 {
+	ruleCounts := map[string]int64{}
+	for _, event := range events {
+		if event.RuleId == "" {
+			continue
+		}
+
+		ruleCounts[event.RuleId]++
+	}
+
+	return ruleCounts
+}
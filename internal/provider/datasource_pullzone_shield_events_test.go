@@ -0,0 +1,92 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestPullzoneShieldEventsRuleCounts(t *testing.T) {
+	type dataType struct {
+		Expected map[string]int64
+		RuleIds  []string
+	}
+
+	dataProvider := []dataType{
+		{map[string]int64{}, []string{}},
+		{map[string]int64{}, []string{""}},
+		{map[string]int64{"913100": 2, "rl-1": 1}, []string{"913100", "", "rl-1", "913100"}},
+	}
+
+	for _, data := range dataProvider {
+		events := make([]api.PullzoneShieldEvent, 0, len(data.RuleIds))
+		for _, ruleId := range data.RuleIds {
+			events = append(events, api.PullzoneShieldEvent{RuleId: ruleId})
+		}
+
+		result := pullzoneShieldEventsRuleCounts(events)
+		if !reflect.DeepEqual(result, data.Expected) {
+			t.Errorf("Expected %v to return %v, got %v", data.RuleIds, data.Expected, result)
+		}
+	}
+}
+
+const configPullzoneShieldEventsDataSourceTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_shield" "test" {
+  pullzone = bunnynet_pullzone.test.id
+
+  ddos {
+    level = "Medium"
+    mode  = "Log"
+  }
+}
+
+data "bunnynet_pullzone_shield_events" "test" {
+  pullzone = bunnynet_pullzone_shield.test.pullzone
+  from     = "2026-01-01T00:00:00Z"
+  to       = "2026-01-01T12:00:00Z"
+  actions  = ["block"]
+  limit    = 10
+}
+`
+
+func TestAccPullzoneShieldEventsDataSource(t *testing.T) {
+	dataSourceName := "data.bunnynet_pullzone_shield_events.test"
+	testKey := generateRandomString(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configPullzoneShieldEventsDataSourceTest, testKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "from", "2026-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "to", "2026-01-01T12:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "total", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule_counts.%", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "events.#", "0"),
+				),
+			},
+		},
+	})
+}
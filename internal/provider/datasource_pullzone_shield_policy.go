@@ -0,0 +1,100 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/shieldpolicy"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &PullzoneShieldPolicyDataSource{}
+var _ datasource.DataSourceWithConfigure = &PullzoneShieldPolicyDataSource{}
+
+func NewPullzoneShieldPolicyDataSource() datasource.DataSource {
+	return &PullzoneShieldPolicyDataSource{}
+}
+
+type PullzoneShieldPolicyDataSource struct {
+	client *api.Client
+}
+
+type PullzoneShieldPolicyDataSourceModel struct {
+	Pullzone types.Int64  `tfsdk:"pullzone"`
+	Version  types.Int64  `tfsdk:"version"`
+	Document types.String `tfsdk:"document"`
+}
+
+func (d *PullzoneShieldPolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_shield_policy"
+}
+
+func (d *PullzoneShieldPolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source exports the whole shield configuration of a pullzone as a versioned JSON document: the shield settings and WAF engine config, custom WAF rules, ratelimit rules, custom access lists and enabled curated access lists. The document can be applied to another pullzone with the `bunnynet_pullzone_shield_policy` resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the linked pullzone.",
+			},
+			"version": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The version of the document format.",
+			},
+			"document": schema.StringAttribute{
+				Computed:    true,
+				Description: "The shield policy, as a JSON document.",
+			},
+		},
+	}
+}
+
+func (d *PullzoneShieldPolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PullzoneShieldPolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PullzoneShieldPolicyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	doc, _, err := pullzoneShieldPolicyFetch(ctx, d.client, data.Pullzone.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch shield policy", err.Error())
+		return
+	}
+
+	content, err := doc.Marshal()
+	if err != nil {
+		resp.Diagnostics.AddError("Could not encode shield policy", err.Error())
+		return
+	}
+
+	data.Version = types.Int64Value(shieldpolicy.Version)
+	data.Document = types.StringValue(content)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,197 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &PullzoneShieldWafRulesDataSource{}
+var _ datasource.DataSourceWithConfigure = &PullzoneShieldWafRulesDataSource{}
+
+func NewPullzoneShieldWafRulesDataSource() datasource.DataSource {
+	return &PullzoneShieldWafRulesDataSource{}
+}
+
+type PullzoneShieldWafRulesDataSource struct {
+	client *api.Client
+}
+
+type PullzoneShieldWafRulesDataSourceModel struct {
+	Groups types.List `tfsdk:"groups"`
+	Rules  types.Map  `tfsdk:"rules"`
+}
+
+var pullzoneShieldWafRulesRuleType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":          types.StringType,
+		"description": types.StringType,
+		"category":    types.StringType,
+		"group":       types.StringType,
+	},
+}
+
+var pullzoneShieldWafRulesGroupType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":          types.StringType,
+		"name":        types.StringType,
+		"description": types.StringType,
+		"rules":       types.ListType{ElemType: pullzoneShieldWafRulesRuleType},
+	},
+}
+
+func (d *PullzoneShieldWafRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_shield_waf_rules"
+}
+
+func (d *PullzoneShieldWafRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ruleAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the rule, to be used in `bunnynet_pullzone_shield.waf.rules_disabled` and `bunnynet_pullzone_shield.waf.rules_logonly`.",
+		},
+		"description": schema.StringAttribute{
+			Computed: true,
+		},
+		"category": schema.StringAttribute{
+			Computed:    true,
+			Description: "The name of the category the rule group belongs to.",
+		},
+		"group": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the rule group.",
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "This data source lists the managed WAF rules available to bunny.net pullzone shields. The API does not expose a default action per rule: every managed rule uses the `waf.mode` of the shield, unless it is listed in `waf.rules_disabled` or `waf.rules_logonly`.",
+
+		Attributes: map[string]schema.Attribute{
+			"groups": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The managed rule groups.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the rule group. A whole group can be disabled or set to log-only by its ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"rules": schema.ListNestedAttribute{
+							Computed: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: ruleAttributes,
+							},
+						},
+					},
+				},
+			},
+			"rules": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "The managed rules, indexed by their ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: ruleAttributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *PullzoneShieldWafRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PullzoneShieldWafRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PullzoneShieldWafRulesDataSourceModel
+
+	groups, err := d.client.GetPullzoneShieldWafManagedRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch WAF rules", err.Error())
+		return
+	}
+
+	groupValues := make([]attr.Value, 0, len(groups))
+	ruleValues := map[string]attr.Value{}
+
+	for _, group := range groups {
+		rules := make([]attr.Value, 0, len(group.Rules))
+
+		for _, rule := range group.Rules {
+			ruleValue, diags := types.ObjectValue(pullzoneShieldWafRulesRuleType.AttrTypes, map[string]attr.Value{
+				"id":          types.StringValue(rule.Id),
+				"description": types.StringValue(rule.Description),
+				"category":    types.StringValue(rule.Category),
+				"group":       types.StringValue(group.Id),
+			})
+
+			if diags.HasError() {
+				resp.Diagnostics.Append(diags...)
+				return
+			}
+
+			rules = append(rules, ruleValue)
+			ruleValues[rule.Id] = ruleValue
+		}
+
+		rulesList, diags := types.ListValue(pullzoneShieldWafRulesRuleType, rules)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		groupValue, diags := types.ObjectValue(pullzoneShieldWafRulesGroupType.AttrTypes, map[string]attr.Value{
+			"id":          types.StringValue(group.Id),
+			"name":        types.StringValue(group.Name),
+			"description": types.StringValue(group.Description),
+			"rules":       rulesList,
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		groupValues = append(groupValues, groupValue)
+	}
+
+	var diags diag.Diagnostics
+	data.Groups, diags = types.ListValue(pullzoneShieldWafRulesGroupType, groupValues)
+	resp.Diagnostics.Append(diags...)
+
+	data.Rules, diags = types.MapValue(pullzoneShieldWafRulesRuleType, ruleValues)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,39 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const configPullzoneShieldWafRulesDataSourceTest = `
+data "bunnynet_pullzone_shield_waf_rules" "test" {}
+`
+
+func TestAccPullzoneShieldWafRulesDataSource(t *testing.T) {
+	dataSourceName := "data.bunnynet_pullzone_shield_waf_rules.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configPullzoneShieldWafRulesDataSourceTest,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(dataSourceName, "groups.#", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestMatchResourceAttr(dataSourceName, "groups.0.rules.#", regexp.MustCompile(`^[1-9][0-9]*$`)),
+					resource.TestCheckResourceAttrSet(dataSourceName, "groups.0.id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "groups.0.name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "groups.0.rules.0.id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "groups.0.rules.0.category"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "groups.0.rules.0.group", dataSourceName, "groups.0.id"),
+					resource.TestCheckResourceAttr(dataSourceName, "rules.913100.group", "REQUEST-913-SCANNER-DETECTION"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &PullzoneWafRulesDataSource{}
+var _ datasource.DataSourceWithConfigure = &PullzoneWafRulesDataSource{}
+
+func NewPullzoneWafRulesDataSource() datasource.DataSource {
+	return &PullzoneWafRulesDataSource{}
+}
+
+type PullzoneWafRulesDataSource struct {
+	client *api.Client
+}
+
+type PullzoneWafRulesDataSourceModel struct {
+	Pullzone types.Int64 `tfsdk:"pullzone"`
+	Rules    types.List  `tfsdk:"rules"`
+}
+
+var pullzoneWafRulesRuleType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":          types.Int64Type,
+		"name":        types.StringType,
+		"description": types.StringType,
+		"action":      types.StringType,
+	},
+}
+
+func (d *PullzoneWafRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_waf_rules"
+}
+
+func (d *PullzoneWafRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the custom WAF rules of a bunny.net pullzone shield, i.e. the rules managed by `bunnynet_pullzone_waf_rule`.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the linked pullzone.",
+			},
+			"rules": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The custom WAF rules.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The ID of the rule.",
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"action": schema.StringAttribute{
+							Computed:    true,
+							Description: "The action to take if the WAF rule is triggered. " + generateMarkdownMapOptions(pullzoneShieldWafRuleResponseActionMap),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PullzoneWafRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PullzoneWafRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PullzoneWafRulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := d.client.GetPullzoneWafRules(ctx, data.Pullzone.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch WAF rules", err.Error())
+		return
+	}
+
+	ruleValues := make([]attr.Value, 0, len(rules))
+	for _, rule := range rules {
+		ruleValue, diags := types.ObjectValue(pullzoneWafRulesRuleType.AttrTypes, map[string]attr.Value{
+			"id":          types.Int64Value(rule.Id),
+			"name":        types.StringValue(rule.Name),
+			"description": types.StringValue(rule.Description),
+			"action":      types.StringValue(mapKeyToValue(pullzoneShieldWafRuleResponseActionMap, rule.RuleConfiguration.ActionType)),
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		ruleValues = append(ruleValues, ruleValue)
+	}
+
+	rulesList, diags := types.ListValue(pullzoneWafRulesRuleType, ruleValues)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.Rules = rulesList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,231 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"regexp"
+	"strings"
+)
+
+var _ datasource.DataSource = &PullzonesDataSource{}
+var _ datasource.DataSourceWithConfigure = &PullzonesDataSource{}
+
+func NewPullzonesDataSource() datasource.DataSource {
+	return &PullzonesDataSource{}
+}
+
+type PullzonesDataSource struct {
+	client *api.Client
+}
+
+type PullzonesDataSourceModel struct {
+	NameRegex           types.String `tfsdk:"name_regex"`
+	OriginType          types.String `tfsdk:"origin_type"`
+	HostnameSuffix      types.String `tfsdk:"hostname_suffix"`
+	OriginShieldEnabled types.Bool   `tfsdk:"originshield_enabled"`
+	Tier                types.String `tfsdk:"tier"`
+	Ids                 types.List   `tfsdk:"ids"`
+	Data                types.List   `tfsdk:"data"`
+}
+
+var pullzonesDataSourceType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                   types.Int64Type,
+		"name":                 types.StringType,
+		"cdn_domain":           types.StringType,
+		"hostnames":            types.ListType{ElemType: types.StringType},
+		"origin_type":          types.StringType,
+		"originshield_enabled": types.BoolType,
+		"tier":                 types.StringType,
+	},
+}
+
+func (d *PullzonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzones"
+}
+
+func (d *PullzonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the bunny.net Pullzones, optionally filtered.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include pullzones whose name matches this regular expression.",
+			},
+			"origin_type": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(maps.Values(pullzoneOriginTypeMap)...),
+				},
+				MarkdownDescription: "Only include pullzones with this origin type. " + generateMarkdownMapOptions(pullzoneOriginTypeMap),
+			},
+			"hostname_suffix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only include pullzones with at least one hostname that is, or is a subdomain of, this value.",
+			},
+			"originshield_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only include pullzones with Origin Shield enabled or disabled.",
+			},
+			"tier": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(maps.Values(pullzoneRoutingTierMap)...),
+				},
+				MarkdownDescription: "Only include pullzones with this routing tier. " + generateMarkdownMapOptions(pullzoneRoutingTierMap),
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "The IDs of the matching pullzones.",
+			},
+			"data": schema.ListAttribute{
+				ElementType: pullzonesDataSourceType,
+				Computed:    true,
+				Description: "The matching pullzones, ordered by name.",
+			},
+		},
+	}
+}
+
+func (d *PullzonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PullzonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PullzonesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	pullzones, err := d.client.GetPullzones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch pullzones", err.Error())
+		return
+	}
+
+	suffix := strings.ToLower(strings.Trim(data.HostnameSuffix.ValueString(), "."))
+	ids := []attr.Value{}
+	objs := []attr.Value{}
+
+	slices.SortFunc(pullzones, func(a, b api.Pullzone) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, pullzone := range pullzones {
+		if nameRegex != nil && !nameRegex.MatchString(pullzone.Name) {
+			continue
+		}
+
+		originType := pullzoneOriginTypeMap[pullzone.OriginType]
+
+		if !data.OriginType.IsNull() && data.OriginType.ValueString() != originType {
+			continue
+		}
+
+		tier := pullzoneRoutingTierMap[pullzone.Type]
+
+		if !data.Tier.IsNull() && data.Tier.ValueString() != tier {
+			continue
+		}
+
+		if !data.OriginShieldEnabled.IsNull() && data.OriginShieldEnabled.ValueBool() != pullzone.EnableOriginShield {
+			continue
+		}
+
+		hostnames := make([]attr.Value, len(pullzone.Hostnames))
+		hostnameMatched := suffix == ""
+		for i, hostname := range pullzone.Hostnames {
+			hostnames[i] = types.StringValue(hostname.Name)
+
+			name := strings.ToLower(hostname.Name)
+			if suffix != "" && (name == suffix || strings.HasSuffix(name, "."+suffix)) {
+				hostnameMatched = true
+			}
+		}
+
+		if !hostnameMatched {
+			continue
+		}
+
+		hostnamesList, diags := types.ListValue(types.StringType, hostnames)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		obj, diags := types.ObjectValue(pullzonesDataSourceType.AttrTypes, map[string]attr.Value{
+			"id":                   types.Int64Value(pullzone.Id),
+			"name":                 types.StringValue(pullzone.Name),
+			"cdn_domain":           types.StringValue(pullzone.CnameDomain),
+			"hostnames":            hostnamesList,
+			"origin_type":          types.StringValue(originType),
+			"originshield_enabled": types.BoolValue(pullzone.EnableOriginShield),
+			"tier":                 types.StringValue(tier),
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		ids = append(ids, types.Int64Value(pullzone.Id))
+		objs = append(objs, obj)
+	}
+
+	idsList, diags := types.ListValue(types.Int64Type, ids)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	dataList, diags := types.ListValue(pullzonesDataSourceType, objs)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.Ids = idsList
+	data.Data = dataList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/pullzoneshieldresourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ShieldCuratedAccessListsDataSource{}
+var _ datasource.DataSourceWithConfigure = &ShieldCuratedAccessListsDataSource{}
+
+func NewShieldCuratedAccessListsDataSource() datasource.DataSource {
+	return &ShieldCuratedAccessListsDataSource{}
+}
+
+type ShieldCuratedAccessListsDataSource struct {
+	client *api.Client
+}
+
+type ShieldCuratedAccessListsDataSourceModel struct {
+	Pullzone types.Int64 `tfsdk:"pullzone"`
+	Data     types.Map   `tfsdk:"data"`
+}
+
+var shieldCuratedAccessListType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":            types.Int64Type,
+		"name":          types.StringType,
+		"description":   types.StringType,
+		"type":          types.StringType,
+		"entry_count":   types.Int64Type,
+		"last_updated":  types.StringType,
+		"required_tier": types.StringType,
+		"enabled":       types.BoolType,
+		"action":        types.StringType,
+		"priority":      types.Int64Type,
+	},
+}
+
+func (d *ShieldCuratedAccessListsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_shield_curated_access_lists"
+}
+
+func (d *ShieldCuratedAccessListsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the curated access lists maintained by bunny.net (i.e. Tor exit nodes, known bad bots, cloud providers), and how they are configured in the shield of a pullzone. Curated lists are enabled by name with the `access_list` block of `bunnynet_pullzone_shield`.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the pullzone whose shield the lists are read from.",
+			},
+			"data": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "The curated access lists, indexed by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the list, to be used in `bunnynet_pullzone_shield.access_list.name`.",
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: generateMarkdownMapOptions(pullzoneAccessListTypeMap),
+						},
+						"entry_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of entries in the list.",
+						},
+						"last_updated": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the list was last updated by bunny.net.",
+						},
+						"required_tier": schema.StringAttribute{
+							Computed:    true,
+							Description: "The minimum shield tier required to enable the list.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the list is enabled in the shield of the pullzone.",
+						},
+						"action": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The action taken when a request matches the list. " + generateMarkdownMapOptions(pullzoneAccessListActionMap),
+						},
+						"priority": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The order in which the list is evaluated, lower values first.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ShieldCuratedAccessListsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ShieldCuratedAccessListsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ShieldCuratedAccessListsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lists, err := d.client.GetPullzoneCuratedAccessLists(ctx, data.Pullzone.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Could not fetch curated access lists", err.Error())
+		return
+	}
+
+	objs := map[string]attr.Value{}
+
+	for _, list := range lists {
+		requiredTier := ""
+		if list.RequiredPlan > 0 {
+			requiredTier = mapKeyToValue(pullzoneshieldresourcevalidator.PlanTypeMap, list.RequiredPlan)
+		}
+
+		obj, diags := types.ObjectValue(shieldCuratedAccessListType.AttrTypes, map[string]attr.Value{
+			"id":            types.Int64Value(list.Id),
+			"name":          types.StringValue(list.Name),
+			"description":   types.StringValue(list.Description),
+			"type":          types.StringValue(mapKeyToValue(pullzoneAccessListTypeMap, list.Type)),
+			"entry_count":   types.Int64Value(list.EntryCount),
+			"last_updated":  types.StringValue(list.LastUpdated),
+			"required_tier": types.StringValue(requiredTier),
+			"enabled":       types.BoolValue(list.IsEnabled),
+			"action":        types.StringValue(mapKeyToValue(pullzoneAccessListActionMap, list.Action)),
+			"priority":      types.Int64Value(list.Priority),
+		})
+
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		objs[list.Name] = obj
+	}
+
+	dataMap, diags := types.MapValue(shieldCuratedAccessListType, objs)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.Data = dataMap
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
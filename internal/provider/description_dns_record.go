@@ -21,6 +21,8 @@ type dnsRecordDescriptionType struct {
 	AcceleratedPullzone string
 	Link                string
 	MonitorType         string
+	Monitor             string
+	MonitorStatus       string
 	GeolocationLat      string
 	GeolocationLong     string
 	LatencyZone         string
@@ -34,7 +36,7 @@ var dnsRecordDescription = dnsRecordDescriptionType{
 	Enabled:             "Indicates whether the DNS record is enabled.",
 	Type:                generateMarkdownMapOptions(dnsRecordTypeMap),
 	TTL:                 "The time-to-live value for the DNS record.",
-	Value:               "The value of the DNS record.",
+	Value:               "The value of the DNS record. For TXT records, values that differ only in quoting, escaping, whitespace or character-string splitting are considered equal, and values longer than 255 bytes are split into multiple character-strings.",
 	Name:                `The name of the DNS record. Use <code>name = ""</code> for apex domain records.`,
 	Weight:              "The weight of the DNS record. It is used in load balancing scenarios to distribute traffic based on the specified weight.",
 	Priority:            "The priority of the DNS record.",
@@ -46,6 +48,8 @@ var dnsRecordDescription = dnsRecordDescriptionType{
 	AcceleratedPullzone: "The ID of the accelerated pull zone.",
 	Link:                "The name of the linked resource.",
 	MonitorType:         generateMarkdownMapOptions(dnsRecordMonitorTypeMap),
+	Monitor:             "Configures health monitoring for the DNS record. Checks are performed against the record value, and failing targets are excluded from smart routing. The API only exposes the monitor type, so `type` is equivalent to `monitor_type`.",
+	MonitorStatus:       "The current health status reported by the monitor. " + generateMarkdownMapOptions(dnsRecordMonitorStatusMap),
 	GeolocationLat:      "The latitude for geolocation-based routing.",
 	GeolocationLong:     "The longitude for geolocation-based routing.",
 	LatencyZone:         "The latency zone for latency-based routing.",
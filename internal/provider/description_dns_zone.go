@@ -20,6 +20,9 @@ type dnsZoneDescriptionType struct {
 	DnssecDigestType   string
 	DnssecFlags        string
 	DnssecKeyTag       string
+	DnssecDsRecord     string
+	DnssecDnskeyRecord string
+	DnssecDsConfigured string
 }
 
 var dnsZoneDescription = dnsZoneDescriptionType{
@@ -39,4 +42,7 @@ var dnsZoneDescription = dnsZoneDescriptionType{
 	DnssecDigestType:   "The DNSSEC digest type.",
 	DnssecFlags:        "The DNSSEC flags.",
 	DnssecKeyTag:       "The DNSSEC key tag.",
+	DnssecDsRecord:     "The DS record in presentation format, to be configured at the domain registrar.",
+	DnssecDnskeyRecord: "The DNSKEY record in presentation format.",
+	DnssecDsConfigured: "Indicates whether the DS record is configured at the domain registrar.",
 }
@@ -0,0 +1,18 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+type pullzonePurgeDescriptionType struct {
+	PullzoneId string
+	CacheTag   string
+	Paths      string
+	Urls       string
+}
+
+var pullzonePurgeDescription = pullzonePurgeDescriptionType{
+	PullzoneId: "The ID of the pullzone to purge.",
+	CacheTag:   "Only purge the files tagged with this cache tag.",
+	Paths:      "Paths to purge, relative to the pullzone hostnames (i.e. the `path` of a `bunnynet_storage_file`). A trailing `*` purges every path with that prefix.",
+	Urls:       "Full URLs to purge. A trailing `*` purges every URL with that prefix.",
+}
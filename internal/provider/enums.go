@@ -1,6 +1,12 @@
 // This file was generated via "go generate". DO NOT EDIT.
 package provider
 
+var dnsRecordMonitorStatusMap = map[uint8]string{
+	0: "Unknown",
+	1: "Online",
+	2: "Offline",
+}
+
 var dnsRecordMonitorTypeMap = map[uint8]string{
 	0: "None",
 	1: "Ping",
@@ -0,0 +1,64 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/dnssec"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &DnssecDsRecordValidFunction{}
+
+func NewDnssecDsRecordValidFunction() function.Function {
+	return &DnssecDsRecordValidFunction{}
+}
+
+type DnssecDsRecordValidFunction struct{}
+
+func (f *DnssecDsRecordValidFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dnssec_ds_record_valid"
+}
+
+func (f *DnssecDsRecordValidFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Checks whether a DS record matches a DNSKEY record",
+		MarkdownDescription: "Returns `true` if the DS record matches the key tag, algorithm and digest of the DNSKEY record. Use it to verify the DS record configured at your domain registrar against the `dnssec_dnskey_record` attribute of a `bunnynet_dns_zone`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ds_record",
+				MarkdownDescription: "The DS record, either as a resource record in presentation format or as `<key tag> <algorithm> <digest type> <digest>`.",
+			},
+			function.StringParameter{
+				Name:                "dnskey_record",
+				MarkdownDescription: "The DNSKEY record in presentation format.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *DnssecDsRecordValidFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var dsRecord string
+	var dnskeyRecord string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &dsRecord, &dnskeyRecord))
+	if resp.Error != nil {
+		return
+	}
+
+	if _, err := dnssec.ParseDsRecord(dsRecord); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	dnskey, err := dnssec.ParseDnskeyRecord(dnskeyRecord)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	err = dnssec.ValidateDsRecord(dsRecord, dnskey.Domain, dnskey.Flags, dnskey.Algorithm, dnskey.PublicKey)
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, err == nil))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+const configDnssecDsRecordValidTest = `
+locals {
+  dnskey = "dskey.example.com. 86400 IN DNSKEY 256 3 5 AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+}
+
+output "valid" {
+  value = provider::bunnynet::dnssec_ds_record_valid("dskey.example.com. 86400 IN DS 60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", local.dnskey)
+}
+
+output "invalid" {
+  value = provider::bunnynet::dnssec_ds_record_valid("60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50B", local.dnskey)
+}
+`
+
+func TestAccDnssecDsRecordValidFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configDnssecDsRecordValidTest,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("valid", knownvalue.Bool(true)),
+					statecheck.ExpectKnownOutputValue("invalid", knownvalue.Bool(false)),
+				},
+			},
+			{
+				Config:      `output "error" { value = provider::bunnynet::dnssec_ds_record_valid("60485 5 2", "example.com. IN DNSKEY 257 3 13 AAAA") }`,
+				ExpectError: regexp.MustCompile(`invalid DS record`),
+			},
+		},
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/exp/constraints"
+	"math/big"
+)
+
+// The helpers below read function arguments declared as function.DynamicParameter, so objects can omit optional attributes.
+
+func dynamicList(value tftypes.Value, path string) ([]tftypes.Value, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("%s must be known", path)
+	}
+
+	var values []tftypes.Value
+	if value.IsNull() {
+		return values, nil
+	}
+
+	if err := value.As(&values); err != nil {
+		return nil, fmt.Errorf("%s must be a list", path)
+	}
+
+	return values, nil
+}
+
+func dynamicObject(value tftypes.Value, path string) (map[string]tftypes.Value, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("%s must be known", path)
+	}
+
+	values := map[string]tftypes.Value{}
+	if value.IsNull() {
+		return nil, fmt.Errorf("%s must not be null", path)
+	}
+
+	if err := value.As(&values); err != nil {
+		return nil, fmt.Errorf("%s must be an object", path)
+	}
+
+	return values, nil
+}
+
+func dynamicAttrString(attrs map[string]tftypes.Value, name string, path string) (string, error) {
+	v, ok := attrs[name]
+	if !ok || v.IsNull() {
+		return "", nil
+	}
+
+	if !v.IsKnown() {
+		return "", fmt.Errorf("%s.%s must be known", path, name)
+	}
+
+	var s string
+	if err := v.As(&s); err != nil {
+		return "", fmt.Errorf("%s.%s must be a string", path, name)
+	}
+
+	return s, nil
+}
+
+func dynamicAttrInt64(attrs map[string]tftypes.Value, name string, path string) (int64, error) {
+	v, ok := attrs[name]
+	if !ok || v.IsNull() {
+		return 0, nil
+	}
+
+	if !v.IsKnown() {
+		return 0, fmt.Errorf("%s.%s must be known", path, name)
+	}
+
+	n := new(big.Float)
+	if err := v.As(&n); err != nil {
+		return 0, fmt.Errorf("%s.%s must be a number", path, name)
+	}
+
+	i, accuracy := n.Int64()
+	if accuracy != big.Exact {
+		return 0, fmt.Errorf("%s.%s must be an integer", path, name)
+	}
+
+	return i, nil
+}
+
+func dynamicAttrStringMap(attrs map[string]tftypes.Value, name string, path string) (map[string]string, error) {
+	result := map[string]string{}
+
+	v, ok := attrs[name]
+	if !ok || v.IsNull() {
+		return result, nil
+	}
+
+	values, err := dynamicObject(v, path+"."+name)
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range values {
+		s, err := dynamicAttrString(values, key, path+"."+name)
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = s
+	}
+
+	return result, nil
+}
+
+func dynamicAttrBool(attrs map[string]tftypes.Value, name string, path string) (bool, error) {
+	v, ok := attrs[name]
+	if !ok || v.IsNull() {
+		return false, nil
+	}
+
+	if !v.IsKnown() {
+		return false, fmt.Errorf("%s.%s must be known", path, name)
+	}
+
+	var b bool
+	if err := v.As(&b); err != nil {
+		return false, fmt.Errorf("%s.%s must be a bool", path, name)
+	}
+
+	return b, nil
+}
+
+func dynamicAttrStringList(attrs map[string]tftypes.Value, name string, path string) ([]string, error) {
+	v, ok := attrs[name]
+	if !ok || v.IsNull() {
+		return nil, nil
+	}
+
+	values, err := dynamicList(v, path+"."+name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, len(values))
+	for i, value := range values {
+		if !value.IsKnown() || value.IsNull() {
+			return nil, fmt.Errorf("%s.%s[%d] must be a string", path, name, i)
+		}
+
+		if err := value.As(&result[i]); err != nil {
+			return nil, fmt.Errorf("%s.%s[%d] must be a string", path, name, i)
+		}
+	}
+
+	return result, nil
+}
+
+// dynamicMapKey finds the enum key for a value, using defaultValue if it is empty.
+func dynamicMapKey[K constraints.Integer](mapped map[K]string, value string, defaultValue string, path string) (K, error) {
+	if value == "" {
+		value = defaultValue
+	}
+
+	for k, v := range mapped {
+		if v == value {
+			return k, nil
+		}
+	}
+
+	var zero K
+	return zero, fmt.Errorf("%s has an invalid value: %s", path, value)
+}
@@ -0,0 +1,385 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/edgerule"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/pullzoneedgeruleresourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ function.Function = &EdgeruleEvaluateFunction{}
+
+func NewEdgeruleEvaluateFunction() function.Function {
+	return &EdgeruleEvaluateFunction{}
+}
+
+type EdgeruleEvaluateFunction struct{}
+
+var edgeruleEvaluateActionType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"rule":       types.StringType,
+		"type":       types.StringType,
+		"parameter1": types.StringType,
+		"parameter2": types.StringType,
+		"parameter3": types.StringType,
+	},
+}
+
+var edgeruleEvaluateResultType = map[string]attr.Type{
+	"matched_rules": types.ListType{ElemType: types.StringType},
+	"actions":       types.ListType{ElemType: edgeruleEvaluateActionType},
+}
+
+func (f *EdgeruleEvaluateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "edgerule_evaluate"
+}
+
+func (f *EdgeruleEvaluateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Simulates edge rules against a request",
+		MarkdownDescription: "Evaluates a list of edge rules, in `priority` order, against a synthetic request and returns the matching rules and their actions. Use it in `check` blocks or tests to verify the behaviour of your `bunnynet_pullzone_edgerule` resources.\n\nPatterns are case-insensitive and support the `*` wildcard; patterns starting with `^` are evaluated as regular expressions. The `RandomChance`, `OriginRetryAttemptCount` and `OriginConnectionError` triggers cannot be simulated.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "rules",
+				MarkdownDescription: "A list of objects with the same attributes as `bunnynet_pullzone_edgerule` (`enabled`, `description`, `action`, `action_parameter1`, `action_parameter2`, `action_parameter3`, `actions`, `match_type`, `priority` and `triggers`). Resource references can be used directly. Rules are identified by their `id`, falling back to `description`.",
+			},
+			function.DynamicParameter{
+				Name:                "request",
+				MarkdownDescription: "An object describing the request, with the attributes `url` (required), `method`, `headers`, `response_headers`, `query`, `country`, `state`, `remote_ip` and `status_code`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: edgeruleEvaluateResultType,
+		},
+	}
+}
+
+func (f *EdgeruleEvaluateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rulesValue types.Dynamic
+	var requestValue types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &rulesValue, &requestValue))
+	if resp.Error != nil {
+		return
+	}
+
+	rules, err := edgeruleEvaluateParseRules(ctx, rulesValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	request, err := edgeruleEvaluateParseRequest(ctx, requestValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	result, err := edgerule.Evaluate(rules, request)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	matchedRules := make([]attr.Value, len(result.MatchedRules))
+	for i, rule := range result.MatchedRules {
+		matchedRules[i] = types.StringValue(rule)
+	}
+
+	actions := make([]attr.Value, len(result.Actions))
+	for i, action := range result.Actions {
+		actionType, ok := pullzoneedgeruleresourcevalidator.ActionMap[action.Type]
+		if !ok {
+			actionType = fmt.Sprintf("%d", action.Type)
+		}
+
+		actions[i] = types.ObjectValueMust(edgeruleEvaluateActionType.AttrTypes, map[string]attr.Value{
+			"rule":       types.StringValue(action.RuleId),
+			"type":       types.StringValue(actionType),
+			"parameter1": types.StringValue(action.Parameter1),
+			"parameter2": types.StringValue(action.Parameter2),
+			"parameter3": types.StringValue(action.Parameter3),
+		})
+	}
+
+	resultValue := types.ObjectValueMust(edgeruleEvaluateResultType, map[string]attr.Value{
+		"matched_rules": types.ListValueMust(types.StringType, matchedRules),
+		"actions":       types.ListValueMust(edgeruleEvaluateActionType, actions),
+	})
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, resultValue))
+}
+
+func edgeruleEvaluateParseRules(ctx context.Context, value types.Dynamic) ([]api.PullzoneEdgerule, error) {
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return nil, fmt.Errorf("rules must not be null")
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleValues, err := dynamicList(tfValue, "rules")
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]api.PullzoneEdgerule, 0, len(ruleValues))
+	for i, ruleValue := range ruleValues {
+		rule, err := edgeruleEvaluateParseRule(ruleValue, fmt.Sprintf("rules[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func edgeruleEvaluateParseRule(value tftypes.Value, path string) (api.PullzoneEdgerule, error) {
+	rule := api.PullzoneEdgerule{}
+
+	attrs, err := dynamicObject(value, path)
+	if err != nil {
+		return rule, err
+	}
+
+	if rule.Id, err = dynamicAttrString(attrs, "id", path); err != nil {
+		return rule, err
+	}
+
+	if rule.Description, err = dynamicAttrString(attrs, "description", path); err != nil {
+		return rule, err
+	}
+
+	rule.Enabled = true
+	if v, ok := attrs["enabled"]; ok && !v.IsNull() {
+		if err := v.As(&rule.Enabled); err != nil {
+			return rule, fmt.Errorf("%s.enabled must be a bool", path)
+		}
+	}
+
+	if rule.OrderIndex, err = dynamicAttrInt64(attrs, "priority", path); err != nil {
+		return rule, err
+	}
+
+	matchType, err := dynamicAttrString(attrs, "match_type", path)
+	if err != nil {
+		return rule, err
+	}
+
+	if rule.MatchType, err = dynamicMapKey(pullzoneedgeruleresourcevalidator.TriggerMatchTypeMap, matchType, "MatchAny", path+".match_type"); err != nil {
+		return rule, err
+	}
+
+	// actions
+	var actions []api.PullzoneEdgeruleExtraAction
+	if v, ok := attrs["actions"]; ok && !v.IsNull() {
+		actionValues, err := dynamicList(v, path+".actions")
+		if err != nil {
+			return rule, err
+		}
+
+		for i, actionValue := range actionValues {
+			actionPath := fmt.Sprintf("%s.actions[%d]", path, i)
+			action, err := edgeruleEvaluateParseAction(actionValue, actionPath)
+			if err != nil {
+				return rule, err
+			}
+
+			actions = append(actions, action)
+		}
+	}
+
+	if len(actions) == 0 {
+		action, err := edgeruleEvaluateParseAction(value, path)
+		if err != nil {
+			return rule, err
+		}
+
+		actions = append(actions, action)
+	}
+
+	rule.Action = actions[0].ActionType
+	rule.ActionParameter1 = actions[0].ActionParameter1
+	rule.ActionParameter2 = actions[0].ActionParameter2
+	rule.ActionParameter3 = actions[0].ActionParameter3
+	rule.ExtraActions = actions[1:]
+
+	// triggers
+	if v, ok := attrs["triggers"]; ok && !v.IsNull() {
+		triggerValues, err := dynamicList(v, path+".triggers")
+		if err != nil {
+			return rule, err
+		}
+
+		for i, triggerValue := range triggerValues {
+			trigger, err := edgeruleEvaluateParseTrigger(triggerValue, fmt.Sprintf("%s.triggers[%d]", path, i))
+			if err != nil {
+				return rule, err
+			}
+
+			rule.Triggers = append(rule.Triggers, trigger)
+		}
+	}
+
+	return rule, nil
+}
+
+// edgeruleEvaluateParseAction reads either an element of "actions" or the single action attributes of a rule.
+func edgeruleEvaluateParseAction(value tftypes.Value, path string) (api.PullzoneEdgeruleExtraAction, error) {
+	action := api.PullzoneEdgeruleExtraAction{}
+
+	attrs, err := dynamicObject(value, path)
+	if err != nil {
+		return action, err
+	}
+
+	prefix := "parameter"
+	typeAttr := "type"
+	if _, ok := attrs["type"]; !ok {
+		prefix = "action_parameter"
+		typeAttr = "action"
+	}
+
+	actionType, err := dynamicAttrString(attrs, typeAttr, path)
+	if err != nil {
+		return action, err
+	}
+
+	if actionType == "" {
+		return action, fmt.Errorf("%s.%s is required", path, typeAttr)
+	}
+
+	if action.ActionType, err = dynamicMapKey(pullzoneedgeruleresourcevalidator.ActionMap, actionType, "", path+"."+typeAttr); err != nil {
+		return action, err
+	}
+
+	if action.ActionParameter1, err = dynamicAttrString(attrs, prefix+"1", path); err != nil {
+		return action, err
+	}
+
+	if action.ActionParameter2, err = dynamicAttrString(attrs, prefix+"2", path); err != nil {
+		return action, err
+	}
+
+	if action.ActionParameter3, err = dynamicAttrString(attrs, prefix+"3", path); err != nil {
+		return action, err
+	}
+
+	return action, nil
+}
+
+func edgeruleEvaluateParseTrigger(value tftypes.Value, path string) (api.PullzoneEdgeruleTrigger, error) {
+	trigger := api.PullzoneEdgeruleTrigger{}
+
+	attrs, err := dynamicObject(value, path)
+	if err != nil {
+		return trigger, err
+	}
+
+	triggerType, err := dynamicAttrString(attrs, "type", path)
+	if err != nil {
+		return trigger, err
+	}
+
+	if trigger.Type, err = dynamicMapKey(pullzoneedgeruleresourcevalidator.TriggerTypeMap, triggerType, "", path+".type"); err != nil {
+		return trigger, err
+	}
+
+	matchType, err := dynamicAttrString(attrs, "match_type", path)
+	if err != nil {
+		return trigger, err
+	}
+
+	if trigger.MatchType, err = dynamicMapKey(pullzoneedgeruleresourcevalidator.TriggerMatchTypeMap, matchType, "MatchAny", path+".match_type"); err != nil {
+		return trigger, err
+	}
+
+	if trigger.Parameter1, err = dynamicAttrString(attrs, "parameter1", path); err != nil {
+		return trigger, err
+	}
+
+	if trigger.Parameter2, err = dynamicAttrString(attrs, "parameter2", path); err != nil {
+		return trigger, err
+	}
+
+	patterns, err := dynamicAttrStringList(attrs, "patterns", path)
+	if err != nil {
+		return trigger, err
+	}
+
+	trigger.Patterns = append([]string{}, patterns...)
+
+	return trigger, nil
+}
+
+func edgeruleEvaluateParseRequest(ctx context.Context, value types.Dynamic) (edgerule.Request, error) {
+	request := edgerule.Request{}
+
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return request, fmt.Errorf("request must not be null")
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return request, err
+	}
+
+	attrs, err := dynamicObject(tfValue, "request")
+	if err != nil {
+		return request, err
+	}
+
+	for _, attrName := range []string{"url", "method", "country", "state", "remote_ip"} {
+		v, err := dynamicAttrString(attrs, attrName, "request")
+		if err != nil {
+			return request, err
+		}
+
+		switch attrName {
+		case "url":
+			request.Url = v
+		case "method":
+			request.Method = v
+		case "country":
+			request.Country = v
+		case "state":
+			request.State = v
+		case "remote_ip":
+			request.RemoteIp = v
+		}
+	}
+
+	if request.Url == "" {
+		return request, fmt.Errorf("request.url is required")
+	}
+
+	if request.StatusCode, err = dynamicAttrInt64(attrs, "status_code", "request"); err != nil {
+		return request, err
+	}
+
+	if request.Headers, err = dynamicAttrStringMap(attrs, "headers", "request"); err != nil {
+		return request, err
+	}
+
+	if request.ResponseHeaders, err = dynamicAttrStringMap(attrs, "response_headers", "request"); err != nil {
+		return request, err
+	}
+
+	if request.Query, err = dynamicAttrStringMap(attrs, "query", "request"); err != nil {
+		return request, err
+	}
+
+	return request, nil
+}
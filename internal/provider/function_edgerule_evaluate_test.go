@@ -0,0 +1,89 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+const configEdgeruleEvaluateTest = `
+locals {
+  rules = [
+    {
+      description = "block-admin"
+      enabled     = true
+      action      = "BlockRequest"
+      match_type  = "MatchAll"
+      priority    = 2
+      triggers = [
+        { type = "Url", match_type = "MatchAny", patterns = ["*/admin/*"] },
+        { type = "RemoteIP", match_type = "MatchNone", patterns = ["10.0.0.0/8"] },
+      ]
+    },
+    {
+      description = "cache-images"
+      enabled     = true
+      priority    = 1
+      actions = [
+        { type = "OverrideCacheTime", parameter1 = "86400" },
+        { type = "SetResponseHeader", parameter1 = "X-Cache", parameter2 = "images" },
+      ]
+      triggers = [
+        { type = "UrlExtension", match_type = "MatchAny", patterns = ["jpg", "png"] },
+      ]
+    },
+  ]
+}
+
+output "admin" {
+  value = provider::bunnynet::edgerule_evaluate(local.rules, {
+    url       = "https://example.com/admin/logo.png"
+    remote_ip = "203.0.113.10"
+  })
+}
+
+output "internal" {
+  value = provider::bunnynet::edgerule_evaluate(local.rules, {
+    url       = "https://example.com/admin/"
+    remote_ip = "10.1.2.3"
+  })
+}
+`
+
+func TestAccEdgeruleEvaluateFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configEdgeruleEvaluateTest,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("admin", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched_rules": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("cache-images"),
+							knownvalue.StringExact("block-admin"),
+						}),
+						"actions": knownvalue.ListSizeExact(3),
+					})),
+					statecheck.ExpectKnownOutputValue("internal", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"matched_rules": knownvalue.ListSizeExact(0),
+						"actions":       knownvalue.ListSizeExact(0),
+					})),
+				},
+			},
+			{
+				Config:      `output "error" { value = provider::bunnynet::edgerule_evaluate([{ action = "Unknown", triggers = [] }], { url = "https://example.com/" }) }`,
+				ExpectError: regexp.MustCompile(`rules\[0\]\.action has an invalid value`),
+			},
+			{
+				Config:      `output "error" { value = provider::bunnynet::edgerule_evaluate([], { url = "/path" }) }`,
+				ExpectError: regexp.MustCompile(`must be absolute`),
+			},
+		},
+	})
+}
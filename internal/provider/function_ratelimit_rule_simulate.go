@@ -0,0 +1,220 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/shieldrule"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"slices"
+	"time"
+)
+
+var _ function.Function = &RatelimitRuleSimulateFunction{}
+
+func NewRatelimitRuleSimulateFunction() function.Function {
+	return &RatelimitRuleSimulateFunction{}
+}
+
+type RatelimitRuleSimulateFunction struct{}
+
+var ratelimitRuleSimulateBlockType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"from":  types.StringType,
+		"until": types.StringType,
+	},
+}
+
+var ratelimitRuleSimulateClientType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"ip":               types.StringType,
+		"matched_requests": types.Int64Type,
+		"blocked_requests": types.Int64Type,
+		"blocked_seconds":  types.Int64Type,
+		"blocks":           types.ListType{ElemType: ratelimitRuleSimulateBlockType},
+	},
+}
+
+var ratelimitRuleSimulateRequestType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"line":          types.Int64Type,
+		"timestamp":     types.StringType,
+		"ip":            types.StringType,
+		"method":        types.StringType,
+		"path":          types.StringType,
+		"blocked_until": types.StringType,
+	},
+}
+
+var ratelimitRuleSimulateResultType = map[string]attr.Type{
+	"requests":         types.Int64Type,
+	"matched_requests": types.Int64Type,
+	"blocked_requests": types.Int64Type,
+	"blocked_clients":  types.Int64Type,
+	"clients":          types.ListType{ElemType: ratelimitRuleSimulateClientType},
+	"blocked":          types.ListType{ElemType: ratelimitRuleSimulateRequestType},
+}
+
+func (f *RatelimitRuleSimulateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ratelimit_rule_simulate"
+}
+
+func (f *RatelimitRuleSimulateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Simulates a rate limit rule against a request log",
+		MarkdownDescription: "Replays a request log against a rate limit rule and reports which clients and requests would be blocked, and for how long. Use it to size `limit` and `response` of `bunnynet_pullzone_ratelimit_rule` from your own CDN logs before applying them.\n\nRequests matching the rule conditions are counted per client IP, within a sliding window of `limit.interval` seconds. The request exceeding `limit.requests` blocks the client for `response.interval` seconds, and the matching requests of the client are blocked until then. Conditions are evaluated as in `waf_rule_evaluate`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "rule",
+				MarkdownDescription: "An object with the same attributes as `bunnynet_pullzone_ratelimit_rule` (`transformations` and `condition`, or `expression`, `limit` and `response`). Resource references can be used directly.",
+			},
+			function.StringParameter{
+				Name:                "log",
+				MarkdownDescription: "The request log, either as JSON lines with the `timestamp`, `ip`, `path`, `method` and `headers` attributes, or as CSV with a header row and the `timestamp`, `ip` and `path` columns. In CSV logs, the `headers` column holds a JSON object, and any other column is read as a request header. Timestamps are in RFC 3339 format or Unix seconds.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: ratelimitRuleSimulateResultType,
+		},
+	}
+}
+
+func (f *RatelimitRuleSimulateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ruleValue types.Dynamic
+	var logValue string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ruleValue, &logValue))
+	if resp.Error != nil {
+		return
+	}
+
+	rule, err := ratelimitRuleSimulateParseRule(ctx, ruleValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	entries, err := shieldrule.ParseLog(logValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid log: %s", err.Error()))
+		return
+	}
+
+	result, err := shieldrule.SimulateRatelimit(rule, entries)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	clients := make([]attr.Value, len(result.Clients))
+	for i, client := range result.Clients {
+		blocks := make([]attr.Value, len(client.Blocks))
+		for j, block := range client.Blocks {
+			blocks[j] = types.ObjectValueMust(ratelimitRuleSimulateBlockType.AttrTypes, map[string]attr.Value{
+				"from":  types.StringValue(block.From.UTC().Format(time.RFC3339)),
+				"until": types.StringValue(block.Until.UTC().Format(time.RFC3339)),
+			})
+		}
+
+		clients[i] = types.ObjectValueMust(ratelimitRuleSimulateClientType.AttrTypes, map[string]attr.Value{
+			"ip":               types.StringValue(client.RemoteIp),
+			"matched_requests": types.Int64Value(int64(client.MatchedRequests)),
+			"blocked_requests": types.Int64Value(int64(client.BlockedRequests)),
+			"blocked_seconds":  types.Int64Value(int64(client.BlockedFor.Seconds())),
+			"blocks":           types.ListValueMust(ratelimitRuleSimulateBlockType, blocks),
+		})
+	}
+
+	blocked := make([]attr.Value, len(result.Blocked))
+	for i, request := range result.Blocked {
+		method := types.StringNull()
+		if request.Method != "" {
+			method = types.StringValue(request.Method)
+		}
+
+		blocked[i] = types.ObjectValueMust(ratelimitRuleSimulateRequestType.AttrTypes, map[string]attr.Value{
+			"line":          types.Int64Value(int64(request.Line)),
+			"timestamp":     types.StringValue(request.Timestamp.UTC().Format(time.RFC3339Nano)),
+			"ip":            types.StringValue(request.RemoteIp),
+			"method":        method,
+			"path":          types.StringValue(request.Path),
+			"blocked_until": types.StringValue(request.BlockedUntil.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	resultValue := types.ObjectValueMust(ratelimitRuleSimulateResultType, map[string]attr.Value{
+		"requests":         types.Int64Value(int64(result.Requests)),
+		"matched_requests": types.Int64Value(int64(result.MatchedRequests)),
+		"blocked_requests": types.Int64Value(int64(len(result.Blocked))),
+		"blocked_clients":  types.Int64Value(int64(len(result.Clients))),
+		"clients":          types.ListValueMust(ratelimitRuleSimulateClientType, clients),
+		"blocked":          types.ListValueMust(ratelimitRuleSimulateRequestType, blocked),
+	})
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, resultValue))
+}
+
+// ratelimitRuleSimulateParseRule reads the conditions, limit and response of a rate limit rule.
+func ratelimitRuleSimulateParseRule(ctx context.Context, value types.Dynamic) (api.PullzoneWafRuleConfiguration, error) {
+	rule, _, err := wafRuleEvaluateParseRule(ctx, value)
+	if err != nil {
+		return rule, err
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return rule, err
+	}
+
+	attrs, err := dynamicObject(tfValue, "rule")
+	if err != nil {
+		return rule, err
+	}
+
+	// limit
+	if v, ok := attrs["limit"]; !ok || v.IsNull() {
+		return rule, fmt.Errorf("rule.limit is required")
+	}
+
+	limitAttrs, err := dynamicObject(attrs["limit"], "rule.limit")
+	if err != nil {
+		return rule, err
+	}
+
+	if rule.RequestCount, err = dynamicAttrInt64(limitAttrs, "requests", "rule.limit"); err != nil {
+		return rule, err
+	}
+
+	if rule.Timeframe, err = dynamicAttrInt64(limitAttrs, "interval", "rule.limit"); err != nil {
+		return rule, err
+	}
+
+	if !slices.Contains(pullzoneShieldRatelimitRuleLimitTimeframeOptions, rule.Timeframe) {
+		return rule, fmt.Errorf("rule.limit.interval must be one of %v", pullzoneShieldRatelimitRuleLimitTimeframeOptions)
+	}
+
+	// response
+	if v, ok := attrs["response"]; !ok || v.IsNull() {
+		return rule, fmt.Errorf("rule.response is required")
+	}
+
+	responseAttrs, err := dynamicObject(attrs["response"], "rule.response")
+	if err != nil {
+		return rule, err
+	}
+
+	if rule.BlockTime, err = dynamicAttrInt64(responseAttrs, "interval", "rule.response"); err != nil {
+		return rule, err
+	}
+
+	if !slices.Contains(pullzoneShieldRatelimitRuleResponseTimeframeOptions, rule.BlockTime) {
+		return rule, fmt.Errorf("rule.response.interval must be one of %v", pullzoneShieldRatelimitRuleResponseTimeframeOptions)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+const configRatelimitRuleSimulateTest = `
+locals {
+  rule = {
+    condition = [
+      { variable = "REQUEST_URI", operator = "BEGINSWITH", value = "/login" },
+    ]
+    limit    = { requests = 2, interval = 10 }
+    response = { interval = 30 }
+  }
+}
+
+output "result" {
+  value = provider::bunnynet::ratelimit_rule_simulate(local.rule, <<-CSV
+    timestamp,ip,path,method
+    2026-01-01T00:00:00Z,192.0.2.1,/login,POST
+    2026-01-01T00:00:01Z,192.0.2.1,/login,POST
+    2026-01-01T00:00:02Z,192.0.2.1,/login,POST
+    2026-01-01T00:00:03Z,192.0.2.1,/login,POST
+    2026-01-01T00:00:03Z,192.0.2.2,/login,POST
+    2026-01-01T00:00:04Z,192.0.2.1,/index.html,GET
+    CSV
+  )
+}
+`
+
+func TestAccRatelimitRuleSimulateFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configRatelimitRuleSimulateTest,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("result", knownvalue.ObjectPartial(map[string]knownvalue.Check{
+						"requests":         knownvalue.Int64Exact(6),
+						"matched_requests": knownvalue.Int64Exact(5),
+						"blocked_requests": knownvalue.Int64Exact(2),
+						"blocked_clients":  knownvalue.Int64Exact(1),
+						"clients": knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"ip":              knownvalue.StringExact("192.0.2.1"),
+								"blocked_seconds": knownvalue.Int64Exact(30),
+							}),
+						}),
+					})),
+				},
+			},
+			{
+				Config:      `output "error" { value = provider::bunnynet::ratelimit_rule_simulate({ limit = { requests = 2, interval = 10 }, response = { interval = 30 } }, "timestamp,ip\n2026-01-01T00:00:00Z,192.0.2.1\n") }`,
+				ExpectError: regexp.MustCompile("missing column"),
+			},
+		},
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/tokenauth"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &SignStreamEmbedFunction{}
+
+func NewSignStreamEmbedFunction() function.Function {
+	return &SignStreamEmbedFunction{}
+}
+
+type SignStreamEmbedFunction struct{}
+
+func (f *SignStreamEmbedFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sign_stream_embed"
+}
+
+func (f *SignStreamEmbedFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Signs a Bunny Stream embed URL",
+		MarkdownDescription: "Returns the embed URL of a video, signed with the token authentication key of the stream library, as shown in the library security settings. Use it when `view_token_authentication_required` is enabled in `bunnynet_stream_library`.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "library_id",
+				MarkdownDescription: "The ID of the stream library.",
+			},
+			function.StringParameter{
+				Name:                "video_id",
+				MarkdownDescription: "The GUID of the video.",
+			},
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "The token authentication key of the stream library.",
+			},
+			function.Int64Parameter{
+				Name:                "expires",
+				MarkdownDescription: "The expiration time, as a unix timestamp.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SignStreamEmbedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var libraryId int64
+	var videoId string
+	var key string
+	var expires int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &libraryId, &videoId, &key, &expires))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := tokenauth.SignStreamEmbed(libraryId, videoId, key, expires)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,119 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/tokenauth"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &SignUrlFunction{}
+
+func NewSignUrlFunction() function.Function {
+	return &SignUrlFunction{}
+}
+
+type SignUrlFunction struct{}
+
+func (f *SignUrlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sign_url"
+}
+
+func (f *SignUrlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Signs a URL for pullzone token authentication",
+		MarkdownDescription: "Returns the URL signed with the token authentication key of a pullzone (`bunnynet_pullzone.token_auth_key`), using the SHA-256 token. Set `basic = true` in the options to use the legacy MD5 token instead.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The absolute URL to sign.",
+			},
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "The token authentication key of the pullzone.",
+			},
+			function.Int64Parameter{
+				Name:                "expires",
+				MarkdownDescription: "The expiration time, as a unix timestamp.",
+			},
+			function.DynamicParameter{
+				Name:                "opts",
+				AllowNullValue:      true,
+				MarkdownDescription: "An optional object with the attributes `token_path` (sign every URL under this path), `countries_allowed` and `countries_blocked` (lists of ISO 3166-1 alpha-2 country codes), `remote_ip` (restrict to a client IP), `directory` (place the token in the path, for HLS playlists) and `basic` (use the legacy MD5 token). Use `null` or `{}` for no options.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SignUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var url string
+	var key string
+	var expires int64
+	var optsValue types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &url, &key, &expires, &optsValue))
+	if resp.Error != nil {
+		return
+	}
+
+	opts, err := signUrlParseOptions(ctx, optsValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(3, err.Error())
+		return
+	}
+
+	result, err := tokenauth.SignUrl(url, key, expires, opts)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+func signUrlParseOptions(ctx context.Context, value types.Dynamic) (tokenauth.Options, error) {
+	opts := tokenauth.Options{}
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return opts, nil
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return opts, err
+	}
+
+	attrs, err := dynamicObject(tfValue, "opts")
+	if err != nil {
+		return opts, err
+	}
+
+	if opts.TokenPath, err = dynamicAttrString(attrs, "token_path", "opts"); err != nil {
+		return opts, err
+	}
+
+	if opts.CountriesAllowed, err = dynamicAttrStringList(attrs, "countries_allowed", "opts"); err != nil {
+		return opts, err
+	}
+
+	if opts.CountriesBlocked, err = dynamicAttrStringList(attrs, "countries_blocked", "opts"); err != nil {
+		return opts, err
+	}
+
+	if opts.RemoteIp, err = dynamicAttrString(attrs, "remote_ip", "opts"); err != nil {
+		return opts, err
+	}
+
+	if opts.Directory, err = dynamicAttrBool(attrs, "directory", "opts"); err != nil {
+		return opts, err
+	}
+
+	if opts.Basic, err = dynamicAttrBool(attrs, "basic", "opts"); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+const configSignUrlTest = `
+locals {
+  key = "229248f0-f007-4bf9-ba1f-bbf1b4ad9d40"
+}
+
+output "default" {
+  value = provider::bunnynet::sign_url("https://cdn.example.com/videos/video.mp4", local.key, 1735689600, null)
+}
+
+output "directory" {
+  value = provider::bunnynet::sign_url("https://cdn.example.com/videos/playlist.m3u8", local.key, 1735689600, {
+    token_path        = "/videos/"
+    countries_allowed = ["SI", "HR"]
+    directory         = true
+  })
+}
+
+output "stream" {
+  value = provider::bunnynet::sign_stream_embed(12345, "8f2f6b4d-8c7e-4d0b-9d6b-3b1f0e2a9c11", local.key, 1735689600)
+}
+`
+
+func TestAccSignUrlFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configSignUrlTest,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("default", knownvalue.StringExact("https://cdn.example.com/videos/video.mp4?token=hDSxEMBkZzBiX3So64bE9hkF-BAkiXHubeEEZ0qyvA0&expires=1735689600")),
+					statecheck.ExpectKnownOutputValue("directory", knownvalue.StringExact("https://cdn.example.com/bcdn_token=3Ec0epkRlPQfe_EKcId17ofexmGER54i6TI2r_OyL2E&token_countries=SI%2CHR&token_path=%2Fvideos%2F&expires=1735689600/videos/playlist.m3u8")),
+					statecheck.ExpectKnownOutputValue("stream", knownvalue.StringExact("https://iframe.mediadelivery.net/embed/12345/8f2f6b4d-8c7e-4d0b-9d6b-3b1f0e2a9c11?token=b0c312ee08fdf3dd6098e650a67ca7fce1e4f9675b1962feb0c00102832d39c8&expires=1735689600")),
+				},
+			},
+			{
+				Config:      `output "error" { value = provider::bunnynet::sign_url("https://cdn.example.com/video.mp4", "key", 1735689600, { basic = true, token_path = "/" }) }`,
+				ExpectError: regexp.MustCompile(`basic tokens only support the remote IP restriction`),
+			},
+		},
+	})
+}
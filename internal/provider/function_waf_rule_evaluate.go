@@ -0,0 +1,324 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/shieldrule"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ function.Function = &WafRuleEvaluateFunction{}
+
+func NewWafRuleEvaluateFunction() function.Function {
+	return &WafRuleEvaluateFunction{}
+}
+
+type WafRuleEvaluateFunction struct{}
+
+var wafRuleEvaluateConditionType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"variable":       types.StringType,
+		"variable_value": types.StringType,
+		"operator":       types.StringType,
+		"value":          types.StringType,
+		"matched":        types.BoolType,
+		"matched_value":  types.StringType,
+	},
+}
+
+var wafRuleEvaluateResultType = map[string]attr.Type{
+	"matched":    types.BoolType,
+	"action":     types.StringType,
+	"conditions": types.ListType{ElemType: wafRuleEvaluateConditionType},
+}
+
+func (f *WafRuleEvaluateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "waf_rule_evaluate"
+}
+
+func (f *WafRuleEvaluateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Simulates a WAF or rate limit rule against a request",
+		MarkdownDescription: "Evaluates a WAF or rate limit rule against a synthetic request and returns whether it matches, the resulting action and the result of each condition. Use it in `check` blocks or tests to verify the behaviour of your `bunnynet_pullzone_waf_rule` and `bunnynet_pullzone_ratelimit_rule` resources before they reach production traffic.\n\nThe rule matches when all conditions match. The transformations are applied, in order, to the values of every condition. Regular expressions use the [RE2 syntax](https://github.com/google/re2/wiki/Syntax), which is mostly compatible with PCRE. The `DETECTSQLI` and `DETECTXSS` operators and the `FILES_NAMES` variable cannot be simulated.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "rule",
+				MarkdownDescription: "An object with the same attributes as `bunnynet_pullzone_waf_rule` or `bunnynet_pullzone_ratelimit_rule` (`transformations` and `condition`, or `expression`, and `response`). Resource references can be used directly.",
+			},
+			function.DynamicParameter{
+				Name:                "request",
+				MarkdownDescription: "An object describing the request, with the attributes `url` (required), `method`, `protocol`, `headers`, `body`, `remote_ip`, `country`, `geo`, `fingerprint`, `verified_bot_category`, `response_status`, `response_headers` and `response_body`. Form-encoded bodies are parsed into `ARGS_POST`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: wafRuleEvaluateResultType,
+		},
+	}
+}
+
+func (f *WafRuleEvaluateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ruleValue types.Dynamic
+	var requestValue types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ruleValue, &requestValue))
+	if resp.Error != nil {
+		return
+	}
+
+	rule, action, err := wafRuleEvaluateParseRule(ctx, ruleValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	request, err := wafRuleEvaluateParseRequest(ctx, requestValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	result, err := shieldrule.Evaluate(rule, request)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	conditions := make([]attr.Value, len(result.Conditions))
+	for i, condition := range result.Conditions {
+		variableValue := types.StringNull()
+		if condition.VariableValue != "" {
+			variableValue = types.StringValue(condition.VariableValue)
+		}
+
+		matchedValue := types.StringNull()
+		if condition.Matched {
+			matchedValue = types.StringValue(condition.MatchedValue)
+		}
+
+		conditions[i] = types.ObjectValueMust(wafRuleEvaluateConditionType.AttrTypes, map[string]attr.Value{
+			"variable":       types.StringValue(condition.Variable),
+			"variable_value": variableValue,
+			"operator":       types.StringValue(mapKeyToValue(pullzoneShieldRuleConditionOperationMap, condition.Operator)),
+			"value":          types.StringValue(condition.Value),
+			"matched":        types.BoolValue(condition.Matched),
+			"matched_value":  matchedValue,
+		})
+	}
+
+	actionValue := types.StringNull()
+	if result.Matched && action != "" {
+		actionValue = types.StringValue(action)
+	}
+
+	resultValue := types.ObjectValueMust(wafRuleEvaluateResultType, map[string]attr.Value{
+		"matched":    types.BoolValue(result.Matched),
+		"action":     actionValue,
+		"conditions": types.ListValueMust(wafRuleEvaluateConditionType, conditions),
+	})
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, resultValue))
+}
+
+// wafRuleEvaluateParseRule returns the rule configuration and the response action, if any.
+func wafRuleEvaluateParseRule(ctx context.Context, value types.Dynamic) (api.PullzoneWafRuleConfiguration, string, error) {
+	rule := api.PullzoneWafRuleConfiguration{}
+
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return rule, "", fmt.Errorf("rule must not be null")
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return rule, "", err
+	}
+
+	attrs, err := dynamicObject(tfValue, "rule")
+	if err != nil {
+		return rule, "", err
+	}
+
+	// expression
+	expression, err := dynamicAttrString(attrs, "expression", "rule")
+	if err != nil {
+		return rule, "", err
+	}
+
+	if expression != "" {
+		if err := pullzoneShieldRuleApplyExpression(expression, &rule); err != nil {
+			return rule, "", fmt.Errorf("rule.expression: %w", err)
+		}
+	} else if err := wafRuleEvaluateParseConditions(attrs, &rule); err != nil {
+		return rule, "", err
+	}
+
+	// response
+	var action string
+	if v, ok := attrs["response"]; ok && !v.IsNull() {
+		responseAttrs, err := dynamicObject(v, "rule.response")
+		if err != nil {
+			return rule, "", err
+		}
+
+		if action, err = dynamicAttrString(responseAttrs, "action", "rule.response"); err != nil {
+			return rule, "", err
+		}
+
+		if action != "" {
+			if _, err := dynamicMapKey(pullzoneShieldWafRuleResponseActionMap, action, "", "rule.response.action"); err != nil {
+				return rule, "", err
+			}
+		}
+	}
+
+	return rule, action, nil
+}
+
+// wafRuleEvaluateParseConditions reads the transformations and conditions, in the same format as the resources.
+func wafRuleEvaluateParseConditions(attrs map[string]tftypes.Value, rule *api.PullzoneWafRuleConfiguration) error {
+	// transformations
+	transformations, err := dynamicAttrStringList(attrs, "transformations", "rule")
+	if err != nil {
+		return err
+	}
+
+	for i, transformation := range transformations {
+		t, err := dynamicMapKey(pullzoneShieldRuleTransformationMap, transformation, "", fmt.Sprintf("rule.transformations[%d]", i))
+		if err != nil {
+			return err
+		}
+
+		rule.TransformationTypes = append(rule.TransformationTypes, t)
+	}
+
+	// conditions
+	conditionValues, err := dynamicList(attrs["condition"], "rule.condition")
+	if err != nil {
+		return err
+	}
+
+	if len(conditionValues) == 0 {
+		return fmt.Errorf("rule.condition is required")
+	}
+
+	for i, conditionValue := range conditionValues {
+		condition, err := wafRuleEvaluateParseCondition(conditionValue, fmt.Sprintf("rule.condition[%d]", i))
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			rule.VariableTypes = condition.VariableTypes
+			rule.OperatorType = condition.OperatorType
+			rule.Value = condition.Value
+		} else {
+			rule.ChainedRules = append(rule.ChainedRules, condition)
+		}
+	}
+
+	return nil
+}
+
+func wafRuleEvaluateParseCondition(value tftypes.Value, path string) (api.PullzoneWafRuleChainedRule, error) {
+	condition := api.PullzoneWafRuleChainedRule{}
+
+	attrs, err := dynamicObject(value, path)
+	if err != nil {
+		return condition, err
+	}
+
+	variable, err := dynamicAttrString(attrs, "variable", path)
+	if err != nil {
+		return condition, err
+	}
+
+	if _, err := dynamicMapKey(pullzoneShieldRuleConditionVariableMap, variable, "", path+".variable"); err != nil {
+		return condition, err
+	}
+
+	variableValue, err := dynamicAttrString(attrs, "variable_value", path)
+	if err != nil {
+		return condition, err
+	}
+
+	condition.VariableTypes = map[string]string{variable: variableValue}
+
+	operator, err := dynamicAttrString(attrs, "operator", path)
+	if err != nil {
+		return condition, err
+	}
+
+	if condition.OperatorType, err = dynamicMapKey(pullzoneShieldRuleConditionOperationMap, operator, "", path+".operator"); err != nil {
+		return condition, err
+	}
+
+	if condition.Value, err = dynamicAttrString(attrs, "value", path); err != nil {
+		return condition, err
+	}
+
+	return condition, nil
+}
+
+func wafRuleEvaluateParseRequest(ctx context.Context, value types.Dynamic) (shieldrule.Request, error) {
+	request := shieldrule.Request{}
+
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return request, fmt.Errorf("request must not be null")
+	}
+
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return request, err
+	}
+
+	attrs, err := dynamicObject(tfValue, "request")
+	if err != nil {
+		return request, err
+	}
+
+	stringAttrs := map[string]*string{
+		"url":                   &request.Url,
+		"method":                &request.Method,
+		"protocol":              &request.Protocol,
+		"body":                  &request.Body,
+		"remote_ip":             &request.RemoteIp,
+		"country":               &request.Country,
+		"fingerprint":           &request.Fingerprint,
+		"verified_bot_category": &request.VerifiedBotCategory,
+		"response_body":         &request.ResponseBody,
+	}
+
+	for attrName, target := range stringAttrs {
+		if *target, err = dynamicAttrString(attrs, attrName, "request"); err != nil {
+			return request, err
+		}
+	}
+
+	if request.Url == "" {
+		return request, fmt.Errorf("request.url is required")
+	}
+
+	if request.ResponseStatus, err = dynamicAttrInt64(attrs, "response_status", "request"); err != nil {
+		return request, err
+	}
+
+	if request.Headers, err = dynamicAttrStringMap(attrs, "headers", "request"); err != nil {
+		return request, err
+	}
+
+	if request.Geo, err = dynamicAttrStringMap(attrs, "geo", "request"); err != nil {
+		return request, err
+	}
+
+	if request.ResponseHeaders, err = dynamicAttrStringMap(attrs, "response_headers", "request"); err != nil {
+		return request, err
+	}
+
+	return request, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+const configWafRuleEvaluateTest = `
+locals {
+  rule = {
+    transformations = ["URLDECODE", "LOWERCASE"]
+    condition = [
+      { variable = "REQUEST_URI", operator = "BEGINSWITH", value = "/admin" },
+      { variable = "REQUEST_HEADERS", variable_value = "User-Agent", operator = "RX", value = "curl|wget" },
+    ]
+    response = { action = "Block" }
+  }
+}
+
+output "blocked" {
+  value = provider::bunnynet::waf_rule_evaluate(local.rule, {
+    url     = "https://example.com/%41DMIN/login"
+    headers = { "User-Agent" = "curl/8.0" }
+  })
+}
+
+output "allowed" {
+  value = provider::bunnynet::waf_rule_evaluate(local.rule, {
+    url     = "https://example.com/admin/login"
+    headers = { "User-Agent" = "Mozilla/5.0" }
+  })
+}
+`
+
+func TestAccWafRuleEvaluateFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configWafRuleEvaluateTest,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("blocked", knownvalue.ObjectPartial(map[string]knownvalue.Check{
+						"matched":    knownvalue.Bool(true),
+						"action":     knownvalue.StringExact("Block"),
+						"conditions": knownvalue.ListSizeExact(2),
+					})),
+					statecheck.ExpectKnownOutputValue("allowed", knownvalue.ObjectPartial(map[string]knownvalue.Check{
+						"matched": knownvalue.Bool(false),
+						"action":  knownvalue.Null(),
+					})),
+				},
+			},
+			{
+				Config:      `output "error" { value = provider::bunnynet::waf_rule_evaluate({ condition = [{ variable = "REQUEST_URI", operator = "RX", value = "^(" }] }, { url = "https://example.com/" }) }`,
+				ExpectError: regexp.MustCompile("invalid regular expression"),
+			},
+		},
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/shieldrule"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &WafRuleExpressionFunction{}
+
+func NewWafRuleExpressionFunction() function.Function {
+	return &WafRuleExpressionFunction{}
+}
+
+type WafRuleExpressionFunction struct{}
+
+func (f *WafRuleExpressionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "waf_rule_expression"
+}
+
+func (f *WafRuleExpressionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Renders the conditions of a WAF or rate limit rule as an expression",
+		MarkdownDescription: "Returns the conditions and transformations of a WAF or rate limit rule as an expression, which can be used in the `expression` attribute of `bunnynet_pullzone_waf_rule` and `bunnynet_pullzone_ratelimit_rule`. Use it to migrate existing rules from `condition` blocks.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "rule",
+				MarkdownDescription: "An object with the same attributes as `bunnynet_pullzone_waf_rule` or `bunnynet_pullzone_ratelimit_rule` (`transformations` and `condition`). Resource references can be used directly.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *WafRuleExpressionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ruleValue types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ruleValue))
+	if resp.Error != nil {
+		return
+	}
+
+	rule, _, err := wafRuleEvaluateParseRule(ctx, ruleValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, err := shieldrule.FormatExpression(rule, pullzoneShieldRuleExpressionNames)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
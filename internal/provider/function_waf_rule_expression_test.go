@@ -0,0 +1,43 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+const configWafRuleExpressionTest = `
+output "expression" {
+  value = provider::bunnynet::waf_rule_expression({
+    transformations = ["LOWERCASE"]
+    condition = [
+      { variable = "REQUEST_HEADERS", variable_value = "User-Agent", operator = "CONTAINS", value = "curl" },
+      { variable = "REMOTE_ADDR", operator = "WITHIN", value = "192.0.2.1 192.0.2.2" },
+    ]
+  })
+}
+`
+
+func TestAccWafRuleExpressionFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: configWafRuleExpressionTest,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("expression", knownvalue.StringExact(`lowercase(request_headers["User-Agent"]) contains "curl" and lowercase(remote_addr) in ["192.0.2.1", "192.0.2.2"]`)),
+				},
+			},
+			{
+				Config:      `output "error" { value = provider::bunnynet::waf_rule_expression({ condition = [{ variable = "REQUEST_URI", operator = "UNKNOWN", value = "/" }] }) }`,
+				ExpectError: regexp.MustCompile("rule.condition\\[0\\].operator"),
+			},
+		},
+	})
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
 	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,6 +19,8 @@ import (
 )
 
 var _ provider.Provider = &BunnynetProvider{}
+var _ provider.ProviderWithFunctions = &BunnynetProvider{}
+var _ provider.ProviderWithActions = &BunnynetProvider{}
 
 type BunnynetProvider struct {
 	version string
@@ -105,6 +109,7 @@ func (p *BunnynetProvider) Configure(ctx context.Context, req provider.Configure
 	)
 	resp.DataSourceData = apiClient
 	resp.ResourceData = apiClient
+	resp.ActionData = apiClient
 }
 
 func (p *BunnynetProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -116,16 +121,23 @@ func (p *BunnynetProvider) Resources(ctx context.Context) []func() resource.Reso
 		NewComputeScriptSecretResource,
 		NewComputeScriptVariableResource,
 		NewDatabaseResource,
+		NewDnsAcmeChallengeResource,
 		NewDnsRecordResourceResource,
 		NewDnsScriptResource,
 		NewDnsScriptVariableResource,
 		NewDnsZoneResourceResource,
 		NewPullzoneResource,
 		NewPullzoneEdgeruleResource,
+		NewPullzoneEdgeruleOrderResource,
+		NewPullzoneEdgerulesResource,
 		NewPullzoneHostnameResource,
+		NewPullzoneHostnamesResource,
 		NewPullzoneOptimizerClassResource,
+		NewPullzonePurgeResource,
+		NewPullzoneSecurityKeyResource,
 		NewPullzoneRatelimitRule,
 		NewPullzoneShield,
+		NewPullzoneShieldPolicyResource,
 		NewPullzoneAccessList,
 		NewPullzoneWafRule,
 		NewStorageFileResource,
@@ -142,14 +154,41 @@ func (p *BunnynetProvider) DataSources(ctx context.Context) []func() datasource.
 		NewComputeContainerAppContainerEndpointDataSource,
 		NewComputeContainerImageRegistryDataSource,
 		NewPullzoneDataSource,
+		NewPullzonesDataSource,
 		NewPullzoneAccessListsDataSource,
+		NewPullzoneRatelimitRulesDataSource,
+		NewPullzoneShieldEventsDataSource,
+		NewPullzoneShieldPolicyDataSource,
+		NewPullzoneShieldWafRulesDataSource,
+		NewPullzoneWafRulesDataSource,
+		NewShieldCuratedAccessListsDataSource,
 		NewDnsRecordDataSource,
+		NewDnsRecordHealthDataSource,
 		NewDnsZoneDataSource,
+		NewDnsZonesDataSource,
 		NewRegionDataSource,
 		NewVideoLanguageDataSource,
 	}
 }
 
+func (p *BunnynetProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewDnssecDsRecordValidFunction,
+		NewEdgeruleEvaluateFunction,
+		NewRatelimitRuleSimulateFunction,
+		NewSignStreamEmbedFunction,
+		NewSignUrlFunction,
+		NewWafRuleEvaluateFunction,
+		NewWafRuleExpressionFunction,
+	}
+}
+
+func (p *BunnynetProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewPullzonePurgeAction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &BunnynetProvider{
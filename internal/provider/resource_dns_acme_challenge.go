@@ -0,0 +1,348 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/exp/slices"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var _ resource.Resource = &DnsAcmeChallengeResource{}
+var _ resource.ResourceWithConfigure = &DnsAcmeChallengeResource{}
+
+const dnsAcmeChallengeLabel = "_acme-challenge"
+
+func NewDnsAcmeChallengeResource() resource.Resource {
+	return &DnsAcmeChallengeResource{}
+}
+
+type DnsAcmeChallengeResource struct {
+	client *api.Client
+}
+
+type DnsAcmeChallengeResourceModel struct {
+	Id               types.Int64  `tfsdk:"id"`
+	Zone             types.Int64  `tfsdk:"zone"`
+	Name             types.String `tfsdk:"name"`
+	Value            types.String `tfsdk:"value"`
+	Ttl              types.Int64  `tfsdk:"ttl"`
+	RecordName       types.String `tfsdk:"record_name"`
+	Fqdn             types.String `tfsdk:"fqdn"`
+	PollInterval     types.Int64  `tfsdk:"poll_interval"`
+	Timeout          types.Int64  `tfsdk:"timeout"`
+	CheckNameservers types.Bool   `tfsdk:"check_nameservers"`
+}
+
+func (r *DnsAcmeChallengeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_acme_challenge"
+}
+
+func (r *DnsAcmeChallengeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource manages an ACME DNS-01 challenge TXT record in a bunny.net DNS zone. It waits until the record is reported by the DNS zone, and removes it on destroy.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "The ID of the TXT record.",
+			},
+			"zone": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: dnsRecordDescription.Zone,
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^([a-zA-Z0-9*_-]+(\.[a-zA-Z0-9_-]+)*)?$`), "must be a hostname relative to the DNS zone"),
+				},
+				MarkdownDescription: `The hostname being validated, relative to the DNS zone. Use <code>name = ""</code> for the apex domain. Wildcards (i.e. <code>*.www</code>) are validated on the parent name.`,
+			},
+			"value": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "The challenge token provided by the ACME server.",
+			},
+			"ttl": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(60),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(15),
+				},
+				Description: dnsRecordDescription.TTL,
+			},
+			"record_name": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The name of the TXT record, relative to the DNS zone.",
+			},
+			"fqdn": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The fully qualified name of the TXT record.",
+			},
+			"poll_interval": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(5),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "How often, in seconds, the TXT record is checked.",
+			},
+			"timeout": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "How long, in seconds, to wait for the TXT record before failing.",
+			},
+			"check_nameservers": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Also wait until the TXT record is served by the zone nameservers. The nameservers are queried directly on port 53, which requires outbound DNS access.",
+			},
+		},
+	}
+}
+
+func (r *DnsAcmeChallengeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DnsAcmeChallengeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataTf DnsAcmeChallengeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataTf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.GetDnsZone(ctx, dataTf.Zone.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create ACME challenge", err.Error())
+		return
+	}
+
+	recordName := dnsAcmeChallengeRecordName(dataTf.Name.ValueString())
+
+	// each challenge gets its own TXT record, so concurrent challenges for the same name don't overwrite each other
+	dataApi, err := r.client.CreateDnsRecord(ctx, api.DnsRecord{
+		Zone:    zone.Id,
+		Type:    api.DnsRecordTypeTXT,
+		Name:    recordName,
+		Value:   dataTf.Value.ValueString(),
+		Ttl:     dataTf.Ttl.ValueInt64(),
+		Comment: "Managed by Terraform (ACME challenge)",
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create ACME challenge", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created ACME challenge record %d in zone %d", dataApi.Id, zone.Id))
+
+	dataTf.Id = types.Int64Value(dataApi.Id)
+	dataTf.RecordName = types.StringValue(recordName)
+	dataTf.Fqdn = types.StringValue(recordName + "." + zone.Domain)
+
+	pollInterval := time.Duration(dataTf.PollInterval.ValueInt64()) * time.Second
+	timeout := time.Duration(dataTf.Timeout.ValueInt64()) * time.Second
+
+	var nameservers []string
+	if dataTf.CheckNameservers.ValueBool() {
+		nameservers = []string{zone.Nameserver1, zone.Nameserver2}
+	}
+
+	err = r.waitForPropagation(ctx, zone.Id, dataApi.Id, nameservers, dataTf.Fqdn.ValueString(), dataTf.Value.ValueString(), pollInterval, timeout)
+	if err != nil {
+		deleteErr := r.client.DeleteDnsRecord(ctx, zone.Id, dataApi.Id)
+		if deleteErr != nil && !errors.Is(deleteErr, api.ErrNotFound) {
+			resp.Diagnostics.AddWarning("Unable to remove ACME challenge record", deleteErr.Error())
+		}
+
+		resp.Diagnostics.AddError("ACME challenge record was not propagated", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *DnsAcmeChallengeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DnsAcmeChallengeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataApi, err := r.client.GetDnsRecord(ctx, data.Zone.ValueInt64(), data.Id.ValueInt64())
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error fetching ACME challenge", err.Error()))
+		return
+	}
+
+	if dataApi.Type != api.DnsRecordTypeTXT || dataApi.Name != data.RecordName.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Value = types.StringValue(dataApi.Value)
+	data.Ttl = types.Int64Value(dataApi.Ttl)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsAcmeChallengeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only poll_interval and timeout can change without replacing the resource, and they are only used on create
+	var data DnsAcmeChallengeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DnsAcmeChallengeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DnsAcmeChallengeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDnsRecord(ctx, data.Zone.ValueInt64(), data.Id.ValueInt64())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error deleting ACME challenge", err.Error()))
+	}
+}
+
+// waitForPropagation waits until the record is returned by the API and, if any, served by the nameservers.
+func (r *DnsAcmeChallengeResource) waitForPropagation(ctx context.Context, zoneId int64, recordId int64, nameservers []string, fqdn string, value string, pollInterval time.Duration, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+
+	for {
+		lastErr = r.checkPropagation(ctx, zoneId, recordId, nameservers, fqdn, value)
+		if lastErr == nil {
+			return nil
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("ACME challenge %s not propagated yet: %s", fqdn, lastErr.Error()))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s: %w", timeout, lastErr)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (r *DnsAcmeChallengeResource) checkPropagation(ctx context.Context, zoneId int64, recordId int64, nameservers []string, fqdn string, value string) error {
+	record, err := r.client.GetDnsRecord(ctx, zoneId, recordId)
+	if err != nil {
+		return err
+	}
+
+	if record.Value != value {
+		return errors.New("the DNS zone does not report the challenge value")
+	}
+
+	for _, nameserver := range nameservers {
+		if nameserver == "" {
+			continue
+		}
+
+		values, err := utils.LookupTXTOnNameserver(ctx, nameserver, fqdn)
+		if err != nil {
+			return fmt.Errorf("%s: %w", nameserver, err)
+		}
+
+		if !slices.Contains(values, value) {
+			return fmt.Errorf("%s does not serve the challenge value", nameserver)
+		}
+	}
+
+	return nil
+}
+
+func dnsAcmeChallengeRecordName(name string) string {
+	name = strings.TrimPrefix(name, "*")
+	name = strings.Trim(name, ".")
+
+	if name == "" {
+		return dnsAcmeChallengeLabel
+	}
+
+	return dnsAcmeChallengeLabel + "." + name
+}
@@ -0,0 +1,53 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const configDnsAcmeChallengeTest = `
+data "bunnynet_dns_zone" "domain" {
+  domain = "terraform.internal"
+}
+
+resource "bunnynet_dns_acme_challenge" "first" {
+  zone  = data.bunnynet_dns_zone.domain.id
+  name  = "test-%s"
+  value = "first-%s"
+}
+
+resource "bunnynet_dns_acme_challenge" "second" {
+  zone  = data.bunnynet_dns_zone.domain.id
+  name  = "*.test-%s"
+  value = "second-%s"
+}
+`
+
+func TestAccDnsAcmeChallengeResource(t *testing.T) {
+	testKey := generateRandomString(8)
+	config := fmt.Sprintf(configDnsAcmeChallengeTest, testKey, testKey, testKey, testKey)
+	recordName := fmt.Sprintf("_acme-challenge.test-%s", testKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunnynet_dns_acme_challenge.first", "record_name", recordName),
+					resource.TestCheckResourceAttr("bunnynet_dns_acme_challenge.first", "fqdn", recordName+".terraform.internal"),
+					resource.TestCheckResourceAttr("bunnynet_dns_acme_challenge.first", "value", "first-"+testKey),
+					resource.TestCheckResourceAttr("bunnynet_dns_acme_challenge.first", "check_nameservers", "false"),
+					resource.TestCheckResourceAttr("bunnynet_dns_acme_challenge.second", "record_name", recordName),
+					resource.TestCheckResourceAttr("bunnynet_dns_acme_challenge.second", "value", "second-"+testKey),
+				),
+			},
+		},
+	})
+}
@@ -11,10 +11,14 @@ import (
 	"strings"
 
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/customtype"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/dnsrecordresourcevalidator"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -49,28 +53,34 @@ type DnsRecordResource struct {
 }
 
 type DnsRecordResourceModel struct {
-	Id                    types.Int64   `tfsdk:"id"`
-	Zone                  types.Int64   `tfsdk:"zone"`
-	Enabled               types.Bool    `tfsdk:"enabled"`
-	Type                  types.String  `tfsdk:"type"`
-	Ttl                   types.Int64   `tfsdk:"ttl"`
-	Value                 types.String  `tfsdk:"value"`
-	Name                  types.String  `tfsdk:"name"`
-	Weight                types.Int64   `tfsdk:"weight"`
-	Priority              types.Int64   `tfsdk:"priority"`
-	Port                  types.Int64   `tfsdk:"port"`
-	Flags                 types.Int64   `tfsdk:"flags"`
-	Tag                   types.String  `tfsdk:"tag"`
-	PullzoneId            types.Int64   `tfsdk:"pullzone_id"`
-	Accelerated           types.Bool    `tfsdk:"accelerated"`
-	AcceleratedPullZoneId types.Int64   `tfsdk:"accelerated_pullzone"`
-	LinkName              types.String  `tfsdk:"link_name"`
-	MonitorType           types.String  `tfsdk:"monitor_type"`
-	GeolocationLatitude   types.Float64 `tfsdk:"geolocation_lat"`
-	GeolocationLongitude  types.Float64 `tfsdk:"geolocation_long"`
-	LatencyZone           types.String  `tfsdk:"latency_zone"`
-	SmartRoutingType      types.String  `tfsdk:"smart_routing_type"`
-	Comment               types.String  `tfsdk:"comment"`
+	Id                    types.Int64                    `tfsdk:"id"`
+	Zone                  types.Int64                    `tfsdk:"zone"`
+	Enabled               types.Bool                     `tfsdk:"enabled"`
+	Type                  types.String                   `tfsdk:"type"`
+	Ttl                   types.Int64                    `tfsdk:"ttl"`
+	Value                 customtype.DnsRecordValueValue `tfsdk:"value"`
+	Name                  types.String                   `tfsdk:"name"`
+	Weight                types.Int64                    `tfsdk:"weight"`
+	Priority              types.Int64                    `tfsdk:"priority"`
+	Port                  types.Int64                    `tfsdk:"port"`
+	Flags                 types.Int64                    `tfsdk:"flags"`
+	Tag                   types.String                   `tfsdk:"tag"`
+	PullzoneId            types.Int64                    `tfsdk:"pullzone_id"`
+	Accelerated           types.Bool                     `tfsdk:"accelerated"`
+	AcceleratedPullZoneId types.Int64                    `tfsdk:"accelerated_pullzone"`
+	LinkName              types.String                   `tfsdk:"link_name"`
+	MonitorType           types.String                   `tfsdk:"monitor_type"`
+	Monitor               types.Object                   `tfsdk:"monitor"`
+	MonitorStatus         types.String                   `tfsdk:"monitor_status"`
+	GeolocationLatitude   types.Float64                  `tfsdk:"geolocation_lat"`
+	GeolocationLongitude  types.Float64                  `tfsdk:"geolocation_long"`
+	LatencyZone           types.String                   `tfsdk:"latency_zone"`
+	SmartRoutingType      types.String                   `tfsdk:"smart_routing_type"`
+	Comment               types.String                   `tfsdk:"comment"`
+}
+
+var dnsRecordMonitorType = map[string]attr.Type{
+	"type": types.StringType,
 }
 
 func (r *DnsRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -131,7 +141,8 @@ func (r *DnsRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				Description: dnsRecordDescription.TTL,
 			},
 			"value": schema.StringAttribute{
-				Required: true,
+				CustomType: customtype.DnsRecordValueType{},
+				Required:   true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -245,6 +256,10 @@ func (r *DnsRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 				MarkdownDescription: dnsRecordDescription.MonitorType,
 			},
+			"monitor_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsRecordDescription.MonitorStatus,
+			},
 			"geolocation_lat": schema.Float64Attribute{
 				Optional: true,
 				Computed: true,
@@ -303,6 +318,23 @@ func (r *DnsRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				Description: dnsRecordDescription.Comment,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"monitor": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Required: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(maps.Values(dnsRecordMonitorTypeMap)...),
+						},
+						MarkdownDescription: dnsRecordDescription.MonitorType,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("monitor_type")),
+				},
+				Description: dnsRecordDescription.Monitor,
+			},
+		},
 	}
 }
 
@@ -336,6 +368,36 @@ func (r *DnsRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyP
 		return
 	}
 
+	// monitor_type is kept in sync with the monitor block, as both map to the same API field
+	{
+		var configMonitor types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("monitor"), &configMonitor)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !configMonitor.IsNull() && !configMonitor.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("monitor_type"), configMonitor.Attributes()["type"])...)
+		}
+	}
+
+	// semantic equality of the value only applies to TXT records, other record types keep the configured value
+	{
+		var configType types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &configType)...)
+
+		var configValue customtype.DnsRecordValueValue
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value"), &configValue)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !configType.IsUnknown() && configType.ValueString() != "TXT" && !configValue.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value"), configValue)...)
+		}
+	}
+
 	typeAttr := path.Root("type")
 	weightAttr := path.Root("weight")
 
@@ -394,12 +456,17 @@ func (r *DnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created dns record %s %s", mapKeyToValue(dnsRecordTypeMap, dataApi.Type), dataApi.Name))
+	monitor := dataTf.Monitor
 	dataTf, diags = dnsRecordApiToTf(ctx, dataApi)
 	if diags != nil {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
+	if monitor.IsNull() {
+		dataTf.Monitor = types.ObjectNull(dnsRecordMonitorType)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -428,6 +495,9 @@ func (r *DnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	if data.Monitor.IsNull() {
+		dataTf.Monitor = types.ObjectNull(dnsRecordMonitorType)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -456,6 +526,10 @@ func (r *DnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	if data.Monitor.IsNull() {
+		dataTf.Monitor = types.ObjectNull(dnsRecordMonitorType)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -503,6 +577,10 @@ func (r *DnsRecordResource) ImportState(ctx context.Context, req resource.Import
 		return
 	}
 
+	if dataApi.MonitorType == 0 {
+		dataTf.Monitor = types.ObjectNull(dnsRecordMonitorType)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -513,6 +591,12 @@ func (r *DnsRecordResource) convertModelToApi(ctx context.Context, dataTf DnsRec
 	dataApi.Type = mapValueToKey(dnsRecordTypeMap, dataTf.Type.ValueString())
 	dataApi.Ttl = dataTf.Ttl.ValueInt64()
 	dataApi.Value = dataTf.Value.ValueString()
+	if dataApi.Type == api.DnsRecordTypeTXT {
+		// values that do not fit a single character-string are split into multiple character-strings
+		if content := utils.UnquoteTxtValue(dataApi.Value); len(content) > utils.TxtCharacterStringMaxLength {
+			dataApi.Value = utils.FormatTxtValue(content)
+		}
+	}
 	dataApi.Name = dataTf.Name.ValueString()
 	dataApi.Weight = dataTf.Weight.ValueInt64()
 	dataApi.Priority = dataTf.Priority.ValueInt64()
@@ -552,7 +636,7 @@ func dnsRecordApiToTf(ctx context.Context, dataApi api.DnsRecord) (DnsRecordReso
 	dataTf.Zone = types.Int64Value(dataApi.Zone)
 	dataTf.Type = types.StringValue(mapKeyToValue(dnsRecordTypeMap, dataApi.Type))
 	dataTf.Ttl = types.Int64Value(dataApi.Ttl)
-	dataTf.Value = types.StringValue(dataApi.Value)
+	dataTf.Value = customtype.DnsRecordValueValue{StringValue: types.StringValue(dataApi.Value)}
 	dataTf.Name = types.StringValue(dataApi.Name)
 	dataTf.Priority = types.Int64Value(dataApi.Priority)
 	dataTf.Port = types.Int64Value(dataApi.Port)
@@ -562,6 +646,7 @@ func dnsRecordApiToTf(ctx context.Context, dataApi api.DnsRecord) (DnsRecordReso
 	dataTf.AcceleratedPullZoneId = types.Int64Value(dataApi.AcceleratedPullZoneId)
 	dataTf.LinkName = types.StringValue(dataApi.LinkName)
 	dataTf.MonitorType = types.StringValue(mapKeyToValue(dnsRecordMonitorTypeMap, dataApi.MonitorType))
+	dataTf.MonitorStatus = types.StringValue(mapKeyToValue(dnsRecordMonitorStatusMap, dataApi.MonitorStatus))
 	dataTf.GeolocationLatitude = types.Float64Value(dataApi.GeolocationLatitude)
 	dataTf.GeolocationLongitude = types.Float64Value(dataApi.GeolocationLongitude)
 	dataTf.LatencyZone = types.StringValue(dataApi.LatencyZone)
@@ -569,6 +654,15 @@ func dnsRecordApiToTf(ctx context.Context, dataApi api.DnsRecord) (DnsRecordReso
 	dataTf.Comment = types.StringValue(dataApi.Comment)
 	dataTf.Enabled = types.BoolValue(!dataApi.Disabled)
 
+	monitor, diags := types.ObjectValue(dnsRecordMonitorType, map[string]attr.Value{
+		"type": dataTf.MonitorType,
+	})
+	if diags != nil {
+		return DnsRecordResourceModel{}, diags
+	}
+
+	dataTf.Monitor = monitor
+
 	if dataApi.Type == api.DnsRecordTypeA || dataApi.Type == api.DnsRecordTypeAAAA || dataApi.Type == api.DnsRecordTypeSRV {
 		dataTf.Weight = types.Int64Value(dataApi.Weight)
 	} else {
@@ -6,12 +6,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -266,6 +268,110 @@ func TestAccDnsRecordResourceWeightTXT(t *testing.T) {
 	})
 }
 
+const configDnsRecordMonitorTest = `
+data "bunnynet_dns_zone" "domain" {
+  domain = "terraform.internal"
+}
+
+resource "bunnynet_dns_record" "record" {
+  zone  = data.bunnynet_dns_zone.domain.id
+  name  = "test-%s"
+  type  = "A"
+  value = "192.0.2.1"
+
+  monitor {
+    type = "%s"
+  }
+}
+`
+
+func TestAccDnsRecordResourceMonitor(t *testing.T) {
+	testKey := generateRandomString(4)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configDnsRecordMonitorTest, testKey, "Ping"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("bunnynet_dns_record.record", tfjsonpath.New("monitor").AtMapKey("type"), knownvalue.StringExact("Ping")),
+					statecheck.ExpectKnownValue("bunnynet_dns_record.record", tfjsonpath.New("monitor_type"), knownvalue.StringExact("Ping")),
+					statecheck.ExpectKnownValue("bunnynet_dns_record.record", tfjsonpath.New("monitor_status"), knownvalue.NotNull()),
+				},
+			},
+			{
+				Config: fmt.Sprintf(configDnsRecordMonitorTest, testKey, "Http"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("bunnynet_dns_record.record", tfjsonpath.New("monitor").AtMapKey("type"), knownvalue.StringExact("Http")),
+					statecheck.ExpectKnownValue("bunnynet_dns_record.record", tfjsonpath.New("monitor_type"), knownvalue.StringExact("Http")),
+				},
+			},
+		},
+	})
+}
+
+const configDnsRecordTxtTest = `
+data "bunnynet_dns_zone" "domain" {
+  domain = "terraform.internal"
+}
+
+resource "bunnynet_dns_record" "record" {
+  zone  = data.bunnynet_dns_zone.domain.id
+  name  = "test-%s._domainkey"
+  type  = "TXT"
+  value = %s
+}
+`
+
+func TestAccDnsRecordResourceTxtNormalization(t *testing.T) {
+	testKey := generateRandomString(4)
+	key := strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA", 8)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configDnsRecordTxtTest, testKey, strconv.Quote("v=DKIM1; k=rsa; p="+key)),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("bunnynet_dns_record.record", tfjsonpath.New("value"), knownvalue.StringExact("v=DKIM1; k=rsa; p="+key)),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(state *terraform.State) error {
+						zoneId, err := strconv.ParseInt(state.RootModule().Resources["data.bunnynet_dns_zone.domain"].Primary.ID, 10, 64)
+						if err != nil {
+							return err
+						}
+
+						recordId, err := strconv.ParseInt(state.RootModule().Resources["bunnynet_dns_record.record"].Primary.ID, 10, 64)
+						if err != nil {
+							return err
+						}
+
+						record, err := newApiClient().GetDnsRecord(context.Background(), zoneId, recordId)
+						if err != nil {
+							return err
+						}
+
+						// values longer than 255 bytes are sent as multiple character-strings
+						if !strings.HasPrefix(record.Value, `"`) || utils.NormalizeTxtValue(record.Value) != "v=DKIM1; k=rsa; p="+key {
+							return fmt.Errorf("expected the value to be split into character-strings, got %s", record.Value)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// the same value, split into quoted character-strings, must not produce a diff
+				Config:   fmt.Sprintf(configDnsRecordTxtTest, testKey, strconv.Quote(`"v=DKIM1; k=rsa; " "p=`+key[:200]+`" "`+key[200:]+`"`)),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccDnsRecordDeletedOutOfBand(t *testing.T) {
 	testKey := generateRandomString(12)
 	recordKey := generateRandomString(4)
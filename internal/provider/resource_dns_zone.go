@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/dnssec"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/dnszoneresourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -59,6 +60,9 @@ type DnsZoneResourceModel struct {
 	DnssecPublicKey    types.String `tfsdk:"dnssec_public_key"`
 	DnssecFlags        types.Int64  `tfsdk:"dnssec_flags"`
 	DnssecKeytag       types.Int64  `tfsdk:"dnssec_keytag"`
+	DnssecDsRecord     types.String `tfsdk:"dnssec_ds_record"`
+	DnssecDnskeyRecord types.String `tfsdk:"dnssec_dnskey_record"`
+	DnssecDsConfigured types.Bool   `tfsdk:"dnssec_ds_configured"`
 }
 
 func (r *DnsZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -214,6 +218,24 @@ func (r *DnsZoneResource) Schema(ctx context.Context, req resource.SchemaRequest
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"dnssec_ds_record": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsZoneDescription.DnssecDsRecord,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dnssec_dnskey_record": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsZoneDescription.DnssecDnskeyRecord,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dnssec_ds_configured": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: dnsZoneDescription.DnssecDsConfigured,
+			},
 		},
 	}
 }
@@ -257,6 +279,8 @@ func (r *DnsZoneResource) ModifyPlan(ctx context.Context, request resource.Modif
 	response.Plan.SetAttribute(ctx, path.Root("dnssec_digest_type"), types.Int64Unknown())
 	response.Plan.SetAttribute(ctx, path.Root("dnssec_flags"), types.Int64Unknown())
 	response.Plan.SetAttribute(ctx, path.Root("dnssec_keytag"), types.Int64Unknown())
+	response.Plan.SetAttribute(ctx, path.Root("dnssec_ds_record"), types.StringUnknown())
+	response.Plan.SetAttribute(ctx, path.Root("dnssec_dnskey_record"), types.StringUnknown())
 }
 
 func (r *DnsZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -423,6 +447,20 @@ func dnsZoneApiToTf(dataApi api.DnsZone) (DnsZoneResourceModel, diag.Diagnostics
 	dataTf.DnssecDigestType = types.Int64Value(int64(dataApi.DnssecDigestType))
 	dataTf.DnssecFlags = types.Int64Value(int64(dataApi.DnssecFlags))
 	dataTf.DnssecKeytag = types.Int64Value(int64(dataApi.DnssecKeyTag))
+	dataTf.DnssecDsConfigured = types.BoolValue(dataApi.DnssecDsConfigured)
+
+	if dataApi.DnssecEnabled {
+		dsRecord := dataApi.DnssecDsRecord
+		if dsRecord == "" {
+			dsRecord = dnssec.FormatDsRecord(dataApi.Domain, dataApi.DnssecKeyTag, dataApi.DnssecAlgorithm, dataApi.DnssecDigestType, dataApi.DnssecDigest)
+		}
+
+		dataTf.DnssecDsRecord = types.StringValue(dsRecord)
+		dataTf.DnssecDnskeyRecord = types.StringValue(dnssec.FormatDnskeyRecord(dataApi.Domain, dataApi.DnssecFlags, dataApi.DnssecAlgorithm, dataApi.DnssecPublicKey))
+	} else {
+		dataTf.DnssecDsRecord = types.StringValue("")
+		dataTf.DnssecDnskeyRecord = types.StringValue("")
+	}
 
 	return dataTf, nil
 }
@@ -13,7 +13,7 @@ import (
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -33,7 +33,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
@@ -48,6 +50,8 @@ var _ resource.ResourceWithConfigValidators = &PullzoneResource{}
 var _ resource.ResourceWithImportState = &PullzoneResource{}
 var _ resource.ResourceWithModifyPlan = &PullzoneResource{}
 
+var pullzoneTemplateIgnoredAttributes = []string{"name", "copy_from"}
+
 func NewPullzoneResource() resource.Resource {
 	return &PullzoneResource{}
 }
@@ -152,6 +156,11 @@ type PullzoneResourceModel struct {
 	WebsocketsMaxConnections           types.Int64   `tfsdk:"websockets_max_connections"`
 }
 
+type PullzoneResourceWithTemplateModel struct {
+	PullzoneResourceModel
+	CopyFrom types.Int64 `tfsdk:"copy_from"`
+}
+
 var pullzoneOriginTypes = map[string]attr.Type{
 	"type":                  types.StringType,
 	"url":                   customtype.PullzoneOriginUrlType{},
@@ -219,6 +228,10 @@ func (r *PullzoneResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 				Description: "The name of the pull zone.",
 			},
+			"copy_from": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The ID of an existing pull zone to be used as template when the pull zone is created. Its settings are used for every attribute not explicitly set in the resource, nested blocks are only copied when omitted entirely, and its edge rules and optimizer classes are copied as well. Changing it afterwards has no effect.",
+			},
 			"cdn_domain": schema.StringAttribute{
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -1313,9 +1326,6 @@ func (r *PullzoneResource) Schema(ctx context.Context, req resource.SchemaReques
 						Description: "The list of budget redirected countries with the two-letter Alpha2 ISO codes. Traffic from a redirected country will connect to the cheapest possible node in North America or Europe.",
 					},
 				},
-				Validators: []validator.Object{
-					objectvalidator.IsRequired(),
-				},
 			},
 		},
 	}
@@ -1326,6 +1336,7 @@ func (r *PullzoneResource) ConfigValidators(ctx context.Context) []resource.Conf
 		pullzoneresourcevalidator.Origin(),
 		pullzoneresourcevalidator.PermacacheCacheExpirationTime(),
 		pullzoneresourcevalidator.CacheStaleBackgroundUpdate(),
+		resourcevalidator.AtLeastOneOf(path.MatchRoot("routing"), path.MatchRoot("copy_from")),
 	}
 }
 
@@ -1348,13 +1359,13 @@ func (r *PullzoneResource) Configure(ctx context.Context, req resource.Configure
 }
 
 func (r *PullzoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var dataTf PullzoneResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataTf)...)
+	var data PullzoneResourceWithTemplateModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	dataApi := r.convertModelToApi(ctx, dataTf)
+	dataApi := r.convertModelToApi(ctx, data.PullzoneResourceModel)
 	pzMutex.Lock(0)
 	dataApi, err := r.client.CreatePullzone(dataApi)
 	pzMutex.Unlock(0)
@@ -1365,17 +1376,31 @@ func (r *PullzoneResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	tflog.Trace(ctx, "created pullzone "+dataApi.Name)
+
+	if !data.CopyFrom.IsNull() {
+		pzMutex.Lock(dataApi.Id)
+		dataApiCopy, err := r.client.CopyPullzoneRules(ctx, data.CopyFrom.ValueInt64(), dataApi.Id)
+		pzMutex.Unlock(dataApi.Id)
+
+		if err != nil {
+			// the pullzone was created, so it must be tracked in the state
+			resp.Diagnostics.AddError("Unable to copy edgerules and optimizer classes from template pullzone", err.Error())
+		} else {
+			dataApi = dataApiCopy
+		}
+	}
+
 	dataTf, diags := r.convertApiToModel(dataApi)
 	if diags != nil {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, PullzoneResourceWithTemplateModel{dataTf, data.CopyFrom})...)
 }
 
 func (r *PullzoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data PullzoneResourceModel
+	var data PullzoneResourceWithTemplateModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -1399,7 +1424,7 @@ func (r *PullzoneResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, PullzoneResourceWithTemplateModel{dataTf, data.CopyFrom})...)
 }
 
 func (r *PullzoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -1407,10 +1432,60 @@ func (r *PullzoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		return
 	}
 
+	// copy_from
+	{
+		var copyFrom types.Int64
+		req.Config.GetAttribute(ctx, path.Root("copy_from"), &copyFrom)
+
+		if !copyFrom.IsNull() {
+			var source func(p path.Path) (attr.Value, diag.Diagnostics)
+
+			if !req.State.Raw.IsNull() {
+				// the template is only applied on create, the values it provided are kept afterwards
+				source = func(p path.Path) (attr.Value, diag.Diagnostics) {
+					var value attr.Value
+					diags := req.State.GetAttribute(ctx, p, &value)
+					return value, diags
+				}
+			} else if copyFrom.IsUnknown() {
+				source = func(p path.Path) (attr.Value, diag.Diagnostics) {
+					attrType, diags := resp.Plan.Schema.TypeAtPath(ctx, p)
+					if diags.HasError() {
+						return nil, diags
+					}
+
+					value, err := attrType.ValueFromTerraform(ctx, tftypes.NewValue(attrType.TerraformType(ctx), tftypes.UnknownValue))
+					if err != nil {
+						diags.AddAttributeError(p, "Error marking attribute as unknown", err.Error())
+					}
+
+					return value, diags
+				}
+			} else {
+				template, diags := r.getTemplate(ctx, copyFrom.ValueInt64(), resp.Plan.Schema.(schema.Schema))
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				source = func(p path.Path) (attr.Value, diag.Diagnostics) {
+					var value attr.Value
+					diags := template.GetAttribute(ctx, p, &value)
+					return value, diags
+				}
+			}
+
+			resp.Diagnostics.Append(pullzoneInheritUnconfigured(ctx, req.Config, &resp.Plan, source)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
 	// permacache_storagezone
 	{
 		var planPermacacheStoragezone types.Int64
-		req.Plan.GetAttribute(ctx, path.Root("permacache_storagezone"), &planPermacacheStoragezone)
+		resp.Plan.GetAttribute(ctx, path.Root("permacache_storagezone"), &planPermacacheStoragezone)
 
 		if planPermacacheStoragezone.IsUnknown() {
 			resp.Plan.SetAttribute(ctx, path.Root("cache_expiration_time"), types.Int64Unknown())
@@ -1425,7 +1500,7 @@ func (r *PullzoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		req.State.GetAttribute(ctx, path.Root("cache_stale"), &stateCacheStale)
 
 		var planCacheStale []string
-		req.Plan.GetAttribute(ctx, path.Root("cache_stale"), &planCacheStale)
+		resp.Plan.GetAttribute(ctx, path.Root("cache_stale"), &planCacheStale)
 
 		if len(planCacheStale) > 0 {
 			resp.Plan.SetAttribute(ctx, path.Root("use_background_update"), true)
@@ -1437,8 +1512,68 @@ func (r *PullzoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 	}
 }
 
+// getTemplate fetches the template pullzone and converts it into the resource schema.
+func (r *PullzoneResource) getTemplate(ctx context.Context, templateId int64, resourceSchema schema.Schema) (tfsdk.State, diag.Diagnostics) {
+	template := tfsdk.State{Schema: resourceSchema}
+
+	templateApi, err := r.client.GetPullzone(templateId)
+	if err != nil {
+		return template, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("copy_from"), "Error fetching template pullzone", err.Error())}
+	}
+
+	templateTf, diags := r.convertApiToModel(templateApi)
+	if diags.HasError() {
+		return template, diags
+	}
+
+	diags.Append(template.Set(ctx, PullzoneResourceWithTemplateModel{templateTf, types.Int64Null()})...)
+	return template, diags
+}
+
+// pullzoneInheritUnconfigured replaces the plan values not explicitly configured with the values provided by source.
+// Nested blocks are inherited as a whole, and only when entirely absent from the configuration.
+func pullzoneInheritUnconfigured(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, source func(p path.Path) (attr.Value, diag.Diagnostics)) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	inherit := func(p path.Path) {
+		value, valueDiags := source(p)
+		diags.Append(valueDiags...)
+		if value != nil {
+			diags.Append(plan.SetAttribute(ctx, p, value)...)
+		}
+	}
+
+	s := plan.Schema.(schema.Schema)
+	for name, attribute := range s.Attributes {
+		if !attribute.IsOptional() || slices.Contains(pullzoneTemplateIgnoredAttributes, name) {
+			continue
+		}
+
+		var configValue attr.Value
+		diags.Append(config.GetAttribute(ctx, path.Root(name), &configValue)...)
+		if configValue != nil && configValue.IsNull() {
+			inherit(path.Root(name))
+		}
+	}
+
+	for name, block := range s.Blocks {
+		var configValue types.Object
+		diags.Append(config.GetAttribute(ctx, path.Root(name), &configValue)...)
+		if !configValue.IsNull() {
+			continue
+		}
+
+		// nested blocks cannot be unknown as a whole, so their attributes are set one by one
+		for attrName := range block.(schema.SingleNestedBlock).Attributes {
+			inherit(path.Root(name).AtName(attrName))
+		}
+	}
+
+	return diags
+}
+
 func (r *PullzoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data PullzoneResourceModel
+	var data PullzoneResourceWithTemplateModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -1450,7 +1585,7 @@ func (r *PullzoneResource) Update(ctx context.Context, req resource.UpdateReques
 
 	pullzoneId := data.Id.ValueInt64()
 	pzMutex.Lock(pullzoneId)
-	dataApi := r.convertModelToApi(ctx, data)
+	dataApi := r.convertModelToApi(ctx, data.PullzoneResourceModel)
 	dataApi, err := r.client.UpdatePullzone(dataApi)
 	pzMutex.Unlock(pullzoneId)
 
@@ -1465,11 +1600,11 @@ func (r *PullzoneResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, PullzoneResourceWithTemplateModel{dataTf, data.CopyFrom})...)
 }
 
 func (r *PullzoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data PullzoneResourceModel
+	var data PullzoneResourceWithTemplateModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -1504,7 +1639,7 @@ func (r *PullzoneResource) ImportState(ctx context.Context, req resource.ImportS
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, PullzoneResourceWithTemplateModel{dataTf, types.Int64Null()})...)
 }
 
 func (r *PullzoneResource) convertModelToApi(ctx context.Context, dataTf PullzoneResourceModel) api.Pullzone {
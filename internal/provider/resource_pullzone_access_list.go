@@ -7,11 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/accesslist"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -19,11 +22,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"golang.org/x/exp/maps"
+	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -31,6 +38,8 @@ import (
 var _ resource.Resource = &PullzoneAccessListResource{}
 var _ resource.ResourceWithConfigure = &PullzoneAccessListResource{}
 var _ resource.ResourceWithImportState = &PullzoneAccessListResource{}
+var _ resource.ResourceWithConfigValidators = &PullzoneAccessListResource{}
+var _ resource.ResourceWithModifyPlan = &PullzoneAccessListResource{}
 
 func NewPullzoneAccessList() resource.Resource {
 	return &PullzoneAccessListResource{}
@@ -41,13 +50,17 @@ type PullzoneAccessListResource struct {
 }
 
 type PullzoneAccessListResourceModel struct {
-	Id       types.Int64  `tfsdk:"id"`
-	Pullzone types.Int64  `tfsdk:"pullzone"`
-	Name     types.String `tfsdk:"name"`
-	Enabled  types.Bool   `tfsdk:"enabled"`
-	Type     types.String `tfsdk:"type"`
-	Action   types.String `tfsdk:"action"`
-	Entries  types.Set    `tfsdk:"entries"`
+	Id            types.Int64  `tfsdk:"id"`
+	Pullzone      types.Int64  `tfsdk:"pullzone"`
+	Name          types.String `tfsdk:"name"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Type          types.String `tfsdk:"type"`
+	Action        types.String `tfsdk:"action"`
+	Entries       types.Set    `tfsdk:"entries"`
+	SourceFile    types.String `tfsdk:"source_file"`
+	SourceContent types.String `tfsdk:"source_content"`
+	SourceFormat  types.String `tfsdk:"source_format"`
+	ContentHash   types.String `tfsdk:"content_hash"`
 }
 
 func (r *PullzoneAccessListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -116,16 +129,78 @@ func (r *PullzoneAccessListResource) Schema(ctx context.Context, req resource.Sc
 			},
 			"entries": schema.SetAttribute{
 				ElementType: types.StringType,
-				Required:    true,
+				Optional:    true,
 				PlanModifiers: []planmodifier.Set{
 					setplanmodifier.UseStateForUnknown(),
 				},
-				Description: "The Access List entries.",
+				Description: "The Access List entries. Conflicts with `source_file` and `source_content`.",
+			},
+			"source_file": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "Path to a file with the Access List entries. The entries are validated, normalized and deduplicated, and overlapping or adjacent CIDRs are collapsed. Only `content_hash` is kept in the state. Conflicts with `entries` and `source_content`.",
+			},
+			"source_content": schema.StringAttribute{
+				Optional:    true,
+				Description: "The Access List entries as the content of a file, in the format defined by `source_format`. The entries are validated, normalized and deduplicated, and overlapping or adjacent CIDRs are collapsed. Conflicts with `entries` and `source_file`.",
+			},
+			"source_format": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(string(accesslist.FormatLines)),
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(accesslist.FormatLines), string(accesslist.FormatCsv), string(accesslist.FormatCsvHeader)),
+				},
+				MarkdownDescription: "The format of `source_file` and `source_content`: `lines` for one entry per line, `csv` to use the first column of each record, or `csv_header` to do the same while skipping the first record. Comments starting with `#`, `;` or `//`, at the beginning of a line or after whitespace, are ignored, except for `Organization` lists.",
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "The SHA-256 hash of the normalized entries.",
 			},
 		},
 	}
 }
 
+func (r *PullzoneAccessListResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("entries"),
+			path.MatchRoot("source_file"),
+			path.MatchRoot("source_content"),
+		),
+	}
+}
+
+func (r *PullzoneAccessListResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan PullzoneAccessListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// entries referencing other resources might only be known during apply
+	if plan.Type.IsUnknown() || plan.Entries.IsUnknown() || slices.ContainsFunc(plan.Entries.Elements(), attr.Value.IsUnknown) || plan.SourceFile.IsUnknown() || plan.SourceContent.IsUnknown() || plan.SourceFormat.IsUnknown() {
+		plan.ContentHash = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	entries, diags := r.loadEntries(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ContentHash = types.StringValue(accesslist.Hash(entries))
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
 func (r *PullzoneAccessListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -151,7 +226,12 @@ func (r *PullzoneAccessListResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	dataApi := r.convertModelToApi(ctx, dataTf)
+	dataApi, diags := r.convertModelToApi(ctx, dataTf)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	dataApi, err := r.client.CreatePullzoneAccessList(ctx, dataApi)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create access list", err.Error())
@@ -159,13 +239,14 @@ func (r *PullzoneAccessListResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created access list for pullzone %d", dataTf.Pullzone.ValueInt64()))
-	dataTf, diags := r.convertApiToModel(ctx, dataApi)
+	result, diags := r.convertApiToModel(ctx, dataApi)
 	if diags != nil {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+	r.keepSource(&result, dataTf)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
 }
 
 func (r *PullzoneAccessListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -192,6 +273,7 @@ func (r *PullzoneAccessListResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	r.keepSource(&dataTf, data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -202,7 +284,12 @@ func (r *PullzoneAccessListResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	dataApi := r.convertModelToApi(ctx, data)
+	dataApi, diags := r.convertModelToApi(ctx, data)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	dataApiResult, err := r.client.UpdatePullzoneAccessList(ctx, dataApi)
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating access list", err.Error()))
@@ -215,6 +302,7 @@ func (r *PullzoneAccessListResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	r.keepSource(&dataTf, data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -265,7 +353,7 @@ func (r *PullzoneAccessListResource) ImportState(ctx context.Context, req resour
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
-func (r *PullzoneAccessListResource) convertModelToApi(ctx context.Context, dataTf PullzoneAccessListResourceModel) api.PullzoneAccessList {
+func (r *PullzoneAccessListResource) convertModelToApi(ctx context.Context, dataTf PullzoneAccessListResourceModel) (api.PullzoneAccessList, diag.Diagnostics) {
 	dataApi := api.PullzoneAccessList{}
 	dataApi.Id = dataTf.Id.ValueInt64()
 	dataApi.PullzoneId = dataTf.Pullzone.ValueInt64()
@@ -275,7 +363,18 @@ func (r *PullzoneAccessListResource) convertModelToApi(ctx context.Context, data
 	dataApi.Action = mapValueToKey(pullzoneAccessListActionMap, dataTf.Action.ValueString())
 
 	// entries
-	{
+	if dataTf.Entries.IsNull() {
+		entries, diags := r.loadEntries(dataTf)
+		if diags.HasError() {
+			return dataApi, diags
+		}
+
+		if !dataTf.ContentHash.IsUnknown() && accesslist.Hash(entries) != dataTf.ContentHash.ValueString() {
+			return dataApi, diag.Diagnostics{diag.NewErrorDiagnostic("Access List source changed", "The content of the Access List source changed after the plan was created, please run terraform plan again.")}
+		}
+
+		dataApi.Entries = entries
+	} else {
 		entriesTf := dataTf.Entries.Elements()
 		entries := make([]string, 0, len(entriesTf))
 		for _, entry := range entriesTf {
@@ -284,7 +383,61 @@ func (r *PullzoneAccessListResource) convertModelToApi(ctx context.Context, data
 		dataApi.Entries = entries
 	}
 
-	return dataApi
+	return dataApi, nil
+}
+
+// loadEntries returns the normalized entries, from either entries, source_file or source_content.
+func (r *PullzoneAccessListResource) loadEntries(dataTf PullzoneAccessListResourceModel) ([]string, diag.Diagnostics) {
+	listType := accesslist.Type(mapValueToKey(pullzoneAccessListTypeMap, dataTf.Type.ValueString()))
+
+	if !dataTf.Entries.IsNull() {
+		entries := make([]string, 0, len(dataTf.Entries.Elements()))
+		for _, entry := range dataTf.Entries.Elements() {
+			entries = append(entries, entry.(types.String).ValueString())
+		}
+
+		result, err := accesslist.Normalize(listType, entries)
+		if err != nil {
+			return nil, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("entries"), "Invalid Access List entry", err.Error())}
+		}
+
+		return result, nil
+	}
+
+	content := dataTf.SourceContent.ValueString()
+	attributePath := path.Root("source_content")
+
+	if !dataTf.SourceFile.IsNull() {
+		attributePath = path.Root("source_file")
+		fileContent, err := os.ReadFile(dataTf.SourceFile.ValueString())
+		if err != nil {
+			return nil, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(attributePath, "Unable to read Access List source", err.Error())}
+		}
+
+		content = string(fileContent)
+	}
+
+	result, err := accesslist.Load(content, accesslist.Format(dataTf.SourceFormat.ValueString()), listType)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(attributePath, "Invalid Access List source", err.Error())}
+	}
+
+	return result, nil
+}
+
+// keepSource preserves the source attributes from prior. Entries are not kept in the state when a source is used.
+func (r *PullzoneAccessListResource) keepSource(dataTf *PullzoneAccessListResourceModel, prior PullzoneAccessListResourceModel) {
+	if !prior.SourceFormat.IsNull() {
+		dataTf.SourceFormat = prior.SourceFormat
+	}
+
+	if prior.SourceFile.IsNull() && prior.SourceContent.IsNull() {
+		return
+	}
+
+	dataTf.Entries = types.SetNull(types.StringType)
+	dataTf.SourceFile = prior.SourceFile
+	dataTf.SourceContent = prior.SourceContent
 }
 
 func (r *PullzoneAccessListResource) convertApiToModel(ctx context.Context, dataApi api.PullzoneAccessList) (PullzoneAccessListResourceModel, diag.Diagnostics) {
@@ -308,6 +461,28 @@ func (r *PullzoneAccessListResource) convertApiToModel(ctx context.Context, data
 	}
 
 	dataTf.Entries = entriesSet
+	dataTf.SourceFile = types.StringNull()
+	dataTf.SourceContent = types.StringNull()
+	dataTf.SourceFormat = types.StringValue(string(accesslist.FormatLines))
+	dataTf.ContentHash = types.StringValue(pullzoneAccessListContentHash(dataApi))
 
 	return dataTf, nil
 }
+
+// pullzoneAccessListContentHash hashes the entries returned by the API, normalized in the same way as the configured entries.
+func pullzoneAccessListContentHash(dataApi api.PullzoneAccessList) string {
+	entries := make([]string, 0, len(dataApi.Entries))
+	for _, entry := range dataApi.Entries {
+		if strings.TrimSpace(entry) != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	normalized, err := accesslist.Normalize(accesslist.Type(dataApi.Type), entries)
+	if err != nil {
+		sort.Strings(entries)
+		return accesslist.Hash(entries)
+	}
+
+	return accesslist.Hash(normalized)
+}
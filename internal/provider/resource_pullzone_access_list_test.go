@@ -0,0 +1,128 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/accesslist"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const configPullzoneAccessListPullzoneTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+`
+
+const configPullzoneAccessListSourceContentTest = configPullzoneAccessListPullzoneTest + `
+resource "bunnynet_pullzone_access_list" "test" {
+  pullzone       = bunnynet_pullzone.test.id
+  name           = "test-acceptance-%s"
+  action         = "Block"
+  type           = "CIDR"
+  source_content = %q
+}
+`
+
+const configPullzoneAccessListSourceFileTest = configPullzoneAccessListPullzoneTest + `
+resource "bunnynet_pullzone_access_list" "test" {
+  pullzone      = bunnynet_pullzone.test.id
+  name          = "test-acceptance-%s"
+  action        = "Block"
+  type          = "CIDR"
+  source_file   = %q
+  source_format = "csv_header"
+}
+`
+
+const configPullzoneAccessListUnknownEntriesTest = configPullzoneAccessListPullzoneTest + `
+resource "bunnynet_pullzone_access_list" "test" {
+  pullzone = bunnynet_pullzone.test.id
+  name     = "test-acceptance-%s"
+  action   = "Block"
+  type     = "CIDR"
+  entries  = ["192.0.2.0/24", format("198.51.%%d.0/24", bunnynet_pullzone.test.id %% 256)]
+}
+`
+
+func TestAccPullzoneAccessListUnknownEntriesResource(t *testing.T) {
+	resourceName := "bunnynet_pullzone_access_list.test"
+	testKey := generateRandomString(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configPullzoneAccessListUnknownEntriesTest, testKey, testKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "entries.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "content_hash"),
+				),
+			},
+			{
+				Config:   fmt.Sprintf(configPullzoneAccessListUnknownEntriesTest, testKey, testKey),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccPullzoneAccessListSourceResource(t *testing.T) {
+	resourceName := "bunnynet_pullzone_access_list.test"
+	testKey := generateRandomString(12)
+	contentHash := accesslist.Hash([]string{"192.0.2.0/24", "198.51.100.0/24"})
+
+	path := filepath.Join(t.TempDir(), "threat-feed.csv")
+	err := os.WriteFile(path, []byte("network,reason\n198.51.100.0/24,botnet\n192.0.2.0/24,scanner\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configPullzoneAccessListSourceContentTest, testKey, testKey, "# feed\n192.0.2.0/25\n192.0.2.128/25 ; adjacent\n198.51.100.0/24\n"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "content_hash", contentHash),
+					resource.TestCheckResourceAttr(resourceName, "source_format", "lines"),
+					resource.TestCheckNoResourceAttr(resourceName, "entries.#"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(configPullzoneAccessListSourceFileTest, testKey, testKey, path),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "content_hash", contentHash),
+					resource.TestCheckResourceAttr(resourceName, "source_file", path),
+					resource.TestCheckNoResourceAttr(resourceName, "source_content"),
+					resource.TestCheckNoResourceAttr(resourceName, "entries.#"),
+				),
+			},
+			{
+				Config:   fmt.Sprintf(configPullzoneAccessListSourceFileTest, testKey, testKey, path),
+				PlanOnly: true,
+			},
+			{
+				Config:      fmt.Sprintf(configPullzoneAccessListSourceContentTest, testKey, testKey, "192.0.2.0/24\n192.0.2.1#feed\n"),
+				ExpectError: regexp.MustCompile(`is not a valid CIDR`),
+			},
+		},
+	})
+}
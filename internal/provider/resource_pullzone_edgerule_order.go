@@ -0,0 +1,237 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+)
+
+var _ resource.Resource = &PullzoneEdgeruleOrderResource{}
+var _ resource.ResourceWithConfigure = &PullzoneEdgeruleOrderResource{}
+var _ resource.ResourceWithImportState = &PullzoneEdgeruleOrderResource{}
+
+func NewPullzoneEdgeruleOrderResource() resource.Resource {
+	return &PullzoneEdgeruleOrderResource{}
+}
+
+type PullzoneEdgeruleOrderResource struct {
+	client *api.Client
+}
+
+type PullzoneEdgeruleOrderResourceModel struct {
+	PullzoneId types.Int64 `tfsdk:"pullzone"`
+	Edgerules  types.List  `tfsdk:"edgerules"`
+}
+
+func (r *PullzoneEdgeruleOrderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_edgerule_order"
+}
+
+func (r *PullzoneEdgeruleOrderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages the execution order of the edge rules for a bunny.net pull zone. The listed edge rules are applied first, in the given order, followed by any other edge rules in their current order.\n\nWhen using this resource, the order is no longer defined by the `priority` attribute of `bunnynet_pullzone_edgerule`, so add `priority` to `lifecycle.ignore_changes` in those resources. Destroying this resource does not change the order of the edge rules.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "The ID of the linked pull zone.",
+			},
+			"edgerules": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+				Description: "The IDs of the edge rules, in execution order.",
+			},
+		},
+	}
+}
+
+func (r *PullzoneEdgeruleOrderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PullzoneEdgeruleOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataTf PullzoneEdgeruleOrderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataTf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataTf, err := r.apply(ctx, dataTf)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to order edgerules", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("ordered edgerules for pullzone %d", dataTf.PullzoneId.ValueInt64()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneEdgeruleOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PullzoneEdgeruleOrderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var guids []string
+	resp.Diagnostics.Append(data.Edgerules.ElementsAs(ctx, &guids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pullzoneId := data.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	edgerules, err := r.client.GetPullzoneEdgerules(pullzoneId)
+	pzMutex.Unlock(pullzoneId)
+
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error fetching edgerules", err.Error()))
+		return
+	}
+
+	dataTf, diags := r.convertApiToModel(pullzoneId, edgerules, guids)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneEdgeruleOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PullzoneEdgeruleOrderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataTf, err := r.apply(ctx, data)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error ordering edgerules", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneEdgeruleOrderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the order of the edge rules is kept as-is
+}
+
+func (r *PullzoneEdgeruleOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pullzoneId, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding edgerules", "Use \"<pullzoneId>\" as ID on terraform import command"))
+		return
+	}
+
+	edgerules, err := r.client.GetPullzoneEdgerules(pullzoneId)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding edgerules", err.Error()))
+		return
+	}
+
+	dataTf, diags := r.convertApiToModel(pullzoneId, edgerules, nil)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneEdgeruleOrderResource) apply(ctx context.Context, dataTf PullzoneEdgeruleOrderResourceModel) (PullzoneEdgeruleOrderResourceModel, error) {
+	var guids []string
+	if diags := dataTf.Edgerules.ElementsAs(ctx, &guids, false); diags.HasError() {
+		return dataTf, errors.New(diags[0].Detail())
+	}
+
+	pullzoneId := dataTf.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	edgerules, err := r.client.UpdatePullzoneEdgeruleOrder(ctx, pullzoneId, guids)
+	pzMutex.Unlock(pullzoneId)
+
+	if err != nil {
+		return dataTf, err
+	}
+
+	result, diags := r.convertApiToModel(pullzoneId, edgerules, guids)
+	if diags.HasError() {
+		return dataTf, errors.New(diags[0].Detail())
+	}
+
+	return result, nil
+}
+
+// convertApiToModel lists the managed edge rules in their current execution order, so changes made outside terraform are reported as drift.
+// When managed is nil, all edge rules are listed.
+func (r *PullzoneEdgeruleOrderResource) convertApiToModel(pullzoneId int64, edgerules []api.PullzoneEdgerule, managed []string) (PullzoneEdgeruleOrderResourceModel, diag.Diagnostics) {
+	managedMap := make(map[string]bool, len(managed))
+	for _, guid := range managed {
+		managedMap[guid] = true
+	}
+
+	values := []attr.Value{}
+	for _, edgerule := range edgerules {
+		if managed != nil && !managedMap[edgerule.Id] {
+			continue
+		}
+
+		values = append(values, types.StringValue(edgerule.Id))
+	}
+
+	list, diags := types.ListValue(types.StringType, values)
+	if diags != nil {
+		return PullzoneEdgeruleOrderResourceModel{}, diags
+	}
+
+	return PullzoneEdgeruleOrderResourceModel{
+		PullzoneId: types.Int64Value(pullzoneId),
+		Edgerules:  list,
+	}, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const configPullzoneEdgeruleOrderTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_edgerule" "first" {
+  enabled     = true
+  pullzone    = bunnynet_pullzone.test.id
+  action      = "BlockRequest"
+  description = "Block access to admin"
+
+  triggers = [
+    {
+      type       = "Url"
+      match_type = "MatchAny"
+      patterns   = ["*/wp-admin/*"]
+      parameter1 = null
+      parameter2 = null
+    }
+  ]
+
+  lifecycle {
+    ignore_changes = [priority]
+  }
+}
+
+resource "bunnynet_pullzone_edgerule" "second" {
+  enabled     = true
+  pullzone    = bunnynet_pullzone.test.id
+  action      = "ForceSSL"
+  description = "Force SSL"
+
+  triggers = [
+    {
+      type       = "Url"
+      match_type = "MatchAny"
+      patterns   = ["http://*"]
+      parameter1 = null
+      parameter2 = null
+    }
+  ]
+
+  lifecycle {
+    ignore_changes = [priority]
+  }
+}
+
+resource "bunnynet_pullzone_edgerule_order" "test" {
+  pullzone  = bunnynet_pullzone.test.id
+  edgerules = [%s]
+}
+`
+
+func TestAccPullzoneEdgeruleOrderResource(t *testing.T) {
+	resourceName := "bunnynet_pullzone_edgerule_order.test"
+	testKey := generateRandomString(12)
+
+	var pullzoneId int64
+	var guids []string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configPullzoneEdgeruleOrderTest, testKey, "bunnynet_pullzone_edgerule.second.id, bunnynet_pullzone_edgerule.first.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "edgerules.#", "2"),
+					resource.TestCheckResourceAttrPair(resourceName, "edgerules.0", "bunnynet_pullzone_edgerule.second", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "edgerules.1", "bunnynet_pullzone_edgerule.first", "id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(configPullzoneEdgeruleOrderTest, testKey, "bunnynet_pullzone_edgerule.first.id, bunnynet_pullzone_edgerule.second.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "edgerules.0", "bunnynet_pullzone_edgerule.first", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "edgerules.1", "bunnynet_pullzone_edgerule.second", "id"),
+					func(state *terraform.State) error {
+						var err error
+						resources := state.RootModule().Resources
+						pullzoneId, err = strconv.ParseInt(resources["bunnynet_pullzone.test"].Primary.ID, 10, 64)
+						if err != nil {
+							return err
+						}
+
+						guids = []string{
+							resources["bunnynet_pullzone_edgerule.second"].Primary.ID,
+							resources["bunnynet_pullzone_edgerule.first"].Primary.ID,
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// order changed outside terraform
+				PreConfig: func() {
+					_, err := newApiClient().UpdatePullzoneEdgeruleOrder(context.Background(), pullzoneId, guids)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             fmt.Sprintf(configPullzoneEdgeruleOrderTest, testKey, "bunnynet_pullzone_edgerule.first.id, bunnynet_pullzone_edgerule.second.id"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fmt.Sprintf(configPullzoneEdgeruleOrderTest, testKey, "bunnynet_pullzone_edgerule.first.id, bunnynet_pullzone_edgerule.second.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "edgerules.0", "bunnynet_pullzone_edgerule.first", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,246 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+)
+
+var _ resource.Resource = &PullzoneEdgerulesResource{}
+var _ resource.ResourceWithConfigure = &PullzoneEdgerulesResource{}
+var _ resource.ResourceWithImportState = &PullzoneEdgerulesResource{}
+
+func NewPullzoneEdgerulesResource() resource.Resource {
+	return &PullzoneEdgerulesResource{}
+}
+
+type PullzoneEdgerulesResource struct {
+	client *api.Client
+}
+
+type PullzoneEdgerulesResourceModel struct {
+	PullzoneId types.Int64 `tfsdk:"pullzone"`
+	Edgerules  types.Set   `tfsdk:"edgerules"`
+}
+
+func (r *PullzoneEdgerulesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_edgerules"
+}
+
+func (r *PullzoneEdgerulesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource makes terraform authoritative over the edge rules of a bunny.net pull zone. Edge rules that are not listed, such as rules added in the dashboard, are reported as drift and deleted on apply.\n\nThe edge rules themselves are still managed with `bunnynet_pullzone_edgerule`. Destroying this resource does not delete any edge rule.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "The ID of the linked pull zone.",
+			},
+			"edgerules": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "The IDs of all the edge rules allowed in the pull zone.",
+			},
+		},
+	}
+}
+
+func (r *PullzoneEdgerulesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PullzoneEdgerulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataTf PullzoneEdgerulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataTf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataTf, err := r.apply(ctx, dataTf)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to manage edgerules", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("managing edgerules for pullzone %d", dataTf.PullzoneId.ValueInt64()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneEdgerulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PullzoneEdgerulesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pullzoneId := data.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	edgerules, err := r.client.GetPullzoneEdgerules(pullzoneId)
+	pzMutex.Unlock(pullzoneId)
+
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error fetching edgerules", err.Error()))
+		return
+	}
+
+	dataTf, diags := r.convertApiToModel(pullzoneId, edgerules)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneEdgerulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PullzoneEdgerulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataTf, err := r.apply(ctx, data)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating edgerules", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneEdgerulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the edge rules are kept as-is
+}
+
+func (r *PullzoneEdgerulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pullzoneId, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding edgerules", "Use \"<pullzoneId>\" as ID on terraform import command"))
+		return
+	}
+
+	edgerules, err := r.client.GetPullzoneEdgerules(pullzoneId)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding edgerules", err.Error()))
+		return
+	}
+
+	dataTf, diags := r.convertApiToModel(pullzoneId, edgerules)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+// apply deletes the edge rules that are not in the plan.
+func (r *PullzoneEdgerulesResource) apply(ctx context.Context, dataTf PullzoneEdgerulesResourceModel) (PullzoneEdgerulesResourceModel, error) {
+	var guids []string
+	if diags := dataTf.Edgerules.ElementsAs(ctx, &guids, false); diags.HasError() {
+		return dataTf, errors.New(diags[0].Detail())
+	}
+
+	allowed := make(map[string]bool, len(guids))
+	for _, guid := range guids {
+		allowed[guid] = true
+	}
+
+	pullzoneId := dataTf.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	defer pzMutex.Unlock(pullzoneId)
+
+	edgerules, err := r.client.GetPullzoneEdgerules(pullzoneId)
+	if err != nil {
+		return dataTf, err
+	}
+
+	existing := make(map[string]bool, len(edgerules))
+	for _, edgerule := range edgerules {
+		existing[edgerule.Id] = true
+	}
+
+	for _, guid := range guids {
+		if !existing[guid] {
+			return dataTf, fmt.Errorf("edgerule %s not found", guid)
+		}
+	}
+
+	remaining := make([]api.PullzoneEdgerule, 0, len(edgerules))
+	for _, edgerule := range edgerules {
+		if allowed[edgerule.Id] {
+			remaining = append(remaining, edgerule)
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("deleting unmanaged edgerule %s (%s) from pullzone %d", edgerule.Id, edgerule.Description, pullzoneId))
+		err = r.client.DeletePullzoneEdgerule(pullzoneId, edgerule.Id)
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return dataTf, fmt.Errorf("could not delete edgerule %s: %w", edgerule.Id, err)
+		}
+	}
+
+	result, diags := r.convertApiToModel(pullzoneId, remaining)
+	if diags.HasError() {
+		return dataTf, errors.New(diags[0].Detail())
+	}
+
+	return result, nil
+}
+
+func (r *PullzoneEdgerulesResource) convertApiToModel(pullzoneId int64, edgerules []api.PullzoneEdgerule) (PullzoneEdgerulesResourceModel, diag.Diagnostics) {
+	values := make([]attr.Value, len(edgerules))
+	for i, edgerule := range edgerules {
+		values[i] = types.StringValue(edgerule.Id)
+	}
+
+	set, diags := types.SetValue(types.StringType, values)
+	if diags != nil {
+		return PullzoneEdgerulesResourceModel{}, diags
+	}
+
+	return PullzoneEdgerulesResourceModel{
+		PullzoneId: types.Int64Value(pullzoneId),
+		Edgerules:  set,
+	}, nil
+}
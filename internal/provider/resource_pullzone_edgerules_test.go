@@ -0,0 +1,119 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const configPullzoneEdgerulesTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_edgerule" "test" {
+  enabled     = true
+  pullzone    = bunnynet_pullzone.test.id
+  action      = "BlockRequest"
+  description = "Block access to admin"
+
+  triggers = [
+    {
+      type       = "Url"
+      match_type = "MatchAny"
+      patterns   = ["*/wp-admin/*"]
+      parameter1 = null
+      parameter2 = null
+    }
+  ]
+}
+
+resource "bunnynet_pullzone_edgerules" "test" {
+  pullzone  = bunnynet_pullzone.test.id
+  edgerules = [bunnynet_pullzone_edgerule.test.id]
+}
+`
+
+func TestAccPullzoneEdgerulesResource(t *testing.T) {
+	resourceName := "bunnynet_pullzone_edgerules.test"
+	testKey := generateRandomString(12)
+	config := fmt.Sprintf(configPullzoneEdgerulesTest, testKey)
+
+	var pullzoneId int64
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "edgerules.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "edgerules.*", "bunnynet_pullzone_edgerule.test", "id"),
+					func(state *terraform.State) error {
+						var err error
+						pullzoneId, err = strconv.ParseInt(state.RootModule().Resources["bunnynet_pullzone.test"].Primary.ID, 10, 64)
+						return err
+					},
+				),
+			},
+			{
+				// edge rule added outside terraform
+				PreConfig: func() {
+					_, err := newApiClient().CreatePullzoneEdgerule(context.Background(), api.PullzoneEdgerule{
+						PullzoneId:   pullzoneId,
+						Enabled:      true,
+						Description:  "Unmanaged",
+						Action:       0,
+						ExtraActions: []api.PullzoneEdgeruleExtraAction{},
+						Triggers: []api.PullzoneEdgeruleTrigger{
+							{Type: 0, MatchType: 0, Patterns: []string{"http://*"}},
+						},
+					})
+
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "edgerules.#", "1"),
+					func(state *terraform.State) error {
+						edgerules, err := newApiClient().GetPullzoneEdgerules(pullzoneId)
+						if err != nil {
+							return err
+						}
+
+						if len(edgerules) != 1 {
+							return fmt.Errorf("expected 1 edgerule, got %d", len(edgerules))
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -9,15 +9,18 @@ import (
 	"fmt"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/boolvalidator"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/certificate"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/pullzonehostnameresourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -25,15 +28,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var _ resource.Resource = &PullzoneHostnameResource{}
 var _ resource.ResourceWithConfigure = &PullzoneHostnameResource{}
 var _ resource.ResourceWithConfigValidators = &PullzoneHostnameResource{}
 var _ resource.ResourceWithImportState = &PullzoneHostnameResource{}
+var _ resource.ResourceWithModifyPlan = &PullzoneHostnameResource{}
 
 func NewPullzoneHostnameResource() resource.Resource {
 	return &PullzoneHostnameResource{}
@@ -52,6 +58,27 @@ type PullzoneHostnameResourceModel struct {
 	ForceSSL       types.Bool   `tfsdk:"force_ssl"`
 	Certificate    types.String `tfsdk:"certificate"`
 	CertificateKey types.String `tfsdk:"certificate_key"`
+	Managed        types.Object `tfsdk:"managed_certificate"`
+	ExpiryWarning  types.Int64  `tfsdk:"certificate_expiry_warning_days"`
+	NotAfter       types.String `tfsdk:"certificate_not_after"`
+	Issuer         types.String `tfsdk:"certificate_issuer"`
+	Sans           types.List   `tfsdk:"certificate_sans"`
+}
+
+const pullzoneHostnameDefaultExpiryWarningDays = pullzonehostnameresourcevalidator.DefaultCertificateExpiryWarningDays
+
+// pullzoneHostnameDiagnoseTimeout limits the DNS lookups made to explain a managed certificate error.
+const pullzoneHostnameDiagnoseTimeout = 10 * time.Second
+
+// pullzoneHostnameRetryableCertificateErrors are the free certificate errors caused by DNS records that are still propagating.
+var pullzoneHostnameRetryableCertificateErrors = []*regexp.Regexp{
+	regexp.MustCompile(`loadFreeCertificate failed: The domain .* is not pointing to our servers\.`),
+	regexp.MustCompile(`loadFreeCertificate failed: The certificate could not be requested\.`),
+}
+
+var pullzoneHostnameManagedCertificateType = map[string]attr.Type{
+	"timeout":       types.Int64Type,
+	"poll_interval": types.Int64Type,
 }
 
 func (r *PullzoneHostnameResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -144,6 +171,53 @@ func (r *PullzoneHostnameResource) Schema(ctx context.Context, req resource.Sche
 				},
 				MarkdownDescription: `The certificate private key for the hostname, in PEM format. ***Important***: the Bunny API will not return the certificate key, so you'll have to make sure you're importing the correct certificate key.`,
 			},
+			"certificate_expiry_warning_days": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(pullzoneHostnameDefaultExpiryWarningDays),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "Emit a warning during plan if the custom certificate expires within this number of days.",
+			},
+			"certificate_not_after": schema.StringAttribute{
+				Computed:    true,
+				Description: "The expiration date of the custom certificate, in RFC 3339 format.",
+			},
+			"certificate_issuer": schema.StringAttribute{
+				Computed:    true,
+				Description: "The issuer of the custom certificate.",
+			},
+			"certificate_sans": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The Subject Alternative Names in the custom certificate.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"managed_certificate": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.Int64Attribute{
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(600),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+						Description: "How long, in seconds, to wait for the certificate to be issued.",
+					},
+					"poll_interval": schema.Int64Attribute{
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(15),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+						Description: "How often, in seconds, to check whether the certificate was issued.",
+					},
+				},
+				MarkdownDescription: `Requests a free, Domain-validated certificate for the hostname and waits until it is issued. The DNS records for the hostname must point to bunny.net (i.e. a CNAME to <code>*.b-cdn.net</code>). Requires <code>tls_enabled = true</code>.`,
+			},
 		},
 	}
 }
@@ -151,7 +225,31 @@ func (r *PullzoneHostnameResource) Schema(ctx context.Context, req resource.Sche
 func (r *PullzoneHostnameResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		pullzonehostnameresourcevalidator.CustomCertificate(),
+		pullzonehostnameresourcevalidator.ManagedCertificate(),
+		pullzonehostnameresourcevalidator.CertificateDetails(),
+	}
+}
+
+func (r *PullzoneHostnameResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan PullzoneHostnameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	if plan.Certificate.IsUnknown() {
+		plan.NotAfter = types.StringUnknown()
+		plan.Issuer = types.StringUnknown()
+		plan.Sans = types.ListUnknown(types.StringType)
+	} else {
+		plan.NotAfter, plan.Issuer, plan.Sans = pullzoneHostnameCertificateDetails(plan.Certificate.ValueString())
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
 }
 
 func (r *PullzoneHostnameResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -184,6 +282,14 @@ func (r *PullzoneHostnameResource) Create(ctx context.Context, req resource.Crea
 	pullzoneId := dataApi.PullzoneId
 	certificate := dataApi.Certificate
 	certificateKey := dataApi.CertificateKey
+	managed := dataTf.Managed
+	expiryWarning := dataTf.ExpiryWarning
+
+	// managed certificates are requested once the hostname exists, so DNS propagation delays can be retried
+	if !managed.IsNull() {
+		dataApi.HasCertificate = false
+		dataApi.ForceSSL = false
+	}
 
 	dataApi, err := r.client.CreatePullzoneHostname(dataApi)
 	if err != nil {
@@ -210,6 +316,21 @@ func (r *PullzoneHostnameResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created hostname for pullzone %d", pullzoneId))
+
+	if !managed.IsNull() {
+		dataApi, err = r.applyManagedCertificate(ctx, dataApi, dataTf.ForceSSL.ValueBool(), managed)
+		if err != nil {
+			err2 := r.client.DeletePullzoneHostname(pullzoneId, hostname)
+			if err2 != nil {
+				tflog.Error(ctx, fmt.Sprintf("Delete hostname for pullzone %d failed: %s", pullzoneId, err2.Error()))
+				resp.Diagnostics.AddWarning("pullzone_hostname is in a dirty state", "The hostname creation failed, and unfortunately the cleanup did too. You'll have to manually remove the hostname in dash.bunny.net, or import it in terraform to continue.")
+			}
+
+			resp.Diagnostics.AddError("Unable to obtain managed certificate", err.Error())
+			return
+		}
+	}
+
 	dataApi.Certificate = certificate
 	dataApi.CertificateKey = certificateKey
 	dataTf, diags := r.convertApiToModel(dataApi)
@@ -218,6 +339,8 @@ func (r *PullzoneHostnameResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	dataTf.Managed = managed
+	dataTf.ExpiryWarning = expiryWarning
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -248,6 +371,8 @@ func (r *PullzoneHostnameResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	dataTf.Managed = data.Managed
+	dataTf.ExpiryWarning = data.ExpiryWarning
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -266,10 +391,34 @@ func (r *PullzoneHostnameResource) Update(ctx context.Context, req resource.Upda
 	}
 	previousDataApi := r.convertModelToApi(ctx, previousData)
 
-	dataApiResult, err := r.client.UpdatePullzoneHostname(dataApi, previousDataApi)
-	if err != nil {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating hostname", err.Error()))
-		return
+	var dataApiResult api.PullzoneHostname
+	var err error
+
+	if data.Managed.IsNull() {
+		dataApiResult, err = r.client.UpdatePullzoneHostname(dataApi, previousDataApi)
+		if err != nil {
+			resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating hostname", err.Error()))
+			return
+		}
+	} else {
+		// a custom certificate must be removed before requesting a managed one
+		if len(previousDataApi.Certificate) > 0 {
+			withoutCertificate := dataApi
+			withoutCertificate.HasCertificate = false
+			withoutCertificate.ForceSSL = false
+
+			previousDataApi, err = r.client.UpdatePullzoneHostname(withoutCertificate, previousDataApi)
+			if err != nil {
+				resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating hostname", err.Error()))
+				return
+			}
+		}
+
+		dataApiResult, err = r.applyManagedCertificate(ctx, previousDataApi, dataApi.ForceSSL, data.Managed)
+		if err != nil {
+			resp.Diagnostics.Append(diag.NewErrorDiagnostic("Unable to obtain managed certificate", err.Error()))
+			return
+		}
 	}
 
 	if len(dataApi.Certificate) > 0 {
@@ -286,6 +435,8 @@ func (r *PullzoneHostnameResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	dataTf.Managed = data.Managed
+	dataTf.ExpiryWarning = data.ExpiryWarning
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -358,6 +509,119 @@ func (r *PullzoneHostnameResource) convertApiToModel(dataApi api.PullzoneHostnam
 	dataTf.ForceSSL = types.BoolValue(dataApi.ForceSSL)
 	dataTf.Certificate = types.StringValue(dataApi.Certificate)
 	dataTf.CertificateKey = types.StringValue(dataApi.CertificateKey)
+	dataTf.Managed = types.ObjectNull(pullzoneHostnameManagedCertificateType)
+	dataTf.ExpiryWarning = types.Int64Value(pullzoneHostnameDefaultExpiryWarningDays)
+	dataTf.NotAfter, dataTf.Issuer, dataTf.Sans = pullzoneHostnameCertificateDetails(dataApi.Certificate)
 
 	return dataTf, nil
 }
+
+// pullzoneHostnameCertificateDetails extracts the details of a custom certificate, or null values if there is none.
+func pullzoneHostnameCertificateDetails(certificatePem string) (types.String, types.String, types.List) {
+	if len(certificatePem) == 0 {
+		return types.StringNull(), types.StringNull(), types.ListNull(types.StringType)
+	}
+
+	chain, err := certificate.ParseChain(certificatePem)
+	if err != nil {
+		return types.StringNull(), types.StringNull(), types.ListNull(types.StringType)
+	}
+
+	var sans []attr.Value
+	for _, san := range certificate.Sans(chain[0]) {
+		sans = append(sans, types.StringValue(san))
+	}
+
+	return types.StringValue(chain[0].NotAfter.UTC().Format(time.RFC3339)), types.StringValue(chain[0].Issuer.String()), types.ListValueMust(types.StringType, sans)
+}
+
+// applyManagedCertificate requests a free certificate for the hostname, retrying while the DNS records propagate,
+// and waits until it is issued. ForceSSL is only applied once the certificate is in place.
+func (r *PullzoneHostnameResource) applyManagedCertificate(ctx context.Context, current api.PullzoneHostname, forceSSL bool, managed types.Object) (api.PullzoneHostname, error) {
+	attrs := managed.Attributes()
+	timeout := time.Duration(attrs["timeout"].(types.Int64).ValueInt64()) * time.Second
+	pollInterval := time.Duration(attrs["poll_interval"].(types.Int64).ValueInt64()) * time.Second
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	requested := current.HasCertificate
+	for !current.HasCertificate {
+		if !requested {
+			err := r.client.LoadFreeCertificate(current.Name)
+			if err == nil {
+				requested = true
+			} else if !pullzoneHostnameIsRetryableCertificateError(err) {
+				return current, r.diagnoseManagedCertificate(ctx, current, err)
+			} else {
+				tflog.Debug(ctx, fmt.Sprintf("managed certificate for %s not issued yet: %s", current.Name, err.Error()))
+			}
+		}
+
+		if requested {
+			hostname, err := r.client.GetPullzoneHostname(current.PullzoneId, current.Id)
+			if err != nil {
+				return current, err
+			}
+
+			if hostname.HasCertificate {
+				current = hostname
+				break
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return current, r.diagnoseManagedCertificate(ctx, current, fmt.Errorf("the certificate was not issued after %s", timeout))
+		case <-time.After(pollInterval):
+		}
+	}
+
+	data := current
+	data.ForceSSL = forceSSL
+
+	return r.client.UpdatePullzoneHostname(data, current)
+}
+
+func pullzoneHostnameIsRetryableCertificateError(err error) bool {
+	for _, re := range pullzoneHostnameRetryableCertificateErrors {
+		if re.MatchString(err.Error()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diagnoseManagedCertificate adds the most likely cause to a certificate error, based on the hostname DNS records.
+func (r *PullzoneHostnameResource) diagnoseManagedCertificate(ctx context.Context, hostname api.PullzoneHostname, err error) error {
+	ctx, cancel := context.WithTimeout(ctx, pullzoneHostnameDiagnoseTimeout)
+	defer cancel()
+
+	expected := "b-cdn.net"
+	pullzone, pzErr := r.client.GetPullzone(hostname.PullzoneId)
+	if pzErr == nil {
+		for _, h := range pullzone.Hostnames {
+			if h.IsSystemHostname {
+				expected = h.Name
+				break
+			}
+		}
+	}
+
+	cname, dnsErr := net.DefaultResolver.LookupCNAME(ctx, hostname.Name)
+	if dnsErr != nil {
+		return fmt.Errorf("%w\n\nThe hostname %s could not be resolved (%s). Make sure it has a CNAME record pointing to %s.", err, hostname.Name, dnsErr.Error(), expected)
+	}
+
+	cname = strings.TrimSuffix(cname, ".")
+	if cname == hostname.Name {
+		return fmt.Errorf("%w\n\nThe hostname %s does not have a CNAME record. If it is not a Bunny DNS PullZone record, add a CNAME pointing to %s.", err, hostname.Name, expected)
+	}
+
+	if !strings.HasSuffix(cname, ".b-cdn.net") {
+		return fmt.Errorf("%w\n\nThe CNAME for %s points to %s, it does not point to b-cdn.net. Change it to %s.", err, hostname.Name, cname, expected)
+	}
+
+	return err
+}
@@ -4,10 +4,18 @@
 package provider
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"math/big"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -71,6 +79,133 @@ func TestAccPullzoneHostnameResource(t *testing.T) {
 	})
 }
 
+const configPullzoneHostnameManagedTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_hostname" "test" {
+  pullzone = bunnynet_pullzone.test.id
+  name = "test-acceptance-%s.terraform.internal"
+  tls_enabled = true
+  force_ssl = true
+
+  managed_certificate {
+    timeout = 20
+    poll_interval = 5
+  }
+}
+`
+
+func TestAccPullzoneHostnameResourceManagedCertificate(t *testing.T) {
+	testKey := generateRandomString(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(configPullzoneHostnameManagedTest, testKey, testKey),
+				ExpectError: regexp.MustCompile(`(?s)Unable to obtain managed certificate.*could\s+not\s+be\s+resolved`),
+			},
+		},
+	})
+}
+
+const configPullzoneHostnameCertificateTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_hostname" "test" {
+  pullzone = bunnynet_pullzone.test.id
+  name = "%s"
+  tls_enabled = true
+  certificate = <<EOT
+%sEOT
+  certificate_key = <<EOT
+%sEOT
+}
+`
+
+func TestAccPullzoneHostnameResourceCertificateValidation(t *testing.T) {
+	testKey := generateRandomString(12)
+	certificate, certificateKey := testAccGenerateCertificate(t, "*.terraform.internal", time.Now().Add(90*24*time.Hour))
+	_, otherKey := testAccGenerateCertificate(t, "*.terraform.internal", time.Now().Add(90*24*time.Hour))
+	hostname := fmt.Sprintf("test-acceptance-%s.terraform.internal", testKey)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(configPullzoneHostnameCertificateTest, testKey, "test-acceptance.example.com", certificate, certificateKey),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`certificate is not valid for test-acceptance\.example\.com`),
+			},
+			{
+				Config:      fmt.Sprintf(configPullzoneHostnameCertificateTest, testKey, hostname, certificate, otherKey),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`private key does not match the certificate`),
+			},
+			{
+				Config: fmt.Sprintf(configPullzoneHostnameCertificateTest, testKey, hostname, certificate, certificateKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunnynet_pullzone_hostname.test", "certificate_issuer", "CN=*.terraform.internal"),
+					resource.TestCheckResourceAttr("bunnynet_pullzone_hostname.test", "certificate_sans.#", "1"),
+					resource.TestCheckResourceAttr("bunnynet_pullzone_hostname.test", "certificate_sans.0", "*.terraform.internal"),
+					resource.TestCheckResourceAttrSet("bunnynet_pullzone_hostname.test", "certificate_not_after"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGenerateCertificate(t *testing.T, hostname string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
 func testAccPullzoneHostnameImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
 	return func(s *terraform.State) (string, error) {
 		rs, ok := s.RootModule().Resources[resourceName]
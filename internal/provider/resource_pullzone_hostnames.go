@@ -0,0 +1,256 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+)
+
+var _ resource.Resource = &PullzoneHostnamesResource{}
+var _ resource.ResourceWithConfigure = &PullzoneHostnamesResource{}
+var _ resource.ResourceWithImportState = &PullzoneHostnamesResource{}
+
+func NewPullzoneHostnamesResource() resource.Resource {
+	return &PullzoneHostnamesResource{}
+}
+
+type PullzoneHostnamesResource struct {
+	client *api.Client
+}
+
+type PullzoneHostnamesResourceModel struct {
+	PullzoneId types.Int64 `tfsdk:"pullzone"`
+	Hostnames  types.Set   `tfsdk:"hostnames"`
+}
+
+func (r *PullzoneHostnamesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_hostnames"
+}
+
+func (r *PullzoneHostnamesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource makes terraform authoritative over the hostnames of a bunny.net pull zone. Hostnames that are not listed, such as hostnames added in the dashboard, are reported as drift and deleted on apply. System and managed hostnames (i.e. `*.b-cdn.net`) are ignored.\n\nThe hostnames themselves are still managed with `bunnynet_pullzone_hostname`. Destroying this resource does not delete any hostname.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "The ID of the linked pull zone.",
+			},
+			"hostnames": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "All the custom hostnames allowed in the pull zone.",
+			},
+		},
+	}
+}
+
+func (r *PullzoneHostnamesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PullzoneHostnamesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataTf PullzoneHostnamesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataTf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataTf, err := r.apply(ctx, dataTf)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to manage hostnames", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("managing hostnames for pullzone %d", dataTf.PullzoneId.ValueInt64()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneHostnamesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PullzoneHostnamesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pullzoneId := data.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	hostnames, err := r.client.GetPullzoneHostnames(pullzoneId)
+	pzMutex.Unlock(pullzoneId)
+
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error fetching hostnames", err.Error()))
+		return
+	}
+
+	dataTf, diags := r.convertApiToModel(pullzoneId, hostnames)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneHostnamesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PullzoneHostnamesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataTf, err := r.apply(ctx, data)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating hostnames", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneHostnamesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the hostnames are kept as-is
+}
+
+func (r *PullzoneHostnamesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pullzoneId, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding hostnames", "Use \"<pullzoneId>\" as ID on terraform import command"))
+		return
+	}
+
+	hostnames, err := r.client.GetPullzoneHostnames(pullzoneId)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding hostnames", err.Error()))
+		return
+	}
+
+	dataTf, diags := r.convertApiToModel(pullzoneId, hostnames)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+// apply deletes the hostnames that are not in the plan.
+func (r *PullzoneHostnamesResource) apply(ctx context.Context, dataTf PullzoneHostnamesResourceModel) (PullzoneHostnamesResourceModel, error) {
+	var names []string
+	if diags := dataTf.Hostnames.ElementsAs(ctx, &names, false); diags.HasError() {
+		return dataTf, errors.New(diags[0].Detail())
+	}
+
+	allowed := make(map[string]bool, len(names))
+	for _, name := range names {
+		allowed[name] = true
+	}
+
+	pullzoneId := dataTf.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	defer pzMutex.Unlock(pullzoneId)
+
+	hostnames, err := r.client.GetPullzoneHostnames(pullzoneId)
+	if err != nil {
+		return dataTf, err
+	}
+
+	existing := make(map[string]bool, len(hostnames))
+	for _, hostname := range hostnames {
+		if !pullzoneHostnameIsInternal(hostname) {
+			existing[hostname.Name] = true
+		}
+	}
+
+	for _, name := range names {
+		if !existing[name] {
+			return dataTf, fmt.Errorf("hostname %s not found", name)
+		}
+	}
+
+	remaining := make([]api.PullzoneHostname, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		if pullzoneHostnameIsInternal(hostname) || allowed[hostname.Name] {
+			remaining = append(remaining, hostname)
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("deleting unmanaged hostname %s from pullzone %d", hostname.Name, pullzoneId))
+		err = r.client.DeletePullzoneHostname(pullzoneId, hostname.Name)
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return dataTf, fmt.Errorf("could not delete hostname %s: %w", hostname.Name, err)
+		}
+	}
+
+	result, diags := r.convertApiToModel(pullzoneId, remaining)
+	if diags.HasError() {
+		return dataTf, errors.New(diags[0].Detail())
+	}
+
+	return result, nil
+}
+
+func (r *PullzoneHostnamesResource) convertApiToModel(pullzoneId int64, hostnames []api.PullzoneHostname) (PullzoneHostnamesResourceModel, diag.Diagnostics) {
+	values := make([]attr.Value, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		if pullzoneHostnameIsInternal(hostname) {
+			continue
+		}
+
+		values = append(values, types.StringValue(hostname.Name))
+	}
+
+	set, diags := types.SetValue(types.StringType, values)
+	if diags != nil {
+		return PullzoneHostnamesResourceModel{}, diags
+	}
+
+	return PullzoneHostnamesResourceModel{
+		PullzoneId: types.Int64Value(pullzoneId),
+		Hostnames:  set,
+	}, nil
+}
+
+func pullzoneHostnameIsInternal(hostname api.PullzoneHostname) bool {
+	return hostname.IsSystemHostname || hostname.IsManagedHostname
+}
@@ -0,0 +1,104 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const configPullzoneHostnamesTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_hostname" "test" {
+  pullzone    = bunnynet_pullzone.test.id
+  name        = "test-acceptance-%s.terraform.internal"
+  tls_enabled = false
+  force_ssl   = false
+}
+
+resource "bunnynet_pullzone_hostnames" "test" {
+  pullzone  = bunnynet_pullzone.test.id
+  hostnames = [bunnynet_pullzone_hostname.test.name]
+}
+`
+
+func TestAccPullzoneHostnamesResource(t *testing.T) {
+	resourceName := "bunnynet_pullzone_hostnames.test"
+	testKey := generateRandomString(12)
+	config := fmt.Sprintf(configPullzoneHostnamesTest, testKey, testKey)
+
+	var pullzoneId int64
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "hostnames.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "hostnames.*", fmt.Sprintf("test-acceptance-%s.terraform.internal", testKey)),
+					func(state *terraform.State) error {
+						var err error
+						pullzoneId, err = strconv.ParseInt(state.RootModule().Resources["bunnynet_pullzone.test"].Primary.ID, 10, 64)
+						return err
+					},
+				),
+			},
+			{
+				// hostname added outside terraform
+				PreConfig: func() {
+					_, err := newApiClient().CreatePullzoneHostname(api.PullzoneHostname{
+						PullzoneId: pullzoneId,
+						Name:       fmt.Sprintf("test-acceptance-%s-unmanaged.terraform.internal", testKey),
+					})
+
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "hostnames.#", "1"),
+					func(state *terraform.State) error {
+						hostnames, err := newApiClient().GetPullzoneHostnames(pullzoneId)
+						if err != nil {
+							return err
+						}
+
+						for _, hostname := range hostnames {
+							if hostname.Name == fmt.Sprintf("test-acceptance-%s-unmanaged.terraform.internal", testKey) {
+								return fmt.Errorf("unmanaged hostname %s was not deleted", hostname.Name)
+							}
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,238 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var _ resource.Resource = &PullzonePurgeResource{}
+var _ resource.ResourceWithConfigure = &PullzonePurgeResource{}
+
+var pullzonePurgeUrlRegex = regexp.MustCompile(`^https?://[^/]+/`)
+
+func NewPullzonePurgeResource() resource.Resource {
+	return &PullzonePurgeResource{}
+}
+
+type PullzonePurgeResource struct {
+	client *api.Client
+}
+
+type PullzonePurgeResourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	PullzoneId types.Int64  `tfsdk:"pullzone"`
+	CacheTag   types.String `tfsdk:"cache_tag"`
+	Paths      types.Set    `tfsdk:"paths"`
+	Urls       types.Set    `tfsdk:"urls"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+}
+
+func (r *PullzonePurgeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_purge"
+}
+
+func (r *PullzonePurgeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource purges the cache of a bunny.net pullzone when it is created, and every time one of the `triggers` changes. If neither `cache_tag`, `paths` nor `urls` are set, the whole pullzone cache is purged.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The time of the last purge.",
+			},
+			"pullzone": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Description: pullzonePurgeDescription.PullzoneId,
+			},
+			"cache_tag": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("paths"), path.MatchRoot("urls")),
+				},
+				Description: pullzonePurgeDescription.CacheTag,
+			},
+			"paths": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				MarkdownDescription: pullzonePurgeDescription.Paths,
+			},
+			"urls": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(pullzonePurgeUrlRegex, "must be an absolute http(s) URL")),
+				},
+				MarkdownDescription: pullzonePurgeDescription.Urls,
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				Description: "Arbitrary values that, when changed, will trigger a new purge.",
+			},
+		},
+	}
+}
+
+func (r *PullzonePurgeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PullzonePurgeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PullzonePurgeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var paths []string
+	resp.Diagnostics.Append(data.Paths.ElementsAs(ctx, &paths, true)...)
+
+	var urls []string
+	resp.Diagnostics.Append(data.Urls.ElementsAs(ctx, &urls, true)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := pullzonePurge(ctx, r.client, data.PullzoneId.ValueInt64(), data.CacheTag.ValueString(), paths, urls)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to purge pullzone cache", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("purged pullzone %d", data.PullzoneId.ValueInt64()))
+
+	data.Id = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PullzonePurgeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PullzonePurgeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.GetPullzone(data.PullzoneId.ValueInt64())
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Error fetching pullzone", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PullzonePurgeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all attributes require replacement, so there is nothing to update
+	var data PullzonePurgeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PullzonePurgeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// a purge cannot be undone, removing the resource from the state is enough
+}
+
+// pullzonePurge purges the whole pullzone or a cache tag, or the given paths and URLs if any.
+func pullzonePurge(ctx context.Context, client *api.Client, pullzoneId int64, cacheTag string, paths []string, urls []string) error {
+	if len(paths) == 0 && len(urls) == 0 {
+		return client.PurgePullzoneCache(ctx, pullzoneId, cacheTag)
+	}
+
+	if len(paths) > 0 {
+		pullzone, err := client.GetPullzone(pullzoneId)
+		if err != nil {
+			return err
+		}
+
+		urls = append(urls, pullzonePurgeUrls(pullzone, paths)...)
+	}
+
+	for _, purgeUrl := range urls {
+		err := client.PurgeUrl(ctx, purgeUrl)
+		if err != nil {
+			return fmt.Errorf("%s: %w", purgeUrl, err)
+		}
+	}
+
+	return nil
+}
+
+// pullzonePurgeUrls expands the paths into URLs for every hostname in the pullzone.
+func pullzonePurgeUrls(pullzone api.Pullzone, paths []string) []string {
+	var urls []string
+	for _, hostname := range pullzone.Hostnames {
+		for _, p := range paths {
+			urls = append(urls, fmt.Sprintf("https://%s/%s", hostname.Name, strings.TrimLeft(p, "/")))
+		}
+	}
+
+	return urls
+}
@@ -0,0 +1,67 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+const configPullzonePurgeTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url  = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_purge" "all" {
+  pullzone = bunnynet_pullzone.test.id
+
+  triggers = {
+    version = "%s"
+  }
+}
+
+resource "bunnynet_pullzone_purge" "paths" {
+  pullzone = bunnynet_pullzone.test.id
+  paths    = ["/index.html", "/assets/*"]
+}
+`
+
+func TestAccPullzonePurgeResource(t *testing.T) {
+	testKey := generateRandomString(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configPullzonePurgeTest, testKey, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bunnynet_pullzone_purge.all", "id"),
+					resource.TestCheckResourceAttr("bunnynet_pullzone_purge.paths", "paths.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(configPullzonePurgeTest, testKey, "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bunnynet_pullzone_purge.all", plancheck.ResourceActionReplace),
+						plancheck.ExpectResourceAction("bunnynet_pullzone_purge.paths", plancheck.ResourceActionNoop),
+					},
+				},
+			},
+		},
+	})
+}
@@ -11,12 +11,13 @@ import (
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/resourcestateupgrader"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -37,6 +38,7 @@ import (
 
 var _ resource.Resource = &PullzoneRatelimitRuleResource{}
 var _ resource.ResourceWithConfigure = &PullzoneRatelimitRuleResource{}
+var _ resource.ResourceWithConfigValidators = &PullzoneRatelimitRuleResource{}
 var _ resource.ResourceWithImportState = &PullzoneRatelimitRuleResource{}
 var _ resource.ResourceWithUpgradeState = &PullzoneRatelimitRuleResource{}
 
@@ -55,6 +57,7 @@ type PullzoneRatelimitRuleResourceModel struct {
 	Description     types.String `tfsdk:"description"`
 	Conditions      types.List   `tfsdk:"condition"`
 	Transformations types.Set    `tfsdk:"transformations"`
+	Expression      types.String `tfsdk:"expression"`
 	Limit           types.Object `tfsdk:"limit"`
 	Response        types.Object `tfsdk:"response"`
 }
@@ -139,6 +142,14 @@ func (r *PullzoneRatelimitRuleResource) Schema(ctx context.Context, req resource
 				},
 				Description: generateMarkdownMapOptions(pullzoneShieldRuleTransformationMap),
 			},
+			"expression": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					pullzoneShieldRuleExpressionValidator{},
+					stringvalidator.ConflictsWith(path.MatchRoot("transformations")),
+				},
+				MarkdownDescription: pullzoneShieldRuleExpressionDescription,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"condition": schema.ListNestedBlock{
@@ -146,9 +157,6 @@ func (r *PullzoneRatelimitRuleResource) Schema(ctx context.Context, req resource
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
 				},
-				Validators: []validator.List{
-					listvalidator.IsRequired(),
-				},
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"variable": schema.StringAttribute{
@@ -249,6 +257,12 @@ func (r *PullzoneRatelimitRuleResource) Schema(ctx context.Context, req resource
 	}
 }
 
+func (r *PullzoneRatelimitRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("condition"), path.MatchRoot("expression")),
+	}
+}
+
 func (r *PullzoneRatelimitRuleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
 	return map[int64]resource.StateUpgrader{
 		0: {StateUpgrader: resourcestateupgrader.PullzoneRatelimitRuleV0},
@@ -280,21 +294,27 @@ func (r *PullzoneRatelimitRuleResource) Create(ctx context.Context, req resource
 		return
 	}
 
-	dataApi := r.convertModelToApi(ctx, dataTf)
-	dataApi, err := r.client.CreatePullzoneRatelimitRule(ctx, dataApi)
+	dataApi, err := r.convertModelToApi(ctx, dataTf)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expression"), "Unable to create ratelimit rule", err.Error())
+		return
+	}
+
+	dataApi, err = r.client.CreatePullzoneRatelimitRule(ctx, dataApi)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create ratelimit rule", err.Error())
 		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created ratelimit rule for pullzone %d", dataTf.PullzoneId.ValueInt64()))
-	dataTf, diags := r.convertApiToModel(ctx, dataApi)
+	result, diags := r.convertApiToModel(ctx, dataApi)
 	if diags != nil {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+	resp.Diagnostics.Append(r.keepExpression(&result, dataTf, dataApi)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
 }
 
 func (r *PullzoneRatelimitRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -321,6 +341,7 @@ func (r *PullzoneRatelimitRuleResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
+	resp.Diagnostics.Append(r.keepExpression(&dataTf, data, dataApi)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -331,7 +352,12 @@ func (r *PullzoneRatelimitRuleResource) Update(ctx context.Context, req resource
 		return
 	}
 
-	dataApi := r.convertModelToApi(ctx, data)
+	dataApi, err := r.convertModelToApi(ctx, data)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(path.Root("expression"), "Error updating ratelimit rule", err.Error()))
+		return
+	}
+
 	dataApiResult, err := r.client.UpdatePullzoneRatelimitRule(ctx, dataApi)
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating ratelimit rule", err.Error()))
@@ -344,6 +370,7 @@ func (r *PullzoneRatelimitRuleResource) Update(ctx context.Context, req resource
 		return
 	}
 
+	resp.Diagnostics.Append(r.keepExpression(&dataTf, data, dataApiResult)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -363,7 +390,7 @@ func (r *PullzoneRatelimitRuleResource) Delete(ctx context.Context, req resource
 func (r *PullzoneRatelimitRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	pullzoneIdStr, ruleIdStr, ok := strings.Cut(req.ID, "|")
 	if !ok {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", "Use \"<pullzoneId>|<ruleID>\" as ID on terraform import command"))
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", "Use \"<pullzoneId>|<ruleID>\" or \"<pullzoneId>|<ruleName>\" as ID on terraform import command, prefixing the rule with \"id:\" or \"name:\" to disambiguate"))
 		return
 	}
 
@@ -373,9 +400,9 @@ func (r *PullzoneRatelimitRuleResource) ImportState(ctx context.Context, req res
 		return
 	}
 
-	ruleId, err := strconv.ParseInt(ruleIdStr, 10, 64)
+	ruleId, err := r.findRuleId(ctx, pullzoneId, ruleIdStr)
 	if err != nil {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", "Invalid ruleId: "+err.Error()))
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", err.Error()))
 		return
 	}
 
@@ -394,7 +421,44 @@ func (r *PullzoneRatelimitRuleResource) ImportState(ctx context.Context, req res
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
-func (r *PullzoneRatelimitRuleResource) convertModelToApi(ctx context.Context, dataTf PullzoneRatelimitRuleResourceModel) api.PullzoneRatelimitRule {
+// findRuleId resolves the rule referenced in an import ID, see pullzoneShieldRuleImportId.
+func (r *PullzoneRatelimitRuleResource) findRuleId(ctx context.Context, pullzoneId int64, ref string) (int64, error) {
+	if strings.HasPrefix(ref, "id:") {
+		return pullzoneShieldRuleImportId("ratelimit rule", ref, nil)
+	}
+
+	rules, err := r.client.GetPullzoneRatelimitRules(ctx, pullzoneId)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make(map[int64]string, len(rules))
+	for _, rule := range rules {
+		names[rule.Id] = rule.Name
+	}
+
+	return pullzoneShieldRuleImportId("ratelimit rule", ref, names)
+}
+
+// keepExpression preserves the expression from prior, as the API only returns the conditions.
+func (r *PullzoneRatelimitRuleResource) keepExpression(dataTf *PullzoneRatelimitRuleResourceModel, prior PullzoneRatelimitRuleResourceModel, dataApi api.PullzoneRatelimitRule) diag.Diagnostics {
+	if prior.Expression.IsNull() {
+		return nil
+	}
+
+	expression, err := pullzoneShieldRuleExpressionFromApi(prior.Expression, pullzoneRatelimitRuleConfigurationToWaf(dataApi.RuleConfiguration))
+	if err != nil {
+		return diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("expression"), "Unable to convert the rule into an expression", err.Error())}
+	}
+
+	dataTf.Expression = expression
+	dataTf.Conditions = prior.Conditions
+	dataTf.Transformations = prior.Transformations
+
+	return nil
+}
+
+func (r *PullzoneRatelimitRuleResource) convertModelToApi(ctx context.Context, dataTf PullzoneRatelimitRuleResourceModel) (api.PullzoneRatelimitRule, error) {
 	dataApi := api.PullzoneRatelimitRule{}
 	dataApi.Id = dataTf.Id.ValueInt64()
 	dataApi.PullzoneId = dataTf.PullzoneId.ValueInt64()
@@ -455,7 +519,17 @@ func (r *PullzoneRatelimitRuleResource) convertModelToApi(ctx context.Context, d
 		dataApi.RuleConfiguration.ActionType = 1 // RateLimit
 	}
 
-	return dataApi
+	// expression
+	if !dataTf.Expression.IsNull() {
+		configuration := pullzoneRatelimitRuleConfigurationToWaf(dataApi.RuleConfiguration)
+		if err := pullzoneShieldRuleApplyExpression(dataTf.Expression.ValueString(), &configuration); err != nil {
+			return dataApi, err
+		}
+
+		dataApi.RuleConfiguration = pullzoneRatelimitRuleConfigurationFromWaf(configuration, dataApi.RuleConfiguration)
+	}
+
+	return dataApi, nil
 }
 
 func (r *PullzoneRatelimitRuleResource) convertApiToModel(ctx context.Context, dataApi api.PullzoneRatelimitRule) (PullzoneRatelimitRuleResourceModel, diag.Diagnostics) {
@@ -466,6 +540,7 @@ func (r *PullzoneRatelimitRuleResource) convertApiToModel(ctx context.Context, d
 	dataTf.PullzoneId = types.Int64Value(dataApi.PullzoneId)
 	dataTf.Name = types.StringValue(dataApi.Name)
 	dataTf.Description = types.StringValue(dataApi.Description)
+	dataTf.Expression = types.StringNull()
 
 	conditions := make([]attr.Value, 0, len(dataApi.RuleConfiguration.ChainedRules)+1)
 
@@ -0,0 +1,385 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var _ resource.Resource = &PullzoneSecurityKeyResource{}
+var _ resource.ResourceWithConfigure = &PullzoneSecurityKeyResource{}
+var _ resource.ResourceWithModifyPlan = &PullzoneSecurityKeyResource{}
+var _ resource.ResourceWithImportState = &PullzoneSecurityKeyResource{}
+
+func NewPullzoneSecurityKeyResource() resource.Resource {
+	return &PullzoneSecurityKeyResource{}
+}
+
+type PullzoneSecurityKeyResource struct {
+	client *api.Client
+}
+
+type PullzoneSecurityKeyResourceModel struct {
+	PullzoneId           types.Int64  `tfsdk:"pullzone"`
+	RotateAfter          types.String `tfsdk:"rotate_after"`
+	GracePeriod          types.String `tfsdk:"grace_period"`
+	Keepers              types.Map    `tfsdk:"keepers"`
+	Key                  types.String `tfsdk:"key"`
+	PreviousKey          types.String `tfsdk:"previous_key"`
+	PreviousKeyExpiresAt types.String `tfsdk:"previous_key_expires_at"`
+	RotatedAt            types.String `tfsdk:"rotated_at"`
+}
+
+var pullzoneSecurityKeyDurationRegex = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`)
+
+func (r *PullzoneSecurityKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_security_key"
+}
+
+func (r *PullzoneSecurityKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages the token authentication key of a bunny.net pull zone, and rotates it on a schedule or when `keepers` change.\n\nbunny.net only accepts the current key. The previous key is kept in `previous_key` during the grace period, so your own services can verify or re-sign tokens during the rollover. Destroying this resource does not change the key.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "The ID of the linked pull zone.",
+			},
+			"rotate_after": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pullzoneSecurityKeyDurationRegex, "must be a duration, i.e. 720h"),
+				},
+				MarkdownDescription: "Rotate the key during the first plan after this duration since the last rotation, i.e. `720h`.",
+			},
+			"grace_period": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("24h"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pullzoneSecurityKeyDurationRegex, "must be a duration, i.e. 24h"),
+				},
+				MarkdownDescription: "How long the previous key is kept in `previous_key` after a rotation.",
+			},
+			"keepers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Arbitrary values that rotate the key when changed.",
+			},
+			"key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The current token authentication key.",
+			},
+			"previous_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "The previous token authentication key, until the grace period expires.",
+			},
+			"previous_key_expires_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "When the grace period of the previous key expires, in RFC3339 format.",
+			},
+			"rotated_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "When the key was last rotated, in RFC3339 format.",
+			},
+		},
+	}
+}
+
+func (r *PullzoneSecurityKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan PullzoneSecurityKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state PullzoneSecurityKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rotate := !plan.Keepers.Equal(state.Keepers)
+
+	if !rotate && !plan.RotateAfter.IsNull() && !plan.RotateAfter.IsUnknown() {
+		rotateAfter, err := time.ParseDuration(plan.RotateAfter.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("rotate_after"), "Invalid duration", err.Error())
+			return
+		}
+
+		rotatedAt, err := time.Parse(time.RFC3339, state.RotatedAt.ValueString())
+		if err != nil || !time.Now().Before(rotatedAt.Add(rotateAfter)) {
+			rotate = true
+		}
+	}
+
+	if rotate {
+		plan.Key = types.StringUnknown()
+		plan.PreviousKey = types.StringUnknown()
+		plan.PreviousKeyExpiresAt = types.StringUnknown()
+		plan.RotatedAt = types.StringUnknown()
+	} else if state.PreviousKey.IsNull() {
+		plan.PreviousKey = types.StringNull()
+		plan.PreviousKeyExpiresAt = state.PreviousKeyExpiresAt
+	} else if !plan.GracePeriod.Equal(state.GracePeriod) {
+		plan.PreviousKey = types.StringUnknown()
+		plan.PreviousKeyExpiresAt = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *PullzoneSecurityKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PullzoneSecurityKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataTf PullzoneSecurityKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataTf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pullzoneId := dataTf.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	pullzone, err := r.client.GetPullzone(pullzoneId)
+	pzMutex.Unlock(pullzoneId)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to rotate security key", err.Error())
+		return
+	}
+
+	// the key in use before terraform manages it also gets a grace period
+	dataTf, err = r.rotate(dataTf, pullzone.ZoneSecurityKey)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to rotate security key", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("rotated security key for pullzone %d", pullzoneId))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneSecurityKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PullzoneSecurityKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pullzoneId := data.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	pullzone, err := r.client.GetPullzone(pullzoneId)
+	pzMutex.Unlock(pullzoneId)
+
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error fetching pullzone", err.Error()))
+		return
+	}
+
+	data.Key = types.StringValue(pullzone.ZoneSecurityKey)
+
+	expiresAt, err := time.Parse(time.RFC3339, data.PreviousKeyExpiresAt.ValueString())
+	if err == nil && time.Now().After(expiresAt) {
+		data.PreviousKey = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PullzoneSecurityKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PullzoneSecurityKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state PullzoneSecurityKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+	if data.Key.IsUnknown() {
+		data, err = r.rotate(data, state.Key.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error rotating security key", err.Error()))
+			return
+		}
+	} else {
+		data.PreviousKey = state.PreviousKey
+		data.PreviousKeyExpiresAt = state.PreviousKeyExpiresAt
+
+		if !state.PreviousKey.IsNull() {
+			data.PreviousKeyExpiresAt, err = pullzoneSecurityKeyExpiresAt(data.RotatedAt.ValueString(), data.GracePeriod.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("grace_period"), "Invalid duration", err.Error())
+				return
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PullzoneSecurityKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the security key is kept as-is
+}
+
+func (r *PullzoneSecurityKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pullzoneId, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding pullzone", "Use \"<pullzoneId>\" as ID on terraform import command"))
+		return
+	}
+
+	pullzone, err := r.client.GetPullzone(pullzoneId)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error finding pullzone", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &PullzoneSecurityKeyResourceModel{
+		PullzoneId:           types.Int64Value(pullzoneId),
+		RotateAfter:          types.StringNull(),
+		GracePeriod:          types.StringNull(),
+		Keepers:              types.MapNull(types.StringType),
+		Key:                  types.StringValue(pullzone.ZoneSecurityKey),
+		PreviousKey:          types.StringNull(),
+		PreviousKeyExpiresAt: types.StringNull(),
+		RotatedAt:            types.StringValue(time.Now().UTC().Format(time.RFC3339)),
+	})...)
+}
+
+// rotate generates a new key, applies it to the pullzone and keeps previousKey for the grace period.
+func (r *PullzoneSecurityKeyResource) rotate(dataTf PullzoneSecurityKeyResourceModel, previousKey string) (PullzoneSecurityKeyResourceModel, error) {
+	key, err := generatePullzoneSecurityKey()
+	if err != nil {
+		return dataTf, err
+	}
+
+	rotatedAt := time.Now().UTC().Format(time.RFC3339)
+	expiresAt, err := pullzoneSecurityKeyExpiresAt(rotatedAt, dataTf.GracePeriod.ValueString())
+	if err != nil {
+		return dataTf, err
+	}
+
+	pullzoneId := dataTf.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	pullzone, err := r.client.UpdatePullzoneSecurityKey(pullzoneId, key)
+	pzMutex.Unlock(pullzoneId)
+
+	if err != nil {
+		return dataTf, err
+	}
+
+	if pullzone.ZoneSecurityKey != key {
+		return dataTf, errors.New("the security key was not updated")
+	}
+
+	dataTf.Key = types.StringValue(key)
+	dataTf.RotatedAt = types.StringValue(rotatedAt)
+	dataTf.PreviousKeyExpiresAt = expiresAt
+
+	if previousKey == "" {
+		dataTf.PreviousKey = types.StringNull()
+	} else {
+		dataTf.PreviousKey = types.StringValue(previousKey)
+	}
+
+	return dataTf, nil
+}
+
+func pullzoneSecurityKeyExpiresAt(rotatedAt string, gracePeriod string) (types.String, error) {
+	rotatedAtTime, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	gracePeriodDuration, err := time.ParseDuration(gracePeriod)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	return types.StringValue(rotatedAtTime.Add(gracePeriodDuration).UTC().Format(time.RFC3339)), nil
+}
+
+// generatePullzoneSecurityKey generates a random key, in the same UUID format as the keys generated by bunny.net.
+func generatePullzoneSecurityKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
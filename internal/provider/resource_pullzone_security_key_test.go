@@ -0,0 +1,114 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const configPullzoneSecurityKeyTest = `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s"
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_security_key" "test" {
+  pullzone     = bunnynet_pullzone.test.id
+  rotate_after = "720h"
+  grace_period = "1h"
+
+  keepers = {
+    version = "%s"
+  }
+}
+`
+
+func TestAccPullzoneSecurityKeyResource(t *testing.T) {
+	resourceName := "bunnynet_pullzone_security_key.test"
+	testKey := generateRandomString(12)
+	keyRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	var firstKey string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configPullzoneSecurityKeyTest, testKey, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "key", keyRegex),
+					resource.TestCheckResourceAttrSet(resourceName, "previous_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "rotated_at"),
+					resource.TestCheckResourceAttrWith(resourceName, "key", func(value string) error {
+						firstKey = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(configPullzoneSecurityKeyTest, testKey, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "key", keyRegex),
+					resource.TestCheckResourceAttrWith(resourceName, "key", func(value string) error {
+						if value == firstKey {
+							return fmt.Errorf("expected the key to be rotated")
+						}
+
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith(resourceName, "previous_key", func(value string) error {
+						if value != firstKey {
+							return fmt.Errorf("expected previous_key to be the key before the rotation")
+						}
+
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccPullzoneSecurityKeyImportStateIdFunc(resourceName),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					for _, attr := range []string{"grace_period", "previous_key", "previous_key_expires_at"} {
+						if value, ok := states[0].Attributes[attr]; ok && value != "" {
+							return fmt.Errorf("expected %s to be unset after import, got %s", attr, value)
+						}
+					}
+
+					return nil
+				},
+			},
+			{
+				Config:      `resource "bunnynet_pullzone_security_key" "test" { pullzone = 1, rotate_after = "30 days" }`,
+				ExpectError: regexp.MustCompile(`must be a duration`),
+			},
+		},
+	})
+}
+
+func testAccPullzoneSecurityKeyImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		return rs.Primary.Attributes["pullzone"], nil
+	}
+}
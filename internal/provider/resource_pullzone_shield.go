@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -32,12 +33,15 @@ import (
 	"golang.org/x/exp/maps"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 )
 
 var _ resource.Resource = &PullzoneShieldResource{}
 var _ resource.ResourceWithConfigure = &PullzoneShieldResource{}
 var _ resource.ResourceWithConfigValidators = &PullzoneShieldResource{}
 var _ resource.ResourceWithImportState = &PullzoneShieldResource{}
+var _ resource.ResourceWithModifyPlan = &PullzoneShieldResource{}
 
 func NewPullzoneShield() resource.Resource {
 	return &PullzoneShieldResource{}
@@ -56,11 +60,15 @@ type PullzoneShieldResourceModel struct {
 	BotDetection types.Object `tfsdk:"bot_detection"`
 	DDoS         types.Object `tfsdk:"ddos"`
 	WAF          types.Object `tfsdk:"waf"`
+	Preset       types.String `tfsdk:"preset"`
+	Staging      types.Object `tfsdk:"staging"`
 }
 
 var pullzoneShieldAccessListType = map[string]attr.Type{
-	"id":     types.Int64Type,
-	"action": types.StringType,
+	"id":       types.Int64Type,
+	"name":     types.StringType,
+	"action":   types.StringType,
+	"priority": types.Int64Type,
 }
 
 var pullzoneShieldDdosType = map[string]attr.Type{
@@ -96,6 +104,70 @@ var pullzoneShieldWafType = map[string]attr.Type{
 	"rules_logonly":                 types.SetType{ElemType: types.StringType},
 }
 
+var pullzoneShieldStagingType = map[string]attr.Type{
+	"period":             types.Int64Type,
+	"status":             types.StringType,
+	"promote_after":      types.StringType,
+	"bot_detection_mode": types.StringType,
+	"ddos_mode":          types.StringType,
+	"waf_mode":           types.StringType,
+}
+
+const (
+	pullzoneShieldStagingStatusLogOnly  = "LogOnly"
+	pullzoneShieldStagingStatusPromoted = "Promoted"
+)
+
+// pullzoneShieldPresets maps each preset to the attributes it sets, by block.
+// Attributes set in the configuration take precedence over the preset.
+var pullzoneShieldPresets = map[string]map[string]map[string]attr.Value{
+	"api": {
+		"bot_detection": {
+			"fingerprint_sensitivity": types.Int64Value(0),
+			"ip_sensitivity":          types.Int64Value(2),
+		},
+		"ddos": {
+			"level": types.StringValue("Medium"),
+			"mode":  types.StringValue("Block"),
+		},
+		"waf": {
+			"detection_sensitivity": types.Int64Value(2),
+			"execution_sensitivity": types.Int64Value(2),
+			"blocking_sensitivity":  types.Int64Value(2),
+		},
+	},
+	"ecommerce": {
+		"bot_detection": {
+			"fingerprint_sensitivity": types.Int64Value(2),
+			"ip_sensitivity":          types.Int64Value(2),
+		},
+		"ddos": {
+			"level": types.StringValue("High"),
+			"mode":  types.StringValue("Block"),
+		},
+		"waf": {
+			"detection_sensitivity": types.Int64Value(3),
+			"execution_sensitivity": types.Int64Value(2),
+			"blocking_sensitivity":  types.Int64Value(2),
+		},
+	},
+	"static-site": {
+		"bot_detection": {
+			"fingerprint_sensitivity": types.Int64Value(1),
+			"ip_sensitivity":          types.Int64Value(1),
+		},
+		"ddos": {
+			"level": types.StringValue("Medium"),
+			"mode":  types.StringValue("Block"),
+		},
+		"waf": {
+			"detection_sensitivity": types.Int64Value(1),
+			"execution_sensitivity": types.Int64Value(1),
+			"blocking_sensitivity":  types.Int64Value(1),
+		},
+	},
+}
+
 func (r *PullzoneShieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_pullzone_shield"
 }
@@ -163,6 +235,13 @@ func (r *PullzoneShieldResource) Schema(ctx context.Context, req resource.Schema
 				Default:     booldefault.StaticBool(false),
 				Description: "Replace our bunny.net branded block and challenge pages with a white-labelled experience.",
 			},
+			"preset": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(maps.Keys(pullzoneShieldPresets)...),
+				},
+				MarkdownDescription: "Applies tuned values to the `bot_detection`, `ddos` and `waf` blocks present in the configuration. Attributes set explicitly take precedence, and the plan shows the resulting values. Options: `api` (no browser fingerprinting, stricter IP checks), `ecommerce` (stricter bot detection, `High` DDoS level and higher WAF detection sensitivity), `static-site` (lenient defaults).",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"access_list": schema.SetNestedBlock{
@@ -175,8 +254,19 @@ func (r *PullzoneShieldResource) Schema(ctx context.Context, req resource.Schema
 					},
 					Attributes: map[string]schema.Attribute{
 						"id": schema.Int64Attribute{
-							Required:    true,
-							Description: "The ID of the Access List.",
+							Optional:    true,
+							Description: "The ID of the Access List. Conflicts with `name`.",
+						},
+						"name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The name of the curated Access List, as listed by the `bunnynet_shield_curated_access_lists` data source. Conflicts with `id`.",
+						},
+						"priority": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+							Description: "The order in which the Access List is evaluated, lower values first. If not set, the current priority is kept.",
 						},
 						"action": schema.StringAttribute{
 							Required: true,
@@ -253,11 +343,12 @@ func (r *PullzoneShieldResource) Schema(ctx context.Context, req resource.Schema
 			"ddos": schema.SingleNestedBlock{
 				Attributes: map[string]schema.Attribute{
 					"level": schema.StringAttribute{
-						Required: true,
+						Optional: true,
+						Computed: true,
 						Validators: []validator.String{
 							stringvalidator.OneOf(maps.Values(pullzoneShieldDdosLevelMap)...),
 						},
-						Description: generateMarkdownMapOptions(pullzoneShieldDdosLevelMap),
+						Description: "Required, unless `preset` is set. " + generateMarkdownMapOptions(pullzoneShieldDdosLevelMap),
 					},
 					"mode": schema.StringAttribute{
 						Optional: true,
@@ -372,7 +463,7 @@ func (r *PullzoneShieldResource) Schema(ctx context.Context, req resource.Schema
 						PlanModifiers: []planmodifier.Set{
 							setplanmodifier.UseStateForUnknown(),
 						},
-						Description: "List of disabled WAF rules.",
+						Description: "List of disabled WAF rules or rule groups. The available rules are listed by the `bunnynet_pullzone_shield_waf_rules` data source.",
 					},
 					"rules_logonly": schema.SetAttribute{
 						ElementType: types.StringType,
@@ -382,7 +473,7 @@ func (r *PullzoneShieldResource) Schema(ctx context.Context, req resource.Schema
 						PlanModifiers: []planmodifier.Set{
 							setplanmodifier.UseStateForUnknown(),
 						},
-						Description: "List of WAF rules that will not be blocked, but will be logged when triggered.",
+						Description: "List of WAF rules or rule groups that will not be blocked, but will be logged when triggered. The available rules are listed by the `bunnynet_pullzone_shield_waf_rules` data source.",
 					},
 					"detection_sensitivity": schema.Int64Attribute{
 						Optional: true,
@@ -432,6 +523,38 @@ func (r *PullzoneShieldResource) Schema(ctx context.Context, req resource.Schema
 				},
 				Description: "Configures WAF settings.",
 			},
+			"staging": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"period": schema.Int64Attribute{
+						Required: true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(60),
+						},
+						Description: "For how long, in seconds, changes to `bot_detection`, `ddos` and `waf` run in log-only mode before being promoted.",
+					},
+					"status": schema.StringAttribute{
+						Computed:    true,
+						Description: "`LogOnly` while changes are staged, `Promoted` once the configured modes are applied.",
+					},
+					"promote_after": schema.StringAttribute{
+						Computed:    true,
+						Description: "When the staged changes can be promoted, in RFC 3339 format. They are promoted by the first apply after that time.",
+					},
+					"bot_detection_mode": schema.StringAttribute{
+						Computed:    true,
+						Description: "The Bot Detection mode currently applied.",
+					},
+					"ddos_mode": schema.StringAttribute{
+						Computed:    true,
+						Description: "The DDoS mode currently applied.",
+					},
+					"waf_mode": schema.StringAttribute{
+						Computed:    true,
+						Description: "The WAF mode currently applied.",
+					},
+				},
+				Description: "Stages changes to `bot_detection`, `ddos` and `waf`: they are first applied in log-only mode, and promoted to the configured modes once `period` has elapsed.",
+			},
 		},
 	}
 }
@@ -439,11 +562,164 @@ func (r *PullzoneShieldResource) Schema(ctx context.Context, req resource.Schema
 func (r *PullzoneShieldResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		pullzoneshieldresourcevalidator.BotDetection(),
+		pullzoneshieldresourcevalidator.DdosLevel(),
 		pullzoneshieldresourcevalidator.RealtimeThreatIntelligence(),
 		pullzoneshieldresourcevalidator.Whitelabel(),
 	}
 }
 
+// ModifyPlan expands the preset, plans the staged rollout, and validates waf.rules_disabled and
+// waf.rules_logonly against the managed WAF rules catalogue.
+func (r *PullzoneShieldResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.modifyPlanPreset(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.modifyPlanStaging(ctx, req, resp)
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	var waf types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("waf"), &waf)...)
+	if resp.Diagnostics.HasError() || waf.IsNull() || waf.IsUnknown() {
+		return
+	}
+
+	configured := map[string][]string{}
+	for _, attribute := range []string{"rules_disabled", "rules_logonly"} {
+		value, ok := waf.Attributes()[attribute].(types.Set)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		for _, v := range value.Elements() {
+			if v.IsUnknown() {
+				continue
+			}
+
+			configured[attribute] = append(configured[attribute], v.(types.String).ValueString())
+		}
+	}
+
+	if len(configured) == 0 {
+		return
+	}
+
+	groups, err := r.client.GetPullzoneShieldWafManagedRules(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to validate WAF rules", fmt.Sprintf("The managed WAF rules could not be fetched, so waf.rules_disabled and waf.rules_logonly were not validated: %s", err.Error()))
+		return
+	}
+
+	// rules can be referenced individually or by their group
+	known := []string{}
+	for _, group := range groups {
+		known = append(known, group.Id)
+		for _, rule := range group.Rules {
+			known = append(known, rule.Id)
+		}
+	}
+
+	knownSet := utils.SliceToSet(known)
+
+	for _, attribute := range []string{"rules_disabled", "rules_logonly"} {
+		for _, id := range configured[attribute] {
+			if _, ok := knownSet[id]; ok {
+				continue
+			}
+
+			detail := fmt.Sprintf("WAF rule \"%s\" does not exist. The available rules are listed by the bunnynet_pullzone_shield_waf_rules data source.", id)
+			if suggestions := pullzoneshieldresourcevalidator.WafRuleSuggestions(id, known); len(suggestions) > 0 {
+				detail = fmt.Sprintf("WAF rule \"%s\" does not exist. Did you mean \"%s\"?", id, strings.Join(suggestions, "\", \""))
+			}
+
+			resp.Diagnostics.AddAttributeError(path.Root("waf").AtName(attribute), "Invalid WAF rule", detail)
+		}
+	}
+}
+
+// modifyPlanPreset sets the preset values for the attributes not set in the configuration.
+func (r *PullzoneShieldResource) modifyPlanPreset(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var preset types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("preset"), &preset)...)
+	if resp.Diagnostics.HasError() || preset.IsNull() || preset.IsUnknown() {
+		return
+	}
+
+	for block, values := range pullzoneShieldPresets[preset.ValueString()] {
+		var blockValue types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(block), &blockValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// presets do not add blocks missing from the configuration
+		if blockValue.IsNull() || blockValue.IsUnknown() {
+			continue
+		}
+
+		for attribute, value := range values {
+			if !blockValue.Attributes()[attribute].IsNull() {
+				continue
+			}
+
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(block).AtName(attribute), value)...)
+		}
+	}
+}
+
+// modifyPlanStaging plans the staging status and the modes applied to the shield.
+// Changes to bot_detection, ddos or waf restart the staging in log-only mode, and
+// the configured modes are promoted once the staging period has elapsed.
+func (r *PullzoneShieldResource) modifyPlanStaging(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var plan PullzoneShieldResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Staging.IsNull() || plan.Staging.IsUnknown() {
+		return
+	}
+
+	var state PullzoneShieldResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	status := pullzoneShieldStagingStatusPromoted
+	promoteAfter := types.StringNull()
+
+	if !state.Staging.IsNull() {
+		promoteAfter = state.Staging.Attributes()["promote_after"].(types.String)
+	}
+
+	changed := req.State.Raw.IsNull() || !plan.BotDetection.Equal(state.BotDetection) || !plan.DDoS.Equal(state.DDoS) || !plan.WAF.Equal(state.WAF)
+	if changed {
+		status = pullzoneShieldStagingStatusLogOnly
+		promoteAfter = types.StringUnknown()
+	} else if !state.Staging.IsNull() && state.Staging.Attributes()["status"].(types.String).ValueString() == pullzoneShieldStagingStatusLogOnly {
+		promoteAfterTime, err := time.Parse(time.RFC3339, promoteAfter.ValueString())
+		if err == nil && time.Now().Before(promoteAfterTime) {
+			status = pullzoneShieldStagingStatusLogOnly
+		}
+	}
+
+	modes := pullzoneShieldStagingModes(plan, status == pullzoneShieldStagingStatusLogOnly)
+	staging, diags := pullzoneShieldStagingValue(plan.Staging.Attributes()["period"], status, promoteAfter, modes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("staging"), staging)...)
+}
+
 func (r *PullzoneShieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -469,6 +745,11 @@ func (r *PullzoneShieldResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	resp.Diagnostics.Append(pullzoneShieldStagingStart(&dataTf, time.Now())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	dataApi := r.convertModelToApi(ctx, dataTf)
 	dataApi, err := r.client.CreatePullzoneShield(ctx, dataApi)
 	if err != nil {
@@ -477,12 +758,19 @@ func (r *PullzoneShieldResource) Create(ctx context.Context, req resource.Create
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created shield for pullzone %d", dataTf.PullzoneId.ValueInt64()))
+	previous := dataTf
 	dataTf, diags := r.convertApiToModel(dataApi)
 	if diags != nil {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
+	diags = pullzoneShieldKeepConfig(&dataTf, previous)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -510,6 +798,12 @@ func (r *PullzoneShieldResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	diags = pullzoneShieldKeepConfig(&dataTf, data)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -520,6 +814,11 @@ func (r *PullzoneShieldResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	resp.Diagnostics.Append(pullzoneShieldStagingStart(&data, time.Now())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	dataApi := r.convertModelToApi(ctx, data)
 	dataApiResult, err := r.client.UpdatePullzoneShield(ctx, dataApi)
 	if err != nil {
@@ -533,6 +832,12 @@ func (r *PullzoneShieldResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	diags = pullzoneShieldKeepConfig(&dataTf, data)
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -574,6 +879,12 @@ func (r *PullzoneShieldResource) ImportState(ctx context.Context, req resource.I
 		return
 	}
 
+	diags = pullzoneShieldKeepConfig(&dataTf, PullzoneShieldResourceModel{})
+	if diags != nil {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -607,6 +918,10 @@ func (r *PullzoneShieldResource) convertModelToApi(ctx context.Context, dataTf P
 				item.Action = mapValueToKey(pullzoneAccessListActionMap, v.(types.String).ValueString())
 			}
 
+			if v, ok := listAttrs["priority"]; ok {
+				item.Priority = v.(types.Int64).ValueInt64()
+			}
+
 			accessLists = append(accessLists, item)
 		}
 
@@ -713,6 +1028,23 @@ func (r *PullzoneShieldResource) convertModelToApi(ctx context.Context, dataTf P
 		}
 	}
 
+	// staging: the planned modes replace the configured ones
+	if !dataTf.Staging.IsNull() {
+		attrs := dataTf.Staging.Attributes()
+
+		if v := attrs["bot_detection_mode"].(types.String); !v.IsNull() {
+			dataApi.BotDetectionMode = mapValueToKey(pullzoneShieldBotDetectionModeMap, v.ValueString())
+		}
+
+		if v := attrs["ddos_mode"].(types.String); !v.IsNull() {
+			dataApi.DDoSMode = mapValueToKey(pullzoneShieldDdosModeMap, v.ValueString())
+		}
+
+		if v := attrs["waf_mode"].(types.String); !v.IsNull() {
+			dataApi.WafMode = mapValueToKey(pullzoneShieldWafModeMap, v.ValueString())
+		}
+	}
+
 	return dataApi
 }
 
@@ -728,8 +1060,10 @@ func (r *PullzoneShieldResource) convertApiToModel(dataApi api.PullzoneShield) (
 		setValues := make([]attr.Value, 0, len(dataApi.AccessLists))
 		for _, list := range dataApi.AccessLists {
 			obj, diags := types.ObjectValue(pullzoneShieldAccessListType, map[string]attr.Value{
-				"id":     types.Int64Value(list.Id),
-				"action": types.StringValue(mapKeyToValue(pullzoneAccessListActionMap, list.Action)),
+				"id":       types.Int64Value(list.Id),
+				"name":     types.StringValue(list.Name),
+				"action":   types.StringValue(mapKeyToValue(pullzoneAccessListActionMap, list.Action)),
+				"priority": types.Int64Value(list.Priority),
 			})
 
 			if diags != nil {
@@ -872,3 +1206,175 @@ func (r *PullzoneShieldResource) convertApiToModel(dataApi api.PullzoneShield) (
 
 	return dataTf, nil
 }
+
+// pullzoneShieldKeepConfig keeps the configuration-only values of previous, the plan or the prior state,
+// in dataTf, the model converted from the API.
+func pullzoneShieldKeepConfig(dataTf *PullzoneShieldResourceModel, previous PullzoneShieldResourceModel) diag.Diagnostics {
+	accessList, diags := pullzoneShieldAccessListKeepConfig(previous.AccessList, dataTf.AccessList)
+	if diags != nil {
+		return diags
+	}
+
+	dataTf.AccessList = accessList
+	dataTf.Preset = previous.Preset
+	dataTf.Staging = types.ObjectNull(pullzoneShieldStagingType)
+
+	if previous.Staging.IsNull() || previous.Staging.IsUnknown() {
+		return nil
+	}
+
+	// the API returns the applied modes, while the configured modes are kept from previous
+	stagingAttrs := previous.Staging.Attributes()
+	modes := pullzoneShieldStagingModes(*dataTf, false)
+	dataTf.Staging, diags = pullzoneShieldStagingValue(stagingAttrs["period"], stagingAttrs["status"].(types.String).ValueString(), stagingAttrs["promote_after"].(types.String), modes)
+	if diags != nil {
+		return diags
+	}
+
+	// the configured modes are only kept while a staged rollout is pending, and the shield still runs the staged modes
+	if stagingAttrs["status"].(types.String).ValueString() != pullzoneShieldStagingStatusLogOnly {
+		return nil
+	}
+
+	dataTf.BotDetection, diags = pullzoneShieldKeepMode(dataTf.BotDetection, previous.BotDetection, stagingAttrs["bot_detection_mode"], pullzoneShieldBotDetectionType)
+	if diags != nil {
+		return diags
+	}
+
+	dataTf.DDoS, diags = pullzoneShieldKeepMode(dataTf.DDoS, previous.DDoS, stagingAttrs["ddos_mode"], pullzoneShieldDdosType)
+	if diags != nil {
+		return diags
+	}
+
+	dataTf.WAF, diags = pullzoneShieldKeepMode(dataTf.WAF, previous.WAF, stagingAttrs["waf_mode"], pullzoneShieldWafType)
+	return diags
+}
+
+// pullzoneShieldKeepMode replaces the mode of current with the mode of previous, if current runs the staged mode.
+// Otherwise the mode was changed outside terraform, and is reported as drift.
+func pullzoneShieldKeepMode(current types.Object, previous types.Object, stagedMode attr.Value, attrTypes map[string]attr.Type) (types.Object, diag.Diagnostics) {
+	if current.IsNull() || previous.IsNull() || previous.IsUnknown() {
+		return current, nil
+	}
+
+	attrs := current.Attributes()
+	if stagedMode == nil || stagedMode.IsUnknown() || !attrs["mode"].Equal(stagedMode) {
+		return current, nil
+	}
+
+	attrs["mode"] = previous.Attributes()["mode"]
+
+	return types.ObjectValue(attrTypes, attrs)
+}
+
+// pullzoneShieldStagingModes returns the modes of the bot_detection, ddos and waf blocks,
+// or the log mode for all of them when logOnly is set.
+func pullzoneShieldStagingModes(dataTf PullzoneShieldResourceModel, logOnly bool) map[string]attr.Value {
+	modes := map[string]attr.Value{}
+	for block, blockValue := range map[string]types.Object{"bot_detection": dataTf.BotDetection, "ddos": dataTf.DDoS, "waf": dataTf.WAF} {
+		switch {
+		case blockValue.IsNull():
+			modes[block] = types.StringNull()
+		case blockValue.IsUnknown():
+			modes[block] = types.StringUnknown()
+		case logOnly:
+			modes[block] = types.StringValue("Log")
+		default:
+			modes[block] = blockValue.Attributes()["mode"]
+		}
+	}
+
+	return modes
+}
+
+func pullzoneShieldStagingValue(period attr.Value, status string, promoteAfter types.String, modes map[string]attr.Value) (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(pullzoneShieldStagingType, map[string]attr.Value{
+		"period":             period,
+		"status":             types.StringValue(status),
+		"promote_after":      promoteAfter,
+		"bot_detection_mode": modes["bot_detection"],
+		"ddos_mode":          modes["ddos"],
+		"waf_mode":           modes["waf"],
+	})
+}
+
+// pullzoneShieldStagingStart sets staging.promote_after when a new staging period starts.
+func pullzoneShieldStagingStart(dataTf *PullzoneShieldResourceModel, now time.Time) diag.Diagnostics {
+	if dataTf.Staging.IsNull() || dataTf.Staging.IsUnknown() {
+		return nil
+	}
+
+	attrs := dataTf.Staging.Attributes()
+	if !attrs["promote_after"].IsUnknown() {
+		return nil
+	}
+
+	period := time.Duration(attrs["period"].(types.Int64).ValueInt64()) * time.Second
+	attrs["promote_after"] = types.StringValue(now.UTC().Add(period).Format(time.RFC3339))
+
+	staging, diags := types.ObjectValue(pullzoneShieldStagingType, attrs)
+	if diags != nil {
+		return diags
+	}
+
+	dataTf.Staging = staging
+	return nil
+}
+
+// pullzoneShieldAccessListKeepConfig keeps the shape of the configured access_list blocks:
+// lists referenced by name keep the name instead of the ID, and the priority is only
+// tracked when it was configured. Lists not present in previous are referenced by ID.
+func pullzoneShieldAccessListKeepConfig(previous types.Set, current types.Set) (types.Set, diag.Diagnostics) {
+	previousById := map[int64]map[string]attr.Value{}
+	previousByName := map[string]map[string]attr.Value{}
+
+	if !previous.IsNull() && !previous.IsUnknown() {
+		for _, element := range previous.Elements() {
+			attrs := element.(types.Object).Attributes()
+			if v := attrs["id"].(types.Int64); !v.IsNull() && !v.IsUnknown() {
+				previousById[v.ValueInt64()] = attrs
+			}
+
+			if v := attrs["name"].(types.String); !v.IsNull() && !v.IsUnknown() {
+				previousByName[v.ValueString()] = attrs
+			}
+		}
+	}
+
+	setValues := make([]attr.Value, 0, len(current.Elements()))
+	for _, element := range current.Elements() {
+		attrs := element.(types.Object).Attributes()
+
+		previousAttrs, ok := previousById[attrs["id"].(types.Int64).ValueInt64()]
+		if !ok {
+			previousAttrs, ok = previousByName[attrs["name"].(types.String).ValueString()]
+		}
+
+		values := map[string]attr.Value{
+			"id":       attrs["id"],
+			"name":     types.StringNull(),
+			"action":   attrs["action"],
+			"priority": types.Int64Null(),
+		}
+
+		if ok {
+			if previousAttrs["id"].IsNull() {
+				values["id"] = types.Int64Null()
+				values["name"] = attrs["name"]
+			}
+
+			if !previousAttrs["priority"].IsNull() {
+				values["priority"] = attrs["priority"]
+			}
+		}
+
+		obj, diags := types.ObjectValue(pullzoneShieldAccessListType, values)
+		if diags != nil {
+			return types.Set{}, diags
+		}
+
+		setValues = append(setValues, obj)
+	}
+
+	return types.SetValue(types.ObjectType{AttrTypes: pullzoneShieldAccessListType}, setValues)
+}
@@ -0,0 +1,434 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/customtype"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/shieldpolicy"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+)
+
+var _ resource.Resource = &PullzoneShieldPolicyResource{}
+var _ resource.ResourceWithConfigure = &PullzoneShieldPolicyResource{}
+var _ resource.ResourceWithImportState = &PullzoneShieldPolicyResource{}
+
+func NewPullzoneShieldPolicyResource() resource.Resource {
+	return &PullzoneShieldPolicyResource{}
+}
+
+type PullzoneShieldPolicyResource struct {
+	client *api.Client
+}
+
+type PullzoneShieldPolicyResourceModel struct {
+	PullzoneId types.Int64                          `tfsdk:"pullzone"`
+	Document   customtype.ShieldPolicyDocumentValue `tfsdk:"document"`
+	Prune      types.Bool                           `tfsdk:"prune"`
+}
+
+func (r *PullzoneShieldPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pullzone_shield_policy"
+}
+
+func (r *PullzoneShieldPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource applies a shield policy document, as exported by the `bunnynet_pullzone_shield_policy` data source, to a bunny.net pullzone. The document is authoritative over the shield settings and curated access lists. Custom WAF rules, ratelimit rules and access lists are matched by name, and only the ones that differ are created or updated. Items that are not in the document are left untouched, unless `prune` is enabled.\n\nThe shield must already exist. Destroying this resource does not change the shield.",
+
+		Attributes: map[string]schema.Attribute{
+			"pullzone": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Description: "The ID of the linked pullzone.",
+			},
+			"document": schema.StringAttribute{
+				Required:   true,
+				CustomType: customtype.ShieldPolicyDocumentType{},
+				Validators: []validator.String{
+					pullzoneShieldPolicyDocumentValidator{},
+				},
+				MarkdownDescription: fmt.Sprintf("The shield policy, as a JSON document. Supports up to version `%d` of the format. Engine config items that are not in the document keep their current value.", shieldpolicy.Version),
+			},
+			"prune": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Delete the custom WAF rules, ratelimit rules and access lists that are not in the document, and report them as drift. Leave it disabled when some of them are managed by other resources, such as `bunnynet_pullzone_waf_rule`.",
+			},
+		},
+	}
+}
+
+func (r *PullzoneShieldPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PullzoneShieldPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var dataTf PullzoneShieldPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &dataTf)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apply(ctx, dataTf)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to apply shield policy", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("applied shield policy for pullzone %d", dataTf.PullzoneId.ValueInt64()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneShieldPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PullzoneShieldPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pullzoneId := data.PullzoneId.ValueInt64()
+	current, _, err := pullzoneShieldPolicyFetch(ctx, r.client, pullzoneId)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Error fetching shield policy", err.Error())
+		return
+	}
+
+	// keep the document as written, unless the shield drifted from it
+	if desired, err := shieldpolicy.Parse(data.Document.ValueString()); err == nil {
+		if !data.Prune.ValueBool() {
+			current = current.ListedIn(desired)
+		}
+
+		if len(shieldpolicy.Diff(current, desired)) == 0 {
+			return
+		}
+	}
+
+	dataTf, diags := r.convertDocumentToModel(pullzoneId, current, data.Prune)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+func (r *PullzoneShieldPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PullzoneShieldPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.apply(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to apply shield policy", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PullzoneShieldPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the shield is kept as-is
+}
+
+func (r *PullzoneShieldPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	pullzoneId, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Error finding shield policy", "Use \"<pullzoneId>\" as ID on terraform import command")
+		return
+	}
+
+	current, _, err := pullzoneShieldPolicyFetch(ctx, r.client, pullzoneId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error finding shield policy", err.Error())
+		return
+	}
+
+	dataTf, diags := r.convertDocumentToModel(pullzoneId, current, types.BoolValue(false))
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+}
+
+// apply makes the changes needed for the shield to match the document, one item at a time.
+func (r *PullzoneShieldPolicyResource) apply(ctx context.Context, dataTf PullzoneShieldPolicyResourceModel) error {
+	desired, err := shieldpolicy.Parse(dataTf.Document.ValueString())
+	if err != nil {
+		return err
+	}
+
+	pullzoneId := dataTf.PullzoneId.ValueInt64()
+	pzMutex.Lock(pullzoneId)
+	defer pzMutex.Unlock(pullzoneId)
+
+	// the same lock as bunnynet_pullzone_waf_rule, so both resources can be used on the same pullzone
+	pzWafRuleMutex.Lock(pullzoneId)
+	defer pzWafRuleMutex.Unlock(pullzoneId)
+
+	current, shield, err := pullzoneShieldPolicyFetch(ctx, r.client, pullzoneId)
+	if err != nil {
+		return err
+	}
+
+	if !dataTf.Prune.ValueBool() {
+		current = current.ListedIn(desired)
+	}
+
+	changes := shieldpolicy.Diff(current, desired)
+	shieldUpdated := false
+
+	for _, change := range changes {
+		tflog.Info(ctx, fmt.Sprintf("shield policy for pullzone %d: %s", pullzoneId, change))
+
+		switch change.Kind {
+		case shieldpolicy.KindShield, shieldpolicy.KindCuratedAccessList:
+			// the settings and curated access lists are saved together
+			if shieldUpdated {
+				continue
+			}
+
+			shieldData, err := desired.ApplyShield(shield)
+			if err != nil {
+				return err
+			}
+
+			_, err = r.client.UpdatePullzoneShield(ctx, shieldData)
+			if err != nil {
+				return fmt.Errorf("%s: %w", change, err)
+			}
+
+			shieldUpdated = true
+
+		case shieldpolicy.KindAccessList:
+			err = r.applyAccessList(ctx, pullzoneId, change, desired)
+
+		case shieldpolicy.KindWafRule:
+			err = r.applyWafRule(ctx, pullzoneId, change, desired)
+
+		case shieldpolicy.KindRatelimitRule:
+			err = r.applyRatelimitRule(ctx, pullzoneId, change, desired)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", change, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PullzoneShieldPolicyResource) applyAccessList(ctx context.Context, pullzoneId int64, change shieldpolicy.Change, desired shieldpolicy.Document) error {
+	if change.Action == shieldpolicy.ActionDelete {
+		err := r.client.DeletePullzoneAccessList(ctx, pullzoneId, change.Id)
+		if errors.Is(err, api.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	list := desired.AccessLists[change.Index]
+	dataApi := api.PullzoneAccessList{
+		Id:         change.Id,
+		PullzoneId: pullzoneId,
+		Name:       list.Name,
+		IsEnabled:  list.IsEnabled,
+		Type:       list.Type,
+		Action:     list.Action,
+		Entries:    list.Entries,
+	}
+
+	var err error
+	if change.Action == shieldpolicy.ActionCreate {
+		_, err = r.client.CreatePullzoneAccessList(ctx, dataApi)
+	} else {
+		_, err = r.client.UpdatePullzoneAccessList(ctx, dataApi)
+	}
+
+	return err
+}
+
+func (r *PullzoneShieldPolicyResource) applyWafRule(ctx context.Context, pullzoneId int64, change shieldpolicy.Change, desired shieldpolicy.Document) error {
+	if change.Action == shieldpolicy.ActionDelete {
+		err := r.client.DeletePullzoneWafRule(ctx, change.Id)
+		if errors.Is(err, api.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	rule := desired.WafRules[change.Index]
+	dataApi := api.PullzoneWafRule{
+		Id:                change.Id,
+		PullzoneId:        pullzoneId,
+		Name:              rule.Name,
+		Description:       rule.Description,
+		RuleConfiguration: rule.Configuration,
+	}
+
+	var err error
+	if change.Action == shieldpolicy.ActionCreate {
+		_, err = r.client.CreatePullzoneWafRule(ctx, dataApi)
+	} else {
+		_, err = r.client.UpdatePullzoneWafRule(ctx, dataApi)
+	}
+
+	return err
+}
+
+func (r *PullzoneShieldPolicyResource) applyRatelimitRule(ctx context.Context, pullzoneId int64, change shieldpolicy.Change, desired shieldpolicy.Document) error {
+	if change.Action == shieldpolicy.ActionDelete {
+		err := r.client.DeletePullzoneRatelimitRule(ctx, change.Id)
+		if errors.Is(err, api.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	rule := desired.RatelimitRules[change.Index]
+	dataApi := api.PullzoneRatelimitRule{
+		Id:                change.Id,
+		PullzoneId:        pullzoneId,
+		Name:              rule.Name,
+		Description:       rule.Description,
+		RuleConfiguration: rule.Configuration,
+	}
+
+	var err error
+	if change.Action == shieldpolicy.ActionCreate {
+		_, err = r.client.CreatePullzoneRatelimitRule(ctx, dataApi)
+	} else {
+		_, err = r.client.UpdatePullzoneRatelimitRule(ctx, dataApi)
+	}
+
+	return err
+}
+
+func (r *PullzoneShieldPolicyResource) convertDocumentToModel(pullzoneId int64, doc shieldpolicy.Document, prune types.Bool) (PullzoneShieldPolicyResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	content, err := doc.Marshal()
+	if err != nil {
+		diags.AddError("Unable to encode shield policy", err.Error())
+		return PullzoneShieldPolicyResourceModel{}, diags
+	}
+
+	return PullzoneShieldPolicyResourceModel{
+		PullzoneId: types.Int64Value(pullzoneId),
+		Document:   customtype.ShieldPolicyDocumentValue{StringValue: types.StringValue(content)},
+		Prune:      prune,
+	}, nil
+}
+
+// pullzoneShieldPolicyFetch returns the shield policy of a pullzone, and the shield configuration it was built from.
+func pullzoneShieldPolicyFetch(ctx context.Context, client *api.Client, pullzoneId int64) (shieldpolicy.Document, api.PullzoneShield, error) {
+	shieldZoneId, err := client.GetPullzoneShieldIdByPullzone(pullzoneId)
+	if err != nil {
+		return shieldpolicy.Document{}, api.PullzoneShield{}, err
+	}
+
+	shield, err := client.GetPullzoneShield(ctx, shieldZoneId)
+	if err != nil {
+		return shieldpolicy.Document{}, api.PullzoneShield{}, err
+	}
+
+	wafRules, err := client.GetPullzoneWafRules(ctx, pullzoneId)
+	if err != nil {
+		return shieldpolicy.Document{}, api.PullzoneShield{}, err
+	}
+
+	ratelimitRules, err := client.GetPullzoneRatelimitRules(ctx, pullzoneId)
+	if err != nil {
+		return shieldpolicy.Document{}, api.PullzoneShield{}, err
+	}
+
+	lists, err := client.GetPullzoneAccessLists(ctx, pullzoneId, api.PullzoneAccessListQueryCustom)
+	if err != nil {
+		return shieldpolicy.Document{}, api.PullzoneShield{}, err
+	}
+
+	accessLists := make([]api.PullzoneAccessList, 0, len(lists))
+	for _, list := range lists {
+		accessList, err := client.GetPullzoneAccessList(ctx, pullzoneId, list.Id)
+		if err != nil {
+			return shieldpolicy.Document{}, api.PullzoneShield{}, err
+		}
+
+		accessLists = append(accessLists, accessList)
+	}
+
+	return shieldpolicy.New(shield, wafRules, ratelimitRules, accessLists), shield, nil
+}
+
+// pullzoneShieldPolicyDocumentValidator parses the document during plan.
+type pullzoneShieldPolicyDocumentValidator struct{}
+
+func (v pullzoneShieldPolicyDocumentValidator) Description(ctx context.Context) string {
+	return "The document must be a valid shield policy."
+}
+
+func (v pullzoneShieldPolicyDocumentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pullzoneShieldPolicyDocumentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	doc, err := shieldpolicy.Parse(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid shield policy", err.Error())
+		return
+	}
+
+	if _, err := doc.ApplyShield(api.PullzoneShield{}); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid shield policy", err.Error())
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPullzoneShieldKeepMode(t *testing.T) {
+	type dataType struct {
+		Expected   string
+		Current    string
+		Previous   string
+		StagedMode attr.Value
+	}
+
+	dataProvider := []dataType{
+		// staged rollout pending: the configured mode is kept
+		{"Block", "Log", "Block", types.StringValue("Log")},
+		// changed outside terraform: the applied mode is reported
+		{"Block", "Block", "Log", types.StringValue("Log")},
+		{"Log", "Log", "Block", types.StringValue("Block")},
+		{"Log", "Log", "Block", types.StringNull()},
+	}
+
+	attrTypes := map[string]attr.Type{"mode": types.StringType}
+
+	for _, data := range dataProvider {
+		current := types.ObjectValueMust(attrTypes, map[string]attr.Value{"mode": types.StringValue(data.Current)})
+		previous := types.ObjectValueMust(attrTypes, map[string]attr.Value{"mode": types.StringValue(data.Previous)})
+
+		result, diags := pullzoneShieldKeepMode(current, previous, data.StagedMode, attrTypes)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		if mode := result.Attributes()["mode"].(types.String).ValueString(); mode != data.Expected {
+			t.Errorf("Expected %s with previous %s and staged %s to return %s, got %s", data.Current, data.Previous, data.StagedMode, data.Expected, mode)
+		}
+	}
+}
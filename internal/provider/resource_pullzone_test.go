@@ -5,9 +5,11 @@ package provider
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 const configPullzoneTest = `
@@ -84,3 +86,104 @@ func TestAccPullzoneWithPermacacheResource(t *testing.T) {
 		},
 	})
 }
+
+const configPullzoneCopyFromTemplateTest = `
+resource "bunnynet_pullzone" "template" {
+  name = "test-acceptance-%s"
+  cache_errors = true
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net"
+  }
+
+  routing {
+    tier = "Standard"
+  }
+}
+
+resource "bunnynet_pullzone_edgerule" "template" {
+  enabled     = true
+  pullzone    = bunnynet_pullzone.template.id
+  description = "Force SSL"
+  action      = "ForceSSL"
+  match_type  = "MatchAny"
+
+  triggers = [
+    {
+      type       = "Url"
+      match_type = "MatchAny"
+      patterns   = ["*"]
+      parameter1 = null
+      parameter2 = null
+    }
+  ]
+}
+
+resource "bunnynet_pullzone_optimizer_class" "template" {
+  pullzone = bunnynet_pullzone.template.id
+  name     = "template"
+  quality  = 75
+}
+`
+
+const configPullzoneCopyFromTest = configPullzoneCopyFromTemplateTest + `
+resource "bunnynet_pullzone" "test" {
+  name = "test-acceptance-%s-copy"
+  copy_from = bunnynet_pullzone.template.id
+
+  origin {
+    type = "OriginUrl"
+    url = "https://bunny.net/copy"
+  }
+}
+`
+
+func TestAccPullzoneCopyFromResource(t *testing.T) {
+	testKey := generateRandomString(12)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configPullzoneCopyFromTemplateTest, testKey),
+			},
+			{
+				Config: fmt.Sprintf(configPullzoneCopyFromTest, testKey, testKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bunnynet_pullzone.test", "name", fmt.Sprintf("test-acceptance-%s-copy", testKey)),
+					resource.TestCheckResourceAttr("bunnynet_pullzone.test", "cache_errors", "true"),
+					resource.TestCheckResourceAttr("bunnynet_pullzone.test", "origin.url", "https://bunny.net/copy"),
+					resource.TestCheckResourceAttrPair("bunnynet_pullzone.test", "routing.tier", "bunnynet_pullzone.template", "routing.tier"),
+					resource.TestCheckResourceAttrPair("bunnynet_pullzone.test", "copy_from", "bunnynet_pullzone.template", "id"),
+					func(state *terraform.State) error {
+						pullzoneId, err := strconv.ParseInt(state.RootModule().Resources["bunnynet_pullzone.test"].Primary.ID, 10, 64)
+						if err != nil {
+							return err
+						}
+
+						pullzone, err := newApiClient().GetPullzone(pullzoneId)
+						if err != nil {
+							return err
+						}
+
+						if len(pullzone.Edgerules) != 1 || pullzone.Edgerules[0].Description != "Force SSL" {
+							return fmt.Errorf("expected the edgerule to be copied from the template pullzone, got %d edgerules", len(pullzone.Edgerules))
+						}
+
+						if len(pullzone.OptimizerClasses) != 1 || pullzone.OptimizerClasses[0].Name != "template" {
+							return fmt.Errorf("expected the optimizer class to be copied from the template pullzone, got %d optimizer classes", len(pullzone.OptimizerClasses))
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				Config:   fmt.Sprintf(configPullzoneCopyFromTest, testKey, testKey),
+				PlanOnly: true,
+			},
+		},
+	})
+}
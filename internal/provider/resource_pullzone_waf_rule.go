@@ -11,12 +11,13 @@ import (
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/resourcestateupgrader"
 	"github.com/bunnyway/terraform-provider-bunnynet/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -37,6 +38,7 @@ import (
 
 var _ resource.Resource = &PullzoneWafRuleResource{}
 var _ resource.ResourceWithConfigure = &PullzoneWafRuleResource{}
+var _ resource.ResourceWithConfigValidators = &PullzoneWafRuleResource{}
 var _ resource.ResourceWithImportState = &PullzoneWafRuleResource{}
 var _ resource.ResourceWithUpgradeState = &PullzoneWafRuleResource{}
 
@@ -55,6 +57,7 @@ type PullzoneWafRuleResourceModel struct {
 	Description     types.String `tfsdk:"description"`
 	Conditions      types.List   `tfsdk:"condition"`
 	Transformations types.Set    `tfsdk:"transformations"`
+	Expression      types.String `tfsdk:"expression"`
 	Response        types.Object `tfsdk:"response"`
 }
 
@@ -133,6 +136,14 @@ func (r *PullzoneWafRuleResource) Schema(ctx context.Context, req resource.Schem
 				},
 				Description: generateMarkdownMapOptions(pullzoneShieldRuleTransformationMap),
 			},
+			"expression": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					pullzoneShieldRuleExpressionValidator{},
+					stringvalidator.ConflictsWith(path.MatchRoot("transformations")),
+				},
+				MarkdownDescription: pullzoneShieldRuleExpressionDescription,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"condition": schema.ListNestedBlock{
@@ -140,9 +151,6 @@ func (r *PullzoneWafRuleResource) Schema(ctx context.Context, req resource.Schem
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
 				},
-				Validators: []validator.List{
-					listvalidator.IsRequired(),
-				},
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"variable": schema.StringAttribute{
@@ -213,6 +221,12 @@ func (r *PullzoneWafRuleResource) Schema(ctx context.Context, req resource.Schem
 	}
 }
 
+func (r *PullzoneWafRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("condition"), path.MatchRoot("expression")),
+	}
+}
+
 func (r *PullzoneWafRuleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
 	return map[int64]resource.StateUpgrader{
 		0: {StateUpgrader: resourcestateupgrader.PullzoneWafRuleV0},
@@ -244,11 +258,16 @@ func (r *PullzoneWafRuleResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	dataApi, err := r.convertModelToApi(ctx, dataTf)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expression"), "Unable to create waf rule", err.Error())
+		return
+	}
+
 	pullzoneId := dataTf.PullzoneId.ValueInt64()
 	pzWafRuleMutex.Lock(pullzoneId)
 
-	dataApi := r.convertModelToApi(ctx, dataTf)
-	dataApi, err := r.client.CreatePullzoneWafRule(ctx, dataApi)
+	dataApi, err = r.client.CreatePullzoneWafRule(ctx, dataApi)
 
 	pzWafRuleMutex.Unlock(pullzoneId)
 
@@ -258,13 +277,14 @@ func (r *PullzoneWafRuleResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("created waf rule for pullzone %d", pullzoneId))
-	dataTf, diags := r.convertApiToModel(ctx, dataApi)
+	result, diags := r.convertApiToModel(ctx, dataApi)
 	if diags != nil {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
+	resp.Diagnostics.Append(r.keepExpression(&result, dataTf, dataApi)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
 }
 
 func (r *PullzoneWafRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -291,6 +311,7 @@ func (r *PullzoneWafRuleResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	resp.Diagnostics.Append(r.keepExpression(&dataTf, data, dataApi)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -301,7 +322,12 @@ func (r *PullzoneWafRuleResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	dataApi := r.convertModelToApi(ctx, data)
+	dataApi, err := r.convertModelToApi(ctx, data)
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(path.Root("expression"), "Error updating waf rule", err.Error()))
+		return
+	}
+
 	dataApiResult, err := r.client.UpdatePullzoneWafRule(ctx, dataApi)
 	if err != nil {
 		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Error updating waf rule", err.Error()))
@@ -314,6 +340,7 @@ func (r *PullzoneWafRuleResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	resp.Diagnostics.Append(r.keepExpression(&dataTf, data, dataApiResult)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
@@ -339,7 +366,7 @@ func (r *PullzoneWafRuleResource) Delete(ctx context.Context, req resource.Delet
 func (r *PullzoneWafRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	pullzoneIdStr, ruleIdStr, ok := strings.Cut(req.ID, "|")
 	if !ok {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", "Use \"<pullzoneId>|<ruleID>\" as ID on terraform import command"))
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", "Use \"<pullzoneId>|<ruleID>\" or \"<pullzoneId>|<ruleName>\" as ID on terraform import command, prefixing the rule with \"id:\" or \"name:\" to disambiguate"))
 		return
 	}
 
@@ -349,9 +376,9 @@ func (r *PullzoneWafRuleResource) ImportState(ctx context.Context, req resource.
 		return
 	}
 
-	ruleId, err := strconv.ParseInt(ruleIdStr, 10, 64)
+	ruleId, err := r.findRuleId(ctx, pullzoneId, ruleIdStr)
 	if err != nil {
-		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", "Invalid ruleId: "+err.Error()))
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid resource identifier", err.Error()))
 		return
 	}
 
@@ -370,7 +397,44 @@ func (r *PullzoneWafRuleResource) ImportState(ctx context.Context, req resource.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &dataTf)...)
 }
 
-func (r *PullzoneWafRuleResource) convertModelToApi(ctx context.Context, dataTf PullzoneWafRuleResourceModel) api.PullzoneWafRule {
+// findRuleId resolves the rule referenced in an import ID, see pullzoneShieldRuleImportId.
+func (r *PullzoneWafRuleResource) findRuleId(ctx context.Context, pullzoneId int64, ref string) (int64, error) {
+	if strings.HasPrefix(ref, "id:") {
+		return pullzoneShieldRuleImportId("waf rule", ref, nil)
+	}
+
+	rules, err := r.client.GetPullzoneWafRules(ctx, pullzoneId)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make(map[int64]string, len(rules))
+	for _, rule := range rules {
+		names[rule.Id] = rule.Name
+	}
+
+	return pullzoneShieldRuleImportId("waf rule", ref, names)
+}
+
+// keepExpression preserves the expression from prior, as the API only returns the conditions.
+func (r *PullzoneWafRuleResource) keepExpression(dataTf *PullzoneWafRuleResourceModel, prior PullzoneWafRuleResourceModel, dataApi api.PullzoneWafRule) diag.Diagnostics {
+	if prior.Expression.IsNull() {
+		return nil
+	}
+
+	expression, err := pullzoneShieldRuleExpressionFromApi(prior.Expression, dataApi.RuleConfiguration)
+	if err != nil {
+		return diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("expression"), "Unable to convert the rule into an expression", err.Error())}
+	}
+
+	dataTf.Expression = expression
+	dataTf.Conditions = prior.Conditions
+	dataTf.Transformations = prior.Transformations
+
+	return nil
+}
+
+func (r *PullzoneWafRuleResource) convertModelToApi(ctx context.Context, dataTf PullzoneWafRuleResourceModel) (api.PullzoneWafRule, error) {
 	dataApi := api.PullzoneWafRule{}
 	dataApi.Id = dataTf.Id.ValueInt64()
 	dataApi.PullzoneId = dataTf.PullzoneId.ValueInt64()
@@ -423,7 +487,14 @@ func (r *PullzoneWafRuleResource) convertModelToApi(ctx context.Context, dataTf
 		dataApi.RuleConfiguration.ActionType = mapValueToKey(pullzoneShieldWafRuleResponseActionMap, attrs["action"].(types.String).ValueString())
 	}
 
-	return dataApi
+	// expression
+	if !dataTf.Expression.IsNull() {
+		if err := pullzoneShieldRuleApplyExpression(dataTf.Expression.ValueString(), &dataApi.RuleConfiguration); err != nil {
+			return dataApi, err
+		}
+	}
+
+	return dataApi, nil
 }
 
 func (r *PullzoneWafRuleResource) convertApiToModel(ctx context.Context, dataApi api.PullzoneWafRule) (PullzoneWafRuleResourceModel, diag.Diagnostics) {
@@ -434,6 +505,7 @@ func (r *PullzoneWafRuleResource) convertApiToModel(ctx context.Context, dataApi
 	dataTf.PullzoneId = types.Int64Value(dataApi.PullzoneId)
 	dataTf.Name = types.StringValue(dataApi.Name)
 	dataTf.Description = types.StringValue(dataApi.Description)
+	dataTf.Expression = types.StringNull()
 
 	conditions := make([]attr.Value, 0, len(dataApi.RuleConfiguration.ChainedRules)+1)
 
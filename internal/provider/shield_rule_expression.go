@@ -0,0 +1,174 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/shieldrule"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+var pullzoneShieldRuleExpressionNames = shieldrule.Names{
+	Variables:       pullzoneShieldRuleConditionVariableMap,
+	Operators:       pullzoneShieldRuleConditionOperationMap,
+	Transformations: pullzoneShieldRuleTransformationMap,
+}
+
+const pullzoneShieldRuleExpressionDescription = "The conditions and transformations of the rule as an expression, i.e. `lowercase(request_headers[\"User-Agent\"]) contains \"curl\" and lowercase(request_uri) contains \"/api/\"`. Conditions are joined by `and`; operators can be written as their names (`contains`, `begins_with`) or as `==`, `>=`, `>`, `<=`, `<`, `matches` and `in`. Transformations wrap the variable and apply to the whole rule, so every condition must declare the same ones. Conflicts with `condition` and `transformations`."
+
+// pullzoneShieldRuleExpressionValidator parses the expression during plan, reporting the position of any error.
+type pullzoneShieldRuleExpressionValidator struct{}
+
+func (v pullzoneShieldRuleExpressionValidator) Description(ctx context.Context) string {
+	return "The expression must be valid."
+}
+
+func (v pullzoneShieldRuleExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pullzoneShieldRuleExpressionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := shieldrule.ParseExpression(req.ConfigValue.ValueString(), pullzoneShieldRuleExpressionNames)
+	if err != nil {
+		detail := err.Error()
+
+		var expressionErr *shieldrule.ExpressionError
+		if errors.As(err, &expressionErr) {
+			detail = fmt.Sprintf("%s\n\n%s", detail, pullzoneShieldRuleExpressionPointer(req.ConfigValue.ValueString(), expressionErr))
+		}
+
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid expression", detail)
+	}
+}
+
+// pullzoneShieldRuleExpressionPointer renders the line with the error, and a caret under the column.
+func pullzoneShieldRuleExpressionPointer(expression string, err *shieldrule.ExpressionError) string {
+	lines := strings.Split(expression, "\n")
+	if err.Line < 1 || err.Line > len(lines) {
+		return ""
+	}
+
+	line := []rune(lines[err.Line-1])
+	column := min(max(err.Column-1, 0), len(line))
+
+	return string(line) + "\n" + strings.Repeat(" ", column) + "^"
+}
+
+// pullzoneShieldRuleApplyExpression replaces the conditions and transformations of the rule with the ones in the expression.
+func pullzoneShieldRuleApplyExpression(expression string, rule *api.PullzoneWafRuleConfiguration) error {
+	parsed, err := shieldrule.ParseExpression(expression, pullzoneShieldRuleExpressionNames)
+	if err != nil {
+		return err
+	}
+
+	rule.VariableTypes = parsed.VariableTypes
+	rule.OperatorType = parsed.OperatorType
+	rule.Value = parsed.Value
+	rule.ChainedRules = parsed.ChainedRules
+	rule.TransformationTypes = parsed.TransformationTypes
+	if rule.TransformationTypes == nil {
+		rule.TransformationTypes = []int64{}
+	}
+
+	return nil
+}
+
+// pullzoneShieldRuleExpressionFromApi keeps the current expression if it is equivalent to the rule returned by the API,
+// so formatting differences do not show up as changes. Otherwise, the rule is rendered as a new expression.
+func pullzoneShieldRuleExpressionFromApi(expression types.String, rule api.PullzoneWafRuleConfiguration) (types.String, error) {
+	current := api.PullzoneWafRuleConfiguration{}
+	if err := pullzoneShieldRuleApplyExpression(expression.ValueString(), &current); err == nil && pullzoneShieldRuleConditionsEqual(current, rule) {
+		return expression, nil
+	}
+
+	rendered, err := shieldrule.FormatExpression(rule, pullzoneShieldRuleExpressionNames)
+	if err != nil {
+		return expression, err
+	}
+
+	return types.StringValue(rendered), nil
+}
+
+// pullzoneShieldRuleConditionsEqual compares conditions and transformations, ignoring their order.
+func pullzoneShieldRuleConditionsEqual(a api.PullzoneWafRuleConfiguration, b api.PullzoneWafRuleConfiguration) bool {
+	conditionKeys := func(rule api.PullzoneWafRuleConfiguration) []string {
+		conditions := append([]api.PullzoneWafRuleChainedRule{{
+			VariableTypes: rule.VariableTypes,
+			OperatorType:  rule.OperatorType,
+			Value:         rule.Value,
+		}}, rule.ChainedRules...)
+
+		keys := make([]string, 0, len(conditions))
+		for _, condition := range conditions {
+			variables := maps.Keys(condition.VariableTypes)
+			slices.Sort(variables)
+
+			key := fmt.Sprintf("%d|%q", condition.OperatorType, condition.Value)
+			for _, variable := range variables {
+				key += fmt.Sprintf("|%s=%q", variable, condition.VariableTypes[variable])
+			}
+
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+		return keys
+	}
+
+	transformationsA := slices.Clone(a.TransformationTypes)
+	transformationsB := slices.Clone(b.TransformationTypes)
+	slices.Sort(transformationsA)
+	slices.Sort(transformationsB)
+
+	return slices.Equal(conditionKeys(a), conditionKeys(b)) && slices.Equal(transformationsA, transformationsB)
+}
+
+func pullzoneRatelimitRuleConfigurationToWaf(configuration api.PullzoneRatelimitRuleConfiguration) api.PullzoneWafRuleConfiguration {
+	result := api.PullzoneWafRuleConfiguration{
+		VariableTypes:       configuration.VariableTypes,
+		OperatorType:        configuration.OperatorType,
+		Value:               configuration.Value,
+		TransformationTypes: configuration.TransformationTypes,
+	}
+
+	for _, chained := range configuration.ChainedRules {
+		result.ChainedRules = append(result.ChainedRules, api.PullzoneWafRuleChainedRule{
+			VariableTypes: chained.VariableTypes,
+			OperatorType:  chained.OperatorType,
+			Value:         chained.Value,
+		})
+	}
+
+	return result
+}
+
+// pullzoneRatelimitRuleConfigurationFromWaf replaces the conditions and transformations in configuration.
+func pullzoneRatelimitRuleConfigurationFromWaf(conditions api.PullzoneWafRuleConfiguration, configuration api.PullzoneRatelimitRuleConfiguration) api.PullzoneRatelimitRuleConfiguration {
+	configuration.VariableTypes = conditions.VariableTypes
+	configuration.OperatorType = conditions.OperatorType
+	configuration.Value = conditions.Value
+	configuration.TransformationTypes = conditions.TransformationTypes
+	configuration.ChainedRules = nil
+
+	for _, chained := range conditions.ChainedRules {
+		configuration.ChainedRules = append(configuration.ChainedRules, api.PullzoneRatelimitRuleChainedRule{
+			VariableTypes: chained.VariableTypes,
+			OperatorType:  chained.OperatorType,
+			Value:         chained.Value,
+		})
+	}
+
+	return configuration
+}
@@ -164,3 +164,45 @@ func resourceAttrToDatasourceAttr(ra rschema.Attribute) dschema.Attribute {
 		panic(fmt.Sprintf("unexpected attribute type: %T", ra))
 	}
 }
+
+// pullzoneShieldRuleImportId resolves the rule referenced in an import ID, given the names of the rules of a pullzone indexed by ID.
+// The reference is "id:<ruleID>", "name:<ruleName>", or either of them without prefix, in which case a rule with
+// a matching ID takes precedence over the rules with a matching name. Names are not unique, so ambiguous names are rejected.
+func pullzoneShieldRuleImportId(kind string, ref string, rules map[int64]string) (int64, error) {
+	if idStr, ok := strings.CutPrefix(ref, "id:"); ok {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s ID \"%s\"", kind, idStr)
+		}
+
+		return id, nil
+	}
+
+	name, byName := strings.CutPrefix(ref, "name:")
+	if !byName {
+		if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+			if _, ok := rules[id]; ok {
+				return id, nil
+			}
+		}
+	}
+
+	var ruleId int64
+	for id, ruleName := range rules {
+		if ruleName != name {
+			continue
+		}
+
+		if ruleId > 0 {
+			return 0, fmt.Errorf("multiple %ss are named \"%s\", use \"<pullzoneId>|id:<ruleID>\" instead", kind, name)
+		}
+
+		ruleId = id
+	}
+
+	if ruleId == 0 {
+		return 0, fmt.Errorf("%s \"%s\" not found", kind, ref)
+	}
+
+	return ruleId, nil
+}
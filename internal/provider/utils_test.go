@@ -65,6 +65,50 @@ func TestConvertSecondsToTimestamp(t *testing.T) {
 	}
 }
 
+func TestPullzoneShieldRuleImportId(t *testing.T) {
+	type dataType struct {
+		Expected int64
+		Error    string
+		Ref      string
+	}
+
+	rules := map[int64]string{10: "block-curl", 11: "2024", 12: "duplicated", 13: "duplicated"}
+
+	dataProvider := []dataType{
+		{10, "", "10"},
+		{10, "", "id:10"},
+		{99, "", "id:99"},
+		{10, "", "block-curl"},
+		{10, "", "name:block-curl"},
+		{11, "", "2024"},
+		{11, "", "name:2024"},
+		{0, "waf rule \"name:10\" not found", "name:10"},
+		{0, "invalid waf rule ID \"abc\"", "id:abc"},
+		{0, "multiple waf rules are named \"duplicated\", use \"<pullzoneId>|id:<ruleID>\" instead", "duplicated"},
+		{0, "waf rule \"missing\" not found", "missing"},
+	}
+
+	for _, data := range dataProvider {
+		result, err := pullzoneShieldRuleImportId("waf rule", data.Ref, rules)
+
+		if data.Error != "" {
+			if err == nil || err.Error() != data.Error {
+				t.Errorf("Expected %s to fail with %s, got %v", data.Ref, data.Error, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Expected no error for %s, got %s", data.Ref, err)
+		}
+
+		if result != data.Expected {
+			t.Errorf("Expected %s to return %d, got %d", data.Ref, data.Expected, result)
+		}
+	}
+}
+
 const randomStringOptions = "abcdefghijklmnopqrstuvwxyz0123456789"
 
 func generateRandomString(n int) string {
@@ -0,0 +1,99 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzonehostnameresourcevalidator
+
+import (
+	"context"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/certificate"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+// DefaultCertificateExpiryWarningDays is the default of certificate_expiry_warning_days, which is not part of the config when unset.
+const DefaultCertificateExpiryWarningDays = 30
+
+func CertificateDetails() resource.ConfigValidator {
+	return certificateDetailsValidator{now: time.Now}
+}
+
+type certificateDetailsValidator struct {
+	now func() time.Time
+}
+
+func (v certificateDetailsValidator) Description(ctx context.Context) string {
+	return "The custom certificate must be valid for the hostname, match the private key and include its chain in order."
+}
+
+func (v certificateDetailsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v certificateDetailsValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	certificateAttr := path.Root("certificate")
+	var certificatePem types.String
+	request.Config.GetAttribute(ctx, certificateAttr, &certificatePem)
+
+	if certificatePem.IsUnknown() || certificatePem.IsNull() || len(certificatePem.ValueString()) == 0 {
+		return
+	}
+
+	chain, err := certificate.ParseChain(certificatePem.ValueString())
+	if err != nil {
+		response.Diagnostics.AddAttributeError(certificateAttr, "Invalid certificate", err.Error())
+		return
+	}
+
+	leaf := chain[0]
+	now := v.now()
+
+	err = certificate.VerifyChainOrder(chain)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(certificateAttr, "Invalid certificate chain", err.Error())
+	}
+
+	if certificate.IsMissingIntermediates(chain) {
+		response.Diagnostics.AddAttributeWarning(certificateAttr, "Certificate chain might be incomplete", fmt.Sprintf("The certificate is issued by \"%s\", but no intermediate certificates were provided. Append the intermediate certificates after the leaf certificate.", leaf.Issuer.String()))
+	}
+
+	err = certificate.VerifyValidity(leaf, now)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(certificateAttr, "Certificate is not valid", err.Error())
+	} else {
+		var warningDays types.Int64
+		request.Config.GetAttribute(ctx, path.Root("certificate_expiry_warning_days"), &warningDays)
+
+		days := int64(DefaultCertificateExpiryWarningDays)
+		if !warningDays.IsNull() {
+			days = warningDays.ValueInt64()
+		}
+
+		if !warningDays.IsUnknown() && certificate.ExpiresWithin(leaf, now, time.Duration(days)*24*time.Hour) {
+			response.Diagnostics.AddAttributeWarning(certificateAttr, "Certificate is about to expire", fmt.Sprintf("The certificate expires on %s.", leaf.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	var name types.String
+	request.Config.GetAttribute(ctx, path.Root("name"), &name)
+
+	if !name.IsUnknown() && !name.IsNull() {
+		err = certificate.VerifyHostname(leaf, name.ValueString())
+		if err != nil {
+			response.Diagnostics.AddAttributeError(certificateAttr, "Invalid certificate", err.Error())
+		}
+	}
+
+	certificateKeyAttr := path.Root("certificate_key")
+	var certificateKey types.String
+	request.Config.GetAttribute(ctx, certificateKeyAttr, &certificateKey)
+
+	if !certificateKey.IsUnknown() && !certificateKey.IsNull() {
+		err = certificate.VerifyKeyPair(certificatePem.ValueString(), certificateKey.ValueString())
+		if err != nil {
+			response.Diagnostics.AddAttributeError(certificateKeyAttr, "Invalid certificate key", err.Error())
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzonehostnameresourcevalidator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"math/big"
+	"time"
+
+	"testing"
+)
+
+func generateTestCertificate(t *testing.T, dnsNames []string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestCertificateDetails(t *testing.T) {
+	type testCase struct {
+		ExpectedError   bool
+		ExpectedWarning bool
+		Now             time.Time
+		PlanValues      map[string]tftypes.Value
+	}
+
+	now := time.Now()
+	cert, key := generateTestCertificate(t, []string{"*.example.com"}, now.Add(20*24*time.Hour))
+	_, otherKey := generateTestCertificate(t, []string{"*.example.com"}, now.Add(20*24*time.Hour))
+
+	values := func(name string, certificate string, certificateKey string, warningDays int64) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"name":                            tftypes.NewValue(tftypes.String, name),
+			"certificate":                     tftypes.NewValue(tftypes.String, certificate),
+			"certificate_key":                 tftypes.NewValue(tftypes.String, certificateKey),
+			"certificate_expiry_warning_days": tftypes.NewValue(tftypes.Number, warningDays),
+		}
+	}
+
+	unsetWarningDays := values("cdn.example.com", cert, key, 0)
+	unsetWarningDays["certificate_expiry_warning_days"] = tftypes.NewValue(tftypes.Number, nil)
+
+	testCases := []testCase{
+		// no certificate
+		{false, false, now, values("cdn.example.com", "", "", 7)},
+		// valid
+		{false, false, now, values("cdn.example.com", cert, key, 7)},
+		// wildcard does not cover the apex or nested subdomains
+		{true, false, now, values("example.com", cert, key, 7)},
+		{true, false, now, values("a.cdn.example.com", cert, key, 7)},
+		// mismatched key
+		{true, false, now, values("cdn.example.com", cert, otherKey, 7)},
+		// invalid PEM
+		{true, false, now, values("cdn.example.com", "invalid", key, 7)},
+		// about to expire
+		{false, true, now, values("cdn.example.com", cert, key, 30)},
+		// about to expire, with the default warning days
+		{false, true, now, unsetWarningDays},
+		// expired
+		{true, false, now.Add(30 * 24 * time.Hour), values("cdn.example.com", cert, key, 7)},
+		// not valid yet
+		{true, false, now.Add(-2 * time.Hour), values("cdn.example.com", cert, key, 7)},
+	}
+
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":                            schema.StringAttribute{},
+			"certificate":                     schema.StringAttribute{},
+			"certificate_key":                 schema.StringAttribute{},
+			"certificate_expiry_warning_days": schema.Int64Attribute{},
+		},
+	}
+
+	configTypes := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":                            tftypes.String,
+			"certificate":                     tftypes.String,
+			"certificate_key":                 tftypes.String,
+			"certificate_expiry_warning_days": tftypes.Number,
+		},
+	}
+
+	for i, testCase := range testCases {
+		request := resource.ValidateConfigRequest{
+			Config: tfsdk.Config{
+				Schema: configSchema,
+				Raw:    tftypes.NewValue(configTypes, testCase.PlanValues),
+			},
+		}
+
+		response := resource.ValidateConfigResponse{}
+		certificateDetailsValidator{now: func() time.Time { return testCase.Now }}.ValidateResource(context.Background(), request, &response)
+
+		if testCase.ExpectedError && !response.Diagnostics.HasError() {
+			t.Errorf("case %d: expected error, got none", i)
+		}
+
+		if !testCase.ExpectedError && response.Diagnostics.HasError() {
+			t.Errorf("case %d: expected no errors, got %s", i, response.Diagnostics.Errors())
+		}
+
+		if testCase.ExpectedWarning != (response.Diagnostics.WarningsCount() > 0) {
+			t.Errorf("case %d: expected warning to be %t, got %s", i, testCase.ExpectedWarning, response.Diagnostics.Warnings())
+		}
+	}
+}
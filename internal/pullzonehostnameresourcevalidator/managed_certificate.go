@@ -0,0 +1,51 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzonehostnameresourcevalidator
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func ManagedCertificate() resource.ConfigValidator {
+	return managedCertificateValidator{}
+}
+
+type managedCertificateValidator struct{}
+
+func (v managedCertificateValidator) Description(ctx context.Context) string {
+	return "When using managed certificates, the tls_enabled must be true and a custom certificate cannot be provided."
+}
+
+func (v managedCertificateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v managedCertificateValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var managedCertificate types.Object
+	request.Config.GetAttribute(ctx, path.Root("managed_certificate"), &managedCertificate)
+
+	if managedCertificate.IsUnknown() || managedCertificate.IsNull() {
+		return
+	}
+
+	tlsEnabledAttr := path.Root("tls_enabled")
+	var tlsEnabled types.Bool
+	request.Config.GetAttribute(ctx, tlsEnabledAttr, &tlsEnabled)
+
+	if !tlsEnabled.IsUnknown() && !tlsEnabled.ValueBool() {
+		response.Diagnostics.AddAttributeError(tlsEnabledAttr, "Attribute must be set to true", fmt.Sprintf("\"%s\" must be set to true when using managed certificates.", tlsEnabledAttr))
+	}
+
+	certificateAttr := path.Root("certificate")
+	var certificate types.String
+	request.Config.GetAttribute(ctx, certificateAttr, &certificate)
+
+	if !certificate.IsNull() {
+		response.Diagnostics.AddAttributeError(certificateAttr, "Invalid attribute combination", fmt.Sprintf("\"%s\" cannot be set when using managed certificates.", certificateAttr))
+	}
+}
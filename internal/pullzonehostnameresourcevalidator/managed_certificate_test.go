@@ -0,0 +1,124 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzonehostnameresourcevalidator
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"testing"
+)
+
+func TestManagedCertificate(t *testing.T) {
+	type testCase struct {
+		ExpectedError bool
+		PlanValues    map[string]tftypes.Value
+	}
+
+	managedCertificateType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"timeout": tftypes.Number,
+		},
+	}
+
+	managedCertificate := tftypes.NewValue(managedCertificateType, map[string]tftypes.Value{
+		"timeout": tftypes.NewValue(tftypes.Number, 300),
+	})
+
+	testCases := []testCase{
+		{
+			ExpectedError: false,
+			PlanValues: map[string]tftypes.Value{
+				"tls_enabled":         tftypes.NewValue(tftypes.Bool, nil),
+				"certificate":         tftypes.NewValue(tftypes.String, nil),
+				"managed_certificate": tftypes.NewValue(managedCertificateType, nil),
+			},
+		},
+		{
+			ExpectedError: true,
+			PlanValues: map[string]tftypes.Value{
+				"tls_enabled":         tftypes.NewValue(tftypes.Bool, nil),
+				"certificate":         tftypes.NewValue(tftypes.String, nil),
+				"managed_certificate": managedCertificate,
+			},
+		},
+		{
+			ExpectedError: true,
+			PlanValues: map[string]tftypes.Value{
+				"tls_enabled":         tftypes.NewValue(tftypes.Bool, false),
+				"certificate":         tftypes.NewValue(tftypes.String, nil),
+				"managed_certificate": managedCertificate,
+			},
+		},
+		{
+			ExpectedError: false,
+			PlanValues: map[string]tftypes.Value{
+				"tls_enabled":         tftypes.NewValue(tftypes.Bool, true),
+				"certificate":         tftypes.NewValue(tftypes.String, nil),
+				"managed_certificate": managedCertificate,
+			},
+		},
+		{
+			ExpectedError: true,
+			PlanValues: map[string]tftypes.Value{
+				"tls_enabled":         tftypes.NewValue(tftypes.Bool, true),
+				"certificate":         tftypes.NewValue(tftypes.String, "test"),
+				"managed_certificate": managedCertificate,
+			},
+		},
+		{
+			ExpectedError: false,
+			PlanValues: map[string]tftypes.Value{
+				"tls_enabled":         tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+				"certificate":         tftypes.NewValue(tftypes.String, nil),
+				"managed_certificate": managedCertificate,
+			},
+		},
+	}
+
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"tls_enabled": schema.BoolAttribute{},
+			"certificate": schema.StringAttribute{},
+		},
+		Blocks: map[string]schema.Block{
+			"managed_certificate": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.Int64Attribute{},
+				},
+			},
+		},
+	}
+
+	configTypes := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"tls_enabled":         tftypes.Bool,
+			"certificate":         tftypes.String,
+			"managed_certificate": managedCertificateType,
+		},
+	}
+
+	for _, testCase := range testCases {
+		request := resource.ValidateConfigRequest{
+			Config: tfsdk.Config{
+				Schema: configSchema,
+				Raw:    tftypes.NewValue(configTypes, testCase.PlanValues),
+			},
+		}
+
+		response := resource.ValidateConfigResponse{}
+		managedCertificateValidator{}.ValidateResource(context.Background(), request, &response)
+
+		if testCase.ExpectedError && !response.Diagnostics.HasError() {
+			t.Error("expected error, got none")
+		}
+
+		if !testCase.ExpectedError && response.Diagnostics.HasError() {
+			t.Errorf("expected no errors, got %s", response.Diagnostics.Errors())
+		}
+	}
+}
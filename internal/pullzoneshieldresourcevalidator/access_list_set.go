@@ -23,7 +23,8 @@ func (v accessListSet) MarkdownDescription(ctx context.Context) string {
 
 func (v accessListSet) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
 	elements := req.ConfigValue.Elements()
-	seen := make(map[int64]struct{}, len(elements))
+	seenIds := make(map[int64]struct{}, len(elements))
+	seenNames := make(map[string]struct{}, len(elements))
 
 	for _, element := range elements {
 		if element.IsUnknown() {
@@ -31,21 +32,46 @@ func (v accessListSet) ValidateSet(ctx context.Context, req validator.SetRequest
 		}
 
 		attrs := element.(types.Object).Attributes()
-		idAttr, ok := attrs["id"]
-		if !ok {
+		idAttr, hasId := attrs["id"]
+		nameAttr, hasName := attrs["name"]
+
+		if (hasId && idAttr.IsUnknown()) || (hasName && nameAttr.IsUnknown()) {
 			return
 		}
 
-		if idAttr.IsUnknown() || idAttr.IsNull() {
+		idSet := hasId && !idAttr.IsNull()
+		nameSet := hasName && !nameAttr.IsNull()
+
+		if idSet && nameSet {
+			resp.Diagnostics.AddError("Invalid access list", "Each access_list must have either an id or a name, not both")
 			return
 		}
 
-		id := idAttr.(types.Int64).ValueInt64()
-		if _, ok := seen[id]; ok {
-			resp.Diagnostics.AddError("Duplicate access list", fmt.Sprintf("There are multiple access_list entries with id = \"%d\"", id))
+		if !idSet && !nameSet {
+			if hasName {
+				resp.Diagnostics.AddError("Invalid access list", "Each access_list must have either an id or a name")
+			}
+
+			return
+		}
+
+		if idSet {
+			id := idAttr.(types.Int64).ValueInt64()
+			if _, ok := seenIds[id]; ok {
+				resp.Diagnostics.AddError("Duplicate access list", fmt.Sprintf("There are multiple access_list entries with id = \"%d\"", id))
+				return
+			}
+
+			seenIds[id] = struct{}{}
+			continue
+		}
+
+		name := nameAttr.(types.String).ValueString()
+		if _, ok := seenNames[name]; ok {
+			resp.Diagnostics.AddError("Duplicate access list", fmt.Sprintf("There are multiple access_list entries with name = \"%s\"", name))
 			return
 		}
 
-		seen[id] = struct{}{}
+		seenNames[name] = struct{}{}
 	}
 }
@@ -116,3 +116,85 @@ func TestAccessListSetUniqueValues(t *testing.T) {
 		}
 	}
 }
+
+func TestAccessListSetNames(t *testing.T) {
+	type testCase struct {
+		ExpectedError bool
+		Values        []attr.Value
+	}
+
+	accessListType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":     types.Int64Type,
+			"name":   types.StringType,
+			"action": types.StringType,
+		},
+	}
+
+	accessList := func(id types.Int64, name types.String, action string) attr.Value {
+		return types.ObjectValueMust(accessListType.AttrTypes, map[string]attr.Value{
+			"id":     id,
+			"name":   name,
+			"action": types.StringValue(action),
+		})
+	}
+
+	testCases := []testCase{
+		{
+			ExpectedError: false,
+			Values: []attr.Value{
+				accessList(types.Int64Value(1), types.StringNull(), "Block"),
+				accessList(types.Int64Null(), types.StringValue("Tor"), "Block"),
+			},
+		},
+		{
+			ExpectedError: false,
+			Values: []attr.Value{
+				accessList(types.Int64Null(), types.StringUnknown(), "Block"),
+			},
+		},
+		{
+			ExpectedError: true,
+			Values: []attr.Value{
+				accessList(types.Int64Null(), types.StringNull(), "Block"),
+			},
+		},
+		{
+			ExpectedError: true,
+			Values: []attr.Value{
+				accessList(types.Int64Value(1), types.StringValue("Tor"), "Block"),
+			},
+		},
+		{
+			ExpectedError: true,
+			Values: []attr.Value{
+				accessList(types.Int64Null(), types.StringValue("Tor"), "Block"),
+				accessList(types.Int64Null(), types.StringValue("Tor"), "Log"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		set, diags := types.SetValue(accessListType, tc.Values)
+		if diags.HasError() {
+			t.Error(diags)
+			continue
+		}
+
+		request := validator.SetRequest{
+			Path:        path.Root("access_list"),
+			ConfigValue: set,
+		}
+
+		response := validator.SetResponse{}
+		accessListSet{}.ValidateSet(context.Background(), request, &response)
+
+		if tc.ExpectedError && !response.Diagnostics.HasError() {
+			t.Error("expected error, got none")
+		}
+
+		if !tc.ExpectedError && response.Diagnostics.HasError() {
+			t.Errorf("expected no errors, got %s", response.Diagnostics.Errors())
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzoneshieldresourcevalidator
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func DdosLevel() resource.ConfigValidator {
+	return ddosLevelValidator{}
+}
+
+type ddosLevelValidator struct{}
+
+func (v ddosLevelValidator) Description(ctx context.Context) string {
+	return "ddos.level is required unless \"preset\" is set"
+}
+
+func (v ddosLevelValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ddosLevelValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var preset types.String
+	req.Config.GetAttribute(ctx, path.Root("preset"), &preset)
+
+	if preset.IsUnknown() || !preset.IsNull() {
+		return
+	}
+
+	var ddos types.Object
+	req.Config.GetAttribute(ctx, path.Root("ddos"), &ddos)
+
+	if ddos.IsUnknown() || ddos.IsNull() {
+		return
+	}
+
+	var level types.String
+	levelAttr := path.Root("ddos").AtName("level")
+	req.Config.GetAttribute(ctx, levelAttr, &level)
+
+	if !level.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(levelAttr, "Missing DDoS level", "ddos.level must be set, unless a \"preset\" is used.")
+}
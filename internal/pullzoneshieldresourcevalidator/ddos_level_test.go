@@ -0,0 +1,112 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzoneshieldresourcevalidator
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"testing"
+)
+
+func TestDdosLevel(t *testing.T) {
+	type testCase struct {
+		ExpectedError bool
+		PlanValues    map[string]tftypes.Value
+	}
+
+	ddosType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"level": tftypes.String,
+		},
+	}
+
+	testCases := []testCase{
+		{
+			ExpectedError: false,
+			PlanValues: map[string]tftypes.Value{
+				"preset": tftypes.NewValue(tftypes.String, nil),
+				"ddos": tftypes.NewValue(ddosType, map[string]tftypes.Value{
+					"level": tftypes.NewValue(tftypes.String, "Medium"),
+				}),
+			},
+		},
+		{
+			ExpectedError: true,
+			PlanValues: map[string]tftypes.Value{
+				"preset": tftypes.NewValue(tftypes.String, nil),
+				"ddos": tftypes.NewValue(ddosType, map[string]tftypes.Value{
+					"level": tftypes.NewValue(tftypes.String, nil),
+				}),
+			},
+		},
+		{
+			ExpectedError: false,
+			PlanValues: map[string]tftypes.Value{
+				"preset": tftypes.NewValue(tftypes.String, "api"),
+				"ddos": tftypes.NewValue(ddosType, map[string]tftypes.Value{
+					"level": tftypes.NewValue(tftypes.String, nil),
+				}),
+			},
+		},
+		{
+			ExpectedError: false,
+			PlanValues: map[string]tftypes.Value{
+				"preset": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"ddos": tftypes.NewValue(ddosType, map[string]tftypes.Value{
+					"level": tftypes.NewValue(tftypes.String, nil),
+				}),
+			},
+		},
+		{
+			ExpectedError: false,
+			PlanValues: map[string]tftypes.Value{
+				"preset": tftypes.NewValue(tftypes.String, nil),
+				"ddos":   tftypes.NewValue(ddosType, nil),
+			},
+		},
+	}
+
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"preset": schema.StringAttribute{},
+		},
+		Blocks: map[string]schema.Block{
+			"ddos": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"level": schema.StringAttribute{},
+				},
+			},
+		},
+	}
+
+	configTypes := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"preset": tftypes.String,
+			"ddos":   ddosType,
+		},
+	}
+
+	for _, data := range testCases {
+		request := resource.ValidateConfigRequest{
+			Config: tfsdk.Config{
+				Schema: configSchema,
+				Raw:    tftypes.NewValue(configTypes, data.PlanValues),
+			},
+		}
+
+		response := resource.ValidateConfigResponse{}
+		ddosLevelValidator{}.ValidateResource(context.Background(), request, &response)
+
+		if data.ExpectedError && !response.Diagnostics.HasError() {
+			t.Error("expected error, got none")
+		}
+
+		if !data.ExpectedError && response.Diagnostics.HasError() {
+			t.Errorf("expected no errors, got %s", response.Diagnostics.Errors())
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzoneshieldresourcevalidator
+
+import (
+	"sort"
+	"strings"
+)
+
+// WafRuleSuggestions returns up to three known WAF rule IDs that are close to value, the closest first.
+func WafRuleSuggestions(value string, known []string) []string {
+	type candidate struct {
+		id       string
+		distance int
+	}
+
+	value = strings.ToLower(value)
+	maxDistance := max(len(value)/3, 1)
+
+	var candidates []candidate
+	for _, id := range known {
+		distance := levenshtein(value, strings.ToLower(id))
+		if distance > maxDistance {
+			continue
+		}
+
+		candidates = append(candidates, candidate{id: id, distance: distance})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+
+		return candidates[i].id < candidates[j].id
+	})
+
+	result := make([]string, 0, 3)
+	for _, c := range candidates {
+		if len(result) == 3 {
+			break
+		}
+
+		result = append(result, c.id)
+	}
+
+	return result
+}
+
+func levenshtein(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
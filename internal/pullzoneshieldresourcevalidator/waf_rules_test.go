@@ -0,0 +1,33 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package pullzoneshieldresourcevalidator
+
+import (
+	"golang.org/x/exp/slices"
+	"testing"
+)
+
+func TestWafRuleSuggestions(t *testing.T) {
+	type testCase struct {
+		Value    string
+		Expected []string
+	}
+
+	known := []string{"913100", "913101", "913110", "920100", "942100", "REQUEST-913-SCANNER-DETECTION"}
+
+	testCases := []testCase{
+		{Value: "913100", Expected: []string{"913100", "913101", "913110"}},
+		{Value: "931100", Expected: []string{"913100", "913110", "920100"}},
+		{Value: "92010", Expected: []string{"920100"}},
+		{Value: "123456", Expected: []string{}},
+		{Value: "request-913-scanner-detectoin", Expected: []string{"REQUEST-913-SCANNER-DETECTION"}},
+	}
+
+	for _, data := range testCases {
+		result := WafRuleSuggestions(data.Value, known)
+		if !slices.Equal(result, data.Expected) {
+			t.Errorf("%s: expected %v, got %v", data.Value, data.Expected, result)
+		}
+	}
+}
@@ -45,6 +45,7 @@ func PullzoneRatelimitRuleV0(ctx context.Context, req resource.UpgradeStateReque
 			"pullzone":    tftypes.Number,
 			"name":        tftypes.String,
 			"description": tftypes.String,
+			"expression":  tftypes.String,
 			"transformations": tftypes.List{
 				ElementType: tftypes.String,
 			},
@@ -129,6 +130,7 @@ func PullzoneRatelimitRuleV0(ctx context.Context, req resource.UpgradeStateReque
 		"pullzone":        oldState["pullzone"],
 		"name":            oldState["name"],
 		"description":     oldState["description"],
+		"expression":      tftypes.NewValue(tftypes.String, nil),
 		"transformations": tftypes.NewValue(newType.AttributeTypes["transformations"], newStateTransformations),
 		"condition":       tftypes.NewValue(newType.AttributeTypes["condition"], newStateCondition),
 		"limit":           oldState["limit"],
@@ -39,6 +39,7 @@ func PullzoneWafRuleV0(ctx context.Context, req resource.UpgradeStateRequest, re
 			"pullzone":    tftypes.Number,
 			"name":        tftypes.String,
 			"description": tftypes.String,
+			"expression":  tftypes.String,
 			"transformations": tftypes.List{
 				ElementType: tftypes.String,
 			},
@@ -117,6 +118,7 @@ func PullzoneWafRuleV0(ctx context.Context, req resource.UpgradeStateRequest, re
 		"pullzone":        oldState["pullzone"],
 		"name":            oldState["name"],
 		"description":     oldState["description"],
+		"expression":      tftypes.NewValue(tftypes.String, nil),
 		"transformations": tftypes.NewValue(newType.AttributeTypes["transformations"], newStateTransformations),
 		"condition":       tftypes.NewValue(newType.AttributeTypes["condition"], newStateCondition),
 		"response":        oldState["response"],
@@ -0,0 +1,311 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldpolicy
+
+import (
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"reflect"
+)
+
+type Kind string
+
+const (
+	KindShield            Kind = "shield"
+	KindCuratedAccessList Kind = "curated_access_list"
+	KindAccessList        Kind = "access_list"
+	KindWafRule           Kind = "waf_rule"
+	KindRatelimitRule     Kind = "ratelimit_rule"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a single operation needed to apply a document.
+type Change struct {
+	Kind   Kind
+	Action Action
+	Name   string
+	// Id is the ID of the existing item, for updates and deletes.
+	Id int64
+	// Index is the position of the item in the desired document, for creates and updates.
+	Index int
+}
+
+func (c Change) String() string {
+	if c.Kind == KindShield {
+		return fmt.Sprintf("%s %s", c.Action, c.Kind)
+	}
+
+	return fmt.Sprintf("%s %s \"%s\"", c.Action, c.Kind, c.Name)
+}
+
+// Diff returns the changes needed to turn the current document into the desired one.
+// Items are matched by name; items only found in the current document are deleted.
+// Changes are ordered by kind: shield settings, curated access lists, access lists, WAF rules and ratelimit rules.
+func Diff(current Document, desired Document) []Change {
+	current = current.normalized()
+	desired = desired.normalized()
+
+	var changes []Change
+
+	// shield
+	if !reflect.DeepEqual(desired.Shield.merged(current.Shield), current.Shield.normalized()) {
+		changes = append(changes, Change{Kind: KindShield, Action: ActionUpdate})
+	}
+
+	desired.CuratedAccessLists = resolvedCuratedAccessLists(desired.CuratedAccessLists, current.CuratedAccessLists)
+	changes = append(changes, diffItems(KindCuratedAccessList, desired.CuratedAccessLists, current.CuratedAccessLists)...)
+
+	for _, change := range diffItems(KindAccessList, desired.AccessLists, current.AccessLists) {
+		// the type of an access list cannot be changed, so it is replaced
+		if change.Action == ActionUpdate && desired.AccessLists[change.Index].Type != accessListType(current.AccessLists, change.Id) {
+			changes = append(changes,
+				Change{Kind: KindAccessList, Action: ActionDelete, Name: change.Name, Id: change.Id},
+				Change{Kind: KindAccessList, Action: ActionCreate, Name: change.Name, Index: change.Index},
+			)
+
+			continue
+		}
+
+		changes = append(changes, change)
+	}
+
+	changes = append(changes, diffItems(KindWafRule, desired.WafRules, current.WafRules)...)
+	changes = append(changes, diffItems(KindRatelimitRule, desired.RatelimitRules, current.RatelimitRules)...)
+
+	return changes
+}
+
+// ListedIn returns a copy of the document without the WAF rules, ratelimit rules and custom access lists missing from other.
+// Diffing the result against other leaves the items managed elsewhere untouched.
+func (d Document) ListedIn(other Document) Document {
+	result := d
+	result.WafRules = listedItems(d.WafRules, other.WafRules)
+	result.RatelimitRules = listedItems(d.RatelimitRules, other.RatelimitRules)
+	result.AccessLists = listedItems(d.AccessLists, other.AccessLists)
+
+	return result
+}
+
+// ApplyShield returns the shield configuration with the settings and curated access lists of the document.
+// Engine config items missing from the document keep their current value.
+// Curated access lists missing from the document are disabled, keeping their current action and priority.
+func (d Document) ApplyShield(current api.PullzoneShield) (api.PullzoneShield, error) {
+	s := d.Shield
+	result := current
+
+	result.PlanType = s.PlanType
+	result.DDoSLevel = s.DDoSShieldSensitivity
+	result.DDoSMode = s.DDoSExecutionMode
+	result.DDosChallengeWindow = s.DDoSChallengeWindow
+	result.WafEnabled = s.WafEnabled
+	result.WafMode = s.WafExecutionMode
+	result.WafRealtimeThreatIntelligenceEnabled = s.WafRealtimeThreatIntelligenceEnabled
+	result.WafLogHeaders = s.WafRequestHeaderLoggingEnabled
+	result.WafLogHeadersExcluded = sortedStrings(s.WafRequestIgnoredHeaders)
+	result.WafRulesDisabled = sortedStrings(s.WafDisabledRules)
+	result.WafRulesLogonly = sortedStrings(s.WafLogOnlyRules)
+	result.WafRequestBodyLimitAction = s.WafRequestBodyLimitAction
+	result.WafResponseBodyLimitAction = s.WafResponseBodyLimitAction
+	result.WhiteLabelResponsePages = s.WhitelabelResponsePages
+	result.BotDetectionMode = s.BotDetection.ExecutionMode
+	result.BotDetectionRequestIntegrity = s.BotDetection.RequestIntegrity
+	result.BotDetectionIPSensitivity = s.BotDetection.IpAddress
+	result.BotDetectionFingerprintSensitivity = s.BotDetection.FingerprintSensitivity
+	result.BotDetectionFingerprintAggression = s.BotDetection.FingerprintAggression
+	result.BotDetectionComplexFingerprinting = s.BotDetection.ComplexFingerprinting
+
+	if err := result.SetWafEngineConfig(s.WafEngineConfig); err != nil {
+		return current, fmt.Errorf("invalid engine config: %w", err)
+	}
+
+	currentLists := make([]CuratedAccessList, 0, len(current.AccessLists))
+	for _, list := range current.AccessLists {
+		if list.IsEnabled {
+			currentLists = append(currentLists, CuratedAccessList{Name: list.Name, Action: list.Action, Priority: list.Priority})
+		}
+	}
+
+	desiredLists := resolvedCuratedAccessLists(d.CuratedAccessLists, currentLists)
+	enabled := make(map[string]bool, len(desiredLists))
+
+	result.AccessLists = make([]api.PullzoneShieldAccessList, 0, len(desiredLists)+len(current.AccessLists))
+	for _, list := range desiredLists {
+		enabled[list.Name] = true
+		result.AccessLists = append(result.AccessLists, api.PullzoneShieldAccessList{
+			Name:      list.Name,
+			Action:    list.Action,
+			IsEnabled: true,
+			Priority:  list.Priority,
+		})
+	}
+
+	for _, list := range current.AccessLists {
+		if enabled[list.Name] {
+			continue
+		}
+
+		list.IsEnabled = false
+		result.AccessLists = append(result.AccessLists, list)
+	}
+
+	return result, nil
+}
+
+// resolvedCuratedAccessLists returns a copy of the desired curated access lists, with the missing priorities resolved.
+// Lists already enabled keep their current priority, and newly enabled lists are evaluated after every other list.
+func resolvedCuratedAccessLists(desired []CuratedAccessList, current []CuratedAccessList) []CuratedAccessList {
+	currentPriorities := make(map[string]int64, len(current))
+	var highest int64
+	for _, list := range current {
+		currentPriorities[list.Name] = list.Priority
+		highest = max(highest, list.Priority)
+	}
+
+	for _, list := range desired {
+		highest = max(highest, list.Priority)
+	}
+
+	result := make([]CuratedAccessList, 0, len(desired))
+	for _, list := range desired {
+		if list.Priority == 0 {
+			if priority, ok := currentPriorities[list.Name]; ok {
+				list.Priority = priority
+			} else {
+				highest++
+				list.Priority = highest
+			}
+		}
+
+		result = append(result, list)
+	}
+
+	return result
+}
+
+type item[T any] interface {
+	itemName() string
+	itemId() int64
+	withoutId() T
+}
+
+// diffItems matches the desired items to the current ones by name.
+// If several current items share a name, the first one is matched and the others are deleted.
+func diffItems[T item[T]](kind Kind, desired []T, current []T) []Change {
+	var changes []Change
+	matched := make([]bool, len(current))
+
+	for i, desiredItem := range desired {
+		found := -1
+		for j, currentItem := range current {
+			if !matched[j] && currentItem.itemName() == desiredItem.itemName() {
+				found = j
+				break
+			}
+		}
+
+		if found == -1 {
+			changes = append(changes, Change{Kind: kind, Action: ActionCreate, Name: desiredItem.itemName(), Index: i})
+			continue
+		}
+
+		matched[found] = true
+		if reflect.DeepEqual(desiredItem.withoutId(), current[found].withoutId()) {
+			continue
+		}
+
+		changes = append(changes, Change{Kind: kind, Action: ActionUpdate, Name: desiredItem.itemName(), Id: current[found].itemId(), Index: i})
+	}
+
+	for j, currentItem := range current {
+		if !matched[j] {
+			changes = append(changes, Change{Kind: kind, Action: ActionDelete, Name: currentItem.itemName(), Id: currentItem.itemId()})
+		}
+	}
+
+	return changes
+}
+
+func listedItems[T item[T]](items []T, other []T) []T {
+	names := make(map[string]bool, len(other))
+	for _, otherItem := range other {
+		names[otherItem.itemName()] = true
+	}
+
+	result := make([]T, 0, len(items))
+	for _, i := range items {
+		if names[i.itemName()] {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+func (r WafRule) itemName() string {
+	return r.Name
+}
+
+func (r WafRule) itemId() int64 {
+	return r.Id
+}
+
+func (r WafRule) withoutId() WafRule {
+	r.Id = 0
+	return r
+}
+
+func (r RatelimitRule) itemName() string {
+	return r.Name
+}
+
+func (r RatelimitRule) itemId() int64 {
+	return r.Id
+}
+
+func (r RatelimitRule) withoutId() RatelimitRule {
+	r.Id = 0
+	return r
+}
+
+func (l AccessList) itemName() string {
+	return l.Name
+}
+
+func (l AccessList) itemId() int64 {
+	return l.Id
+}
+
+func (l AccessList) withoutId() AccessList {
+	l.Id = 0
+	return l
+}
+
+func (l CuratedAccessList) itemName() string {
+	return l.Name
+}
+
+func (l CuratedAccessList) itemId() int64 {
+	return 0
+}
+
+func (l CuratedAccessList) withoutId() CuratedAccessList {
+	return l
+}
+
+func accessListType(lists []AccessList, id int64) uint8 {
+	for _, list := range lists {
+		if list.Id == id {
+			return list.Type
+		}
+	}
+
+	return 0
+}
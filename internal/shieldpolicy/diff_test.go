@@ -0,0 +1,213 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldpolicy
+
+import (
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	engineConfig := []api.PullzoneShieldWafEngineConfigItem{
+		{Name: "blocking_paranoia_level", ValueEncoded: "1"},
+		{Name: "detection_paranoia_level", ValueEncoded: "1"},
+	}
+
+	current := Document{
+		Version: Version,
+		Shield:  Shield{WafEnabled: true, WafEngineConfig: engineConfig},
+		WafRules: []WafRule{
+			{Id: 1, Name: "unchanged", Configuration: api.PullzoneWafRuleConfiguration{ActionType: 1}},
+			{Id: 2, Name: "changed", Configuration: api.PullzoneWafRuleConfiguration{ActionType: 1}},
+			{Id: 3, Name: "removed"},
+			{Id: 4, Name: "unchanged"},
+		},
+		RatelimitRules: []RatelimitRule{
+			{Id: 5, Name: "login", Configuration: api.PullzoneRatelimitRuleConfiguration{RequestCount: 10}},
+		},
+		AccessLists: []AccessList{
+			{Id: 6, Name: "office", Type: 0, Entries: []string{"192.0.2.1"}},
+			{Id: 7, Name: "partners", Type: 0, Entries: []string{"192.0.2.2"}},
+		},
+		CuratedAccessLists: []CuratedAccessList{
+			{Name: "Tor exit nodes", Action: 1, Priority: 1},
+			{Name: "VPN providers", Action: 1, Priority: 2},
+		},
+	}
+
+	type testCase struct {
+		Name     string
+		Desired  func(doc Document) Document
+		Expected []string
+	}
+
+	testCases := []testCase{
+		{
+			Name: "no changes",
+			Desired: func(doc Document) Document {
+				doc.WafRules = append(doc.WafRules[:3:3], WafRule{Name: "unchanged"})
+				return doc
+			},
+			Expected: nil,
+		},
+		{
+			Name: "curated access list priority",
+			Desired: func(doc Document) Document {
+				doc.WafRules = append(doc.WafRules[:3:3], WafRule{Name: "unchanged"})
+				doc.CuratedAccessLists = []CuratedAccessList{
+					{Name: "Tor exit nodes", Action: 1},
+					{Name: "VPN providers", Action: 1, Priority: 3},
+				}
+				return doc
+			},
+			Expected: []string{`update curated_access_list "VPN providers"`},
+		},
+		{
+			Name: "engine config subset",
+			Desired: func(doc Document) Document {
+				doc.Shield.WafEngineConfig = engineConfig[:1]
+				doc.WafRules = append(doc.WafRules[:3:3], WafRule{Name: "unchanged"})
+				return doc
+			},
+			Expected: nil,
+		},
+		{
+			Name: "items",
+			Desired: func(doc Document) Document {
+				doc.Shield = Shield{WafEnabled: false}
+				doc.WafRules = []WafRule{
+					{Name: "added", Configuration: api.PullzoneWafRuleConfiguration{ActionType: 2}},
+					{Name: "changed", Configuration: api.PullzoneWafRuleConfiguration{ActionType: 2}},
+					{Name: "unchanged", Configuration: api.PullzoneWafRuleConfiguration{ActionType: 1}},
+				}
+				doc.RatelimitRules = nil
+				doc.AccessLists = []AccessList{
+					{Name: "office", Type: 1, Entries: []string{"192.0.2.1/32"}},
+					{Name: "partners", Type: 0, Entries: []string{"192.0.2.3"}},
+				}
+				doc.CuratedAccessLists = []CuratedAccessList{
+					{Name: "Tor exit nodes", Action: 2},
+					{Name: "Botnets", Action: 1},
+				}
+				return doc
+			},
+			Expected: []string{
+				`update shield`,
+				`update curated_access_list "Tor exit nodes"`,
+				`create curated_access_list "Botnets"`,
+				`delete curated_access_list "VPN providers"`,
+				`delete access_list "office"`,
+				`create access_list "office"`,
+				`update access_list "partners"`,
+				`create waf_rule "added"`,
+				`update waf_rule "changed"`,
+				`delete waf_rule "removed"`,
+				`delete waf_rule "unchanged"`,
+				`delete ratelimit_rule "login"`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		var result []string
+		for _, change := range Diff(current, tc.Desired(current)) {
+			result = append(result, change.String())
+		}
+
+		if !reflect.DeepEqual(result, tc.Expected) {
+			t.Errorf("%s: expected %v, got %v", tc.Name, tc.Expected, result)
+		}
+	}
+}
+
+func TestDiffIds(t *testing.T) {
+	current := Document{
+		WafRules: []WafRule{{Id: 10, Name: "a"}, {Id: 11, Name: "b"}},
+	}
+
+	desired := Document{
+		WafRules: []WafRule{{Name: "c"}, {Name: "a", Description: "updated"}},
+	}
+
+	expected := []Change{
+		{Kind: KindWafRule, Action: ActionCreate, Name: "c", Index: 0},
+		{Kind: KindWafRule, Action: ActionUpdate, Name: "a", Id: 10, Index: 1},
+		{Kind: KindWafRule, Action: ActionDelete, Name: "b", Id: 11},
+	}
+
+	if result := Diff(current, desired); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestApplyShield(t *testing.T) {
+	current := api.PullzoneShield{
+		Id:                          1,
+		WafAllowedHttpMethods:       []string{"GET"},
+		WafRuleSensitivityBlocking:  1,
+		WafRuleSensitivityDetection: 1,
+		AccessLists: []api.PullzoneShieldAccessList{
+			{Id: 10, Name: "VPN providers", Action: 2, IsEnabled: true, Priority: 3},
+			{Id: 11, Name: "Spamhaus", Action: 1, IsEnabled: true, Priority: 1},
+		},
+	}
+
+	doc := Document{
+		Shield: Shield{
+			WafEnabled:      true,
+			WafEngineConfig: []api.PullzoneShieldWafEngineConfigItem{{Name: "blocking_paranoia_level", ValueEncoded: "3"}},
+		},
+		CuratedAccessLists: []CuratedAccessList{{Name: "Tor exit nodes", Action: 1}, {Name: "Spamhaus", Action: 2}},
+	}
+
+	result, err := doc.ApplyShield(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Id != 1 || !result.WafEnabled || result.WafRuleSensitivityBlocking != 3 || result.WafRuleSensitivityDetection != 1 {
+		t.Errorf("unexpected shield %+v", result)
+	}
+
+	if !reflect.DeepEqual(result.WafAllowedHttpMethods, []string{"GET"}) {
+		t.Errorf("expected the allowed methods to be kept, got %v", result.WafAllowedHttpMethods)
+	}
+
+	// new lists are evaluated last, existing ones keep their priority, and lists left out are disabled
+	expectedLists := []api.PullzoneShieldAccessList{
+		{Name: "Tor exit nodes", Action: 1, IsEnabled: true, Priority: 4},
+		{Name: "Spamhaus", Action: 2, IsEnabled: true, Priority: 1},
+		{Id: 10, Name: "VPN providers", Action: 2, IsEnabled: false, Priority: 3},
+	}
+	if !reflect.DeepEqual(result.AccessLists, expectedLists) {
+		t.Errorf("expected %v, got %v", expectedLists, result.AccessLists)
+	}
+
+	doc.Shield.WafEngineConfig = []api.PullzoneShieldWafEngineConfigItem{{Name: "blocking_paranoia_level", ValueEncoded: "high"}}
+	if _, err := doc.ApplyShield(current); err == nil {
+		t.Errorf("expected an error for an invalid engine config")
+	}
+}
+
+func TestListedIn(t *testing.T) {
+	current := Document{
+		WafRules:       []WafRule{{Id: 10, Name: "a"}, {Id: 11, Name: "b"}},
+		RatelimitRules: []RatelimitRule{{Id: 20, Name: "login"}},
+		AccessLists:    []AccessList{{Id: 30, Name: "office"}},
+	}
+
+	desired := Document{
+		WafRules: []WafRule{{Name: "a", Description: "changed"}, {Name: "c"}},
+	}
+
+	expected := []Change{
+		{Kind: KindWafRule, Action: ActionUpdate, Name: "a", Id: 10, Index: 0},
+		{Kind: KindWafRule, Action: ActionCreate, Name: "c", Index: 1},
+	}
+
+	if result := Diff(current.ListedIn(desired), desired); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
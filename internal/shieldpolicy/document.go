@@ -0,0 +1,416 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package shieldpolicy converts the configuration of a pullzone shield into a versioned JSON document,
+// and computes the changes needed to apply such a document to a shield.
+package shieldpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/accesslist"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Version is the current version of the document format.
+const Version = 1
+
+// engineConfigLists are the engine config items holding space-separated values.
+var engineConfigLists = map[string]struct{}{
+	"allowed_http_versions":        {},
+	"allowed_methods":              {},
+	"allowed_request_content_type": {},
+}
+
+// Document is the whole configuration of a pullzone shield.
+// Enum values (actions, modes, operators, etc.) use the same numbers as the shield API.
+type Document struct {
+	Version            int                 `json:"version"`
+	Shield             Shield              `json:"shield"`
+	WafRules           []WafRule           `json:"wafRules"`
+	RatelimitRules     []RatelimitRule     `json:"rateLimitRules"`
+	AccessLists        []AccessList        `json:"accessLists"`
+	CuratedAccessLists []CuratedAccessList `json:"curatedAccessLists"`
+}
+
+type Shield struct {
+	PlanType                             uint8                                   `json:"planType"`
+	DDoSShieldSensitivity                uint8                                   `json:"dDoSShieldSensitivity"`
+	DDoSExecutionMode                    uint8                                   `json:"dDoSExecutionMode"`
+	DDoSChallengeWindow                  int64                                   `json:"dDoSChallengeWindow"`
+	WafEnabled                           bool                                    `json:"wafEnabled"`
+	WafExecutionMode                     uint8                                   `json:"wafExecutionMode"`
+	WafRealtimeThreatIntelligenceEnabled bool                                    `json:"wafRealtimeThreatIntelligenceEnabled"`
+	WafRequestHeaderLoggingEnabled       bool                                    `json:"wafRequestHeaderLoggingEnabled"`
+	WafRequestIgnoredHeaders             []string                                `json:"wafRequestIgnoredHeaders"`
+	WafEngineConfig                      []api.PullzoneShieldWafEngineConfigItem `json:"wafEngineConfig"`
+	WafDisabledRules                     []string                                `json:"wafDisabledRules"`
+	WafLogOnlyRules                      []string                                `json:"wafLogOnlyRules"`
+	WafRequestBodyLimitAction            uint8                                   `json:"wafRequestBodyLimitAction"`
+	WafResponseBodyLimitAction           uint8                                   `json:"wafResponseBodyLimitAction"`
+	WhitelabelResponsePages              bool                                    `json:"whitelabelResponsePages"`
+	BotDetection                         BotDetection                            `json:"botDetection"`
+}
+
+type BotDetection struct {
+	ExecutionMode          uint8 `json:"executionMode"`
+	RequestIntegrity       uint8 `json:"requestIntegritySensitivity"`
+	IpAddress              uint8 `json:"ipAddressSensitivity"`
+	FingerprintSensitivity uint8 `json:"browserFingerprintSensitivity"`
+	FingerprintAggression  uint8 `json:"browserFingerprintAggression"`
+	ComplexFingerprinting  bool  `json:"browserFingerprintComplexEnabled"`
+}
+
+type WafRule struct {
+	Id            int64                            `json:"-"`
+	Name          string                           `json:"name"`
+	Description   string                           `json:"description"`
+	Configuration api.PullzoneWafRuleConfiguration `json:"configuration"`
+}
+
+type RatelimitRule struct {
+	Id            int64                                  `json:"-"`
+	Name          string                                 `json:"name"`
+	Description   string                                 `json:"description"`
+	Configuration api.PullzoneRatelimitRuleConfiguration `json:"configuration"`
+}
+
+// AccessList is a custom access list, including its entries.
+type AccessList struct {
+	Id        int64    `json:"-"`
+	Name      string   `json:"name"`
+	Type      uint8    `json:"type"`
+	Action    uint8    `json:"action"`
+	IsEnabled bool     `json:"isEnabled"`
+	Entries   []string `json:"entries"`
+}
+
+// CuratedAccessList is an enabled curated access list, identified by its name.
+// A priority of 0 keeps the current priority.
+type CuratedAccessList struct {
+	Name     string `json:"name"`
+	Action   uint8  `json:"action"`
+	Priority int64  `json:"priority,omitempty"`
+}
+
+// New builds a document from the shield configuration, its rules and its custom access lists.
+func New(shield api.PullzoneShield, wafRules []api.PullzoneWafRule, ratelimitRules []api.PullzoneRatelimitRule, accessLists []api.PullzoneAccessList) Document {
+	doc := Document{
+		Version: Version,
+		Shield: Shield{
+			PlanType:                             shield.PlanType,
+			DDoSShieldSensitivity:                shield.DDoSLevel,
+			DDoSExecutionMode:                    shield.DDoSMode,
+			DDoSChallengeWindow:                  shield.DDosChallengeWindow,
+			WafEnabled:                           shield.WafEnabled,
+			WafExecutionMode:                     shield.WafMode,
+			WafRealtimeThreatIntelligenceEnabled: shield.WafRealtimeThreatIntelligenceEnabled,
+			WafRequestHeaderLoggingEnabled:       shield.WafLogHeaders,
+			WafRequestIgnoredHeaders:             shield.WafLogHeadersExcluded,
+			WafEngineConfig:                      shield.WafEngineConfigItems(),
+			WafDisabledRules:                     shield.WafRulesDisabled,
+			WafLogOnlyRules:                      shield.WafRulesLogonly,
+			WafRequestBodyLimitAction:            shield.WafRequestBodyLimitAction,
+			WafResponseBodyLimitAction:           shield.WafResponseBodyLimitAction,
+			WhitelabelResponsePages:              shield.WhiteLabelResponsePages,
+			BotDetection: BotDetection{
+				ExecutionMode:          shield.BotDetectionMode,
+				RequestIntegrity:       shield.BotDetectionRequestIntegrity,
+				IpAddress:              shield.BotDetectionIPSensitivity,
+				FingerprintSensitivity: shield.BotDetectionFingerprintSensitivity,
+				FingerprintAggression:  shield.BotDetectionFingerprintAggression,
+				ComplexFingerprinting:  shield.BotDetectionComplexFingerprinting,
+			},
+		},
+	}
+
+	for _, rule := range wafRules {
+		doc.WafRules = append(doc.WafRules, WafRule{
+			Id:            rule.Id,
+			Name:          rule.Name,
+			Description:   rule.Description,
+			Configuration: rule.RuleConfiguration,
+		})
+	}
+
+	for _, rule := range ratelimitRules {
+		doc.RatelimitRules = append(doc.RatelimitRules, RatelimitRule{
+			Id:            rule.Id,
+			Name:          rule.Name,
+			Description:   rule.Description,
+			Configuration: rule.RuleConfiguration,
+		})
+	}
+
+	for _, list := range accessLists {
+		doc.AccessLists = append(doc.AccessLists, AccessList{
+			Id:        list.Id,
+			Name:      list.Name,
+			Type:      list.Type,
+			Action:    list.Action,
+			IsEnabled: list.IsEnabled,
+			Entries:   list.Entries,
+		})
+	}
+
+	for _, list := range shield.AccessLists {
+		if !list.IsEnabled {
+			continue
+		}
+
+		doc.CuratedAccessLists = append(doc.CuratedAccessLists, CuratedAccessList{
+			Name:     list.Name,
+			Action:   list.Action,
+			Priority: list.Priority,
+		})
+	}
+
+	return doc.normalized()
+}
+
+// Parse decodes and validates a document. Unknown fields are rejected, so typos are not silently ignored.
+func Parse(content string) (Document, error) {
+	var doc Document
+
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return Document{}, err
+	}
+
+	if decoder.More() {
+		return Document{}, errors.New("unexpected content after the document")
+	}
+
+	if doc.Version == 0 {
+		return Document{}, errors.New("missing document version")
+	}
+
+	if doc.Version > Version {
+		return Document{}, fmt.Errorf("unsupported document version %d, the highest supported version is %d", doc.Version, Version)
+	}
+
+	if err := checkNames("wafRules", len(doc.WafRules), func(i int) string { return doc.WafRules[i].Name }); err != nil {
+		return Document{}, err
+	}
+
+	if err := checkNames("rateLimitRules", len(doc.RatelimitRules), func(i int) string { return doc.RatelimitRules[i].Name }); err != nil {
+		return Document{}, err
+	}
+
+	if err := checkNames("accessLists", len(doc.AccessLists), func(i int) string { return doc.AccessLists[i].Name }); err != nil {
+		return Document{}, err
+	}
+
+	if err := checkNames("curatedAccessLists", len(doc.CuratedAccessLists), func(i int) string { return doc.CuratedAccessLists[i].Name }); err != nil {
+		return Document{}, err
+	}
+
+	for i, list := range doc.AccessLists {
+		entries, err := accesslist.Normalize(accesslist.Type(list.Type), list.Entries)
+		if err != nil {
+			return Document{}, fmt.Errorf("accessLists[%d]: %w", i, err)
+		}
+
+		doc.AccessLists[i].Entries = entries
+	}
+
+	doc.Version = Version
+	return doc.normalized(), nil
+}
+
+// Marshal encodes the document as indented JSON.
+func (d Document) Marshal() (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(d.normalized()); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Equal reports whether both documents describe the same configuration, regardless of item order.
+func Equal(a Document, b Document) bool {
+	return reflect.DeepEqual(a.comparable(), b.comparable())
+}
+
+func checkNames(field string, count int, name func(i int) string) error {
+	seen := make(map[string]bool, count)
+	for i := 0; i < count; i++ {
+		n := name(i)
+		if n == "" {
+			return fmt.Errorf("%s[%d]: missing name", field, i)
+		}
+
+		if seen[n] {
+			return fmt.Errorf("%s[%d]: duplicated name \"%s\"", field, i, n)
+		}
+
+		seen[n] = true
+	}
+
+	return nil
+}
+
+// normalized returns a copy of the document with empty collections instead of nulls, and sets sorted.
+// Items keep their order, so indexes returned by Diff still match the original document.
+func (d Document) normalized() Document {
+	result := d
+	result.Shield = d.Shield.normalized()
+
+	result.WafRules = make([]WafRule, 0, len(d.WafRules))
+	for _, rule := range d.WafRules {
+		rule.Configuration = normalizedWafRuleConfiguration(rule.Configuration)
+		result.WafRules = append(result.WafRules, rule)
+	}
+
+	result.RatelimitRules = make([]RatelimitRule, 0, len(d.RatelimitRules))
+	for _, rule := range d.RatelimitRules {
+		rule.Configuration = normalizedRatelimitRuleConfiguration(rule.Configuration)
+		result.RatelimitRules = append(result.RatelimitRules, rule)
+	}
+
+	result.AccessLists = make([]AccessList, 0, len(d.AccessLists))
+	for _, list := range d.AccessLists {
+		list.Entries = normalizedEntries(list.Type, list.Entries)
+		result.AccessLists = append(result.AccessLists, list)
+	}
+
+	result.CuratedAccessLists = append(make([]CuratedAccessList, 0, len(d.CuratedAccessLists)), d.CuratedAccessLists...)
+
+	return result
+}
+
+// comparable returns a normalized copy without IDs, with items sorted by name.
+func (d Document) comparable() Document {
+	result := d.normalized()
+
+	for i := range result.WafRules {
+		result.WafRules[i].Id = 0
+	}
+
+	for i := range result.RatelimitRules {
+		result.RatelimitRules[i].Id = 0
+	}
+
+	for i := range result.AccessLists {
+		result.AccessLists[i].Id = 0
+	}
+
+	sort.Slice(result.WafRules, func(i, j int) bool { return result.WafRules[i].Name < result.WafRules[j].Name })
+	sort.Slice(result.RatelimitRules, func(i, j int) bool { return result.RatelimitRules[i].Name < result.RatelimitRules[j].Name })
+	sort.Slice(result.AccessLists, func(i, j int) bool { return result.AccessLists[i].Name < result.AccessLists[j].Name })
+	sort.Slice(result.CuratedAccessLists, func(i, j int) bool {
+		return result.CuratedAccessLists[i].Name < result.CuratedAccessLists[j].Name
+	})
+
+	return result
+}
+
+func (s Shield) normalized() Shield {
+	s.WafRequestIgnoredHeaders = sortedStrings(s.WafRequestIgnoredHeaders)
+	s.WafDisabledRules = sortedStrings(s.WafDisabledRules)
+	s.WafLogOnlyRules = sortedStrings(s.WafLogOnlyRules)
+
+	engineConfig := make([]api.PullzoneShieldWafEngineConfigItem, 0, len(s.WafEngineConfig))
+	for _, item := range s.WafEngineConfig {
+		// the order of list values does not matter
+		if _, ok := engineConfigLists[item.Name]; ok {
+			item.ValueEncoded = strings.Join(sortedStrings(strings.Fields(item.ValueEncoded)), " ")
+		}
+
+		engineConfig = append(engineConfig, item)
+	}
+
+	s.WafEngineConfig = engineConfig
+	sort.SliceStable(s.WafEngineConfig, func(i, j int) bool { return s.WafEngineConfig[i].Name < s.WafEngineConfig[j].Name })
+
+	return s
+}
+
+// merged returns the shield settings, with the engine config items missing from it taken from base.
+func (s Shield) merged(base Shield) Shield {
+	result := s.normalized()
+
+	names := make(map[string]bool, len(result.WafEngineConfig))
+	for _, item := range result.WafEngineConfig {
+		names[item.Name] = true
+	}
+
+	for _, item := range base.WafEngineConfig {
+		if !names[item.Name] {
+			result.WafEngineConfig = append(result.WafEngineConfig, item)
+		}
+	}
+
+	return result.normalized()
+}
+
+func normalizedWafRuleConfiguration(config api.PullzoneWafRuleConfiguration) api.PullzoneWafRuleConfiguration {
+	config.VariableTypes = normalizedVariableTypes(config.VariableTypes)
+	config.TransformationTypes = append(make([]int64, 0, len(config.TransformationTypes)), config.TransformationTypes...)
+
+	chainedRules := make([]api.PullzoneWafRuleChainedRule, 0, len(config.ChainedRules))
+	for _, rule := range config.ChainedRules {
+		rule.VariableTypes = normalizedVariableTypes(rule.VariableTypes)
+		chainedRules = append(chainedRules, rule)
+	}
+
+	config.ChainedRules = chainedRules
+	return config
+}
+
+func normalizedRatelimitRuleConfiguration(config api.PullzoneRatelimitRuleConfiguration) api.PullzoneRatelimitRuleConfiguration {
+	config.VariableTypes = normalizedVariableTypes(config.VariableTypes)
+	config.TransformationTypes = append(make([]int64, 0, len(config.TransformationTypes)), config.TransformationTypes...)
+
+	chainedRules := make([]api.PullzoneRatelimitRuleChainedRule, 0, len(config.ChainedRules))
+	for _, rule := range config.ChainedRules {
+		rule.VariableTypes = normalizedVariableTypes(rule.VariableTypes)
+		chainedRules = append(chainedRules, rule)
+	}
+
+	config.ChainedRules = chainedRules
+	return config
+}
+
+func normalizedVariableTypes(variableTypes map[string]string) map[string]string {
+	result := make(map[string]string, len(variableTypes))
+	for k, v := range variableTypes {
+		result[k] = v
+	}
+
+	return result
+}
+
+// normalizedEntries returns the entries in canonical form. Entries that cannot be parsed are only sorted.
+func normalizedEntries(listType uint8, entries []string) []string {
+	var values []string
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			values = append(values, entry)
+		}
+	}
+
+	if result, err := accesslist.Normalize(accesslist.Type(listType), values); err == nil {
+		return result
+	}
+
+	return sortedStrings(values)
+}
+
+func sortedStrings(values []string) []string {
+	result := append(make([]string, 0, len(values)), values...)
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,134 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldpolicy
+
+import (
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type testCase struct {
+		Content string
+		Error   string
+	}
+
+	testCases := []testCase{
+		{`{"version": 1}`, ""},
+		{`{"version": 1, "wafRules": [{"name": "a"}, {"name": "b"}]}`, ""},
+		{`{}`, "missing document version"},
+		{`{"version": 2}`, "unsupported document version 2"},
+		{`{"version": 1, "unknown": true}`, "unknown field"},
+		{`{"version": 1} {"version": 1}`, "unexpected content"},
+		{`{"version": 1, "wafRules": [{"name": "a"}, {"name": "a"}]}`, "wafRules[1]: duplicated name \"a\""},
+		{`{"version": 1, "rateLimitRules": [{"description": "a"}]}`, "rateLimitRules[0]: missing name"},
+		{`{"version": 1, "accessLists": [{"name": "a", "type": 0, "entries": ["192.0.2.300"]}]}`, "accessLists[0]: \"192.0.2.300\" is not a valid IP address"},
+		{`{"version": 1, "curatedAccessLists": [{"name": "Tor"}, {"name": "Tor"}]}`, "curatedAccessLists[1]: duplicated name \"Tor\""},
+	}
+
+	for _, tc := range testCases {
+		_, err := Parse(tc.Content)
+		if tc.Error == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tc.Content, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tc.Error) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.Content, tc.Error, err)
+		}
+	}
+}
+
+func TestParseNormalizesEntries(t *testing.T) {
+	doc, err := Parse(`{"version": 1, "accessLists": [{"name": "a", "type": 1, "entries": ["192.0.2.128/25", "192.0.2.0/25", "10.0.0.1"]}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"10.0.0.1/32", "192.0.2.0/24"}
+	if !reflect.DeepEqual(doc.AccessLists[0].Entries, expected) {
+		t.Errorf("expected %v, got %v", expected, doc.AccessLists[0].Entries)
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	doc := New(
+		api.PullzoneShield{
+			PlanType:                      1,
+			WafEnabled:                    true,
+			WafLogHeadersExcluded:         []string{"Cookie", "Authorization"},
+			WafAllowedHttpVersions:        []string{"HTTP/1.1", "HTTP/2"},
+			WafAllowedHttpMethods:         []string{"GET", "POST"},
+			WafAllowedRequestContentTypes: []string{"application/json"},
+			WafRuleSensitivityBlocking:    2,
+			AccessLists: []api.PullzoneShieldAccessList{
+				{Id: 10, Name: "Tor exit nodes", Action: 1, IsEnabled: true},
+				{Id: 11, Name: "VPN providers", Action: 1, IsEnabled: false},
+			},
+		},
+		[]api.PullzoneWafRule{{Id: 1, Name: "block-curl", RuleConfiguration: api.PullzoneWafRuleConfiguration{ActionType: 2, VariableTypes: map[string]string{"REQUEST_HEADERS": "User-Agent"}, OperatorType: 3, Value: "curl"}}},
+		[]api.PullzoneRatelimitRule{{Id: 2, Name: "login", RuleConfiguration: api.PullzoneRatelimitRuleConfiguration{ActionType: 2, RequestCount: 10, Timeframe: 60, BlockTime: 300}}},
+		[]api.PullzoneAccessList{{Id: 3, Name: "office", Type: 0, Action: 2, IsEnabled: true, Entries: []string{"192.0.2.2", "192.0.2.1", ""}}},
+	)
+
+	if len(doc.CuratedAccessLists) != 1 {
+		t.Errorf("expected only enabled curated access lists, got %v", doc.CuratedAccessLists)
+	}
+
+	content, err := doc.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := Parse(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !Equal(doc, parsed) {
+		t.Errorf("round trip changed the document:\n%s", content)
+	}
+
+	if changes := Diff(doc, parsed); len(changes) > 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	type testCase struct {
+		Expected bool
+		A        string
+		B        string
+	}
+
+	testCases := []testCase{
+		{true, `{"version": 1}`, `{"version":1,"wafRules":[],"accessLists":null}`},
+		{true, `{"version": 1, "wafRules": [{"name": "a"}, {"name": "b"}]}`, `{"version": 1, "wafRules": [{"name": "b"}, {"name": "a"}]}`},
+		{true, `{"version": 1, "shield": {"wafDisabledRules": ["b", "a"]}}`, `{"version": 1, "shield": {"wafDisabledRules": ["a", "b"]}}`},
+		{true, `{"version": 1, "accessLists": [{"name": "a", "type": 3, "entries": ["si", "DE"]}]}`, `{"version": 1, "accessLists": [{"name": "a", "type": 3, "entries": ["DE", "SI"]}]}`},
+		{true, `{"version": 1, "shield": {"wafEngineConfig": [{"name": "allowed_methods", "valueEncoded": "POST GET"}]}}`, `{"version": 1, "shield": {"wafEngineConfig": [{"name": "allowed_methods", "valueEncoded": "GET POST"}]}}`},
+		{false, `{"version": 1, "wafRules": [{"name": "a"}]}`, `{"version": 1, "wafRules": [{"name": "a", "description": "b"}]}`},
+		{false, `{"version": 1, "shield": {"wafEnabled": true}}`, `{"version": 1}`},
+	}
+
+	for _, tc := range testCases {
+		a, err := Parse(tc.A)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := Parse(tc.B)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result := Equal(a, b); result != tc.Expected {
+			t.Errorf("%s == %s: expected %v, got %v", tc.A, tc.B, tc.Expected, result)
+		}
+	}
+}
@@ -0,0 +1,471 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package shieldrule simulates how bunny.net Shield WAF and rate limit rules match a request.
+//
+// A rule matches when its first condition and every chained condition match. A condition matches when
+// any of the values selected by its variable matches the operator, after the rule transformations are applied.
+// Transformations are applied to chained conditions as well.
+//
+// SimulateRatelimit replays a request log, read by ParseLog, against a rate limit rule.
+package shieldrule
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	OperatorBeginsWith   int64 = 0
+	OperatorEndsWith     int64 = 1
+	OperatorContains     int64 = 2
+	OperatorContainsWord int64 = 3
+	OperatorStrMatch     int64 = 4
+	OperatorEq           int64 = 5
+	OperatorGe           int64 = 6
+	OperatorGt           int64 = 7
+	OperatorLe           int64 = 8
+	OperatorLt           int64 = 9
+	OperatorWithin       int64 = 12
+	OperatorRx           int64 = 14
+	OperatorStrEq        int64 = 15
+	OperatorDetectSqli   int64 = 17
+	OperatorDetectXss    int64 = 18
+)
+
+// Request is the synthetic request the rule is evaluated against.
+type Request struct {
+	Url                 string
+	Method              string
+	Protocol            string
+	Headers             map[string]string
+	Body                string
+	RemoteIp            string
+	Country             string
+	Geo                 map[string]string
+	ResponseStatus      int64
+	ResponseHeaders     map[string]string
+	ResponseBody        string
+	Fingerprint         string
+	VerifiedBotCategory string
+}
+
+// ConditionResult describes how a single condition was evaluated.
+type ConditionResult struct {
+	Variable      string
+	VariableValue string
+	Operator      int64
+	Value         string
+	Matched       bool
+	// MatchedValue is the transformed value that matched the condition.
+	MatchedValue string
+}
+
+// Result lists whether the rule matched and the result of each condition, in rule order.
+type Result struct {
+	Matched    bool
+	Conditions []ConditionResult
+}
+
+type condition struct {
+	variableTypes map[string]string
+	operator      int64
+	value         string
+}
+
+// Evaluate applies the rule configuration to the request.
+func Evaluate(rule api.PullzoneWafRuleConfiguration, request Request) (Result, error) {
+	result := Result{
+		Conditions: []ConditionResult{},
+	}
+
+	req, err := newEvaluationRequest(request)
+	if err != nil {
+		return result, err
+	}
+
+	conditions := make([]condition, 0, len(rule.ChainedRules)+1)
+	conditions = append(conditions, condition{rule.VariableTypes, rule.OperatorType, rule.Value})
+	for _, chained := range rule.ChainedRules {
+		conditions = append(conditions, condition{chained.VariableTypes, chained.OperatorType, chained.Value})
+	}
+
+	result.Matched = true
+	for i, c := range conditions {
+		conditionResult, err := evaluateCondition(c, rule.TransformationTypes, req)
+		if err != nil {
+			return result, fmt.Errorf("condition %d: %w", i, err)
+		}
+
+		result.Conditions = append(result.Conditions, conditionResult)
+		if !conditionResult.Matched {
+			result.Matched = false
+		}
+	}
+
+	return result, nil
+}
+
+type evaluationRequest struct {
+	Request
+	url             *url.URL
+	headers         http.Header
+	responseHeaders http.Header
+	cookies         []*http.Cookie
+	argsGet         url.Values
+	argsPost        url.Values
+}
+
+func newEvaluationRequest(request Request) (evaluationRequest, error) {
+	u, err := url.Parse(request.Url)
+	if err != nil {
+		return evaluationRequest{}, fmt.Errorf("invalid url: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return evaluationRequest{}, errors.New("invalid url: must be absolute, i.e. https://example.com/path")
+	}
+
+	if request.Method == "" {
+		request.Method = http.MethodGet
+	}
+
+	if request.Protocol == "" {
+		request.Protocol = "HTTP/1.1"
+	}
+
+	req := evaluationRequest{
+		Request:         request,
+		url:             u,
+		headers:         http.Header{},
+		responseHeaders: http.Header{},
+		argsGet:         u.Query(),
+		argsPost:        url.Values{},
+	}
+
+	for k, v := range request.Headers {
+		req.headers.Set(k, v)
+	}
+
+	if req.headers.Get("Host") == "" {
+		req.headers.Set("Host", u.Host)
+	}
+
+	for k, v := range request.ResponseHeaders {
+		req.responseHeaders.Set(k, v)
+	}
+
+	req.cookies = (&http.Request{Header: http.Header{"Cookie": req.headers.Values("Cookie")}}).Cookies()
+
+	if mediaType, _, _ := mime.ParseMediaType(req.headers.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" {
+		req.argsPost, err = url.ParseQuery(request.Body)
+		if err != nil {
+			return evaluationRequest{}, fmt.Errorf("invalid body: %w", err)
+		}
+	}
+
+	return req, nil
+}
+
+// evaluateCondition matches when any of the variables matches.
+func evaluateCondition(c condition, transformations []int64, req evaluationRequest) (ConditionResult, error) {
+	result := ConditionResult{
+		Operator: c.operator,
+		Value:    c.value,
+	}
+
+	if len(c.variableTypes) == 0 {
+		return result, errors.New("variable is required")
+	}
+
+	for _, variable := range sortedKeys(c.variableTypes) {
+		if result.Variable == "" {
+			result.Variable = variable
+			result.VariableValue = c.variableTypes[variable]
+		}
+
+		values, err := variableValues(variable, c.variableTypes[variable], req)
+		if err != nil {
+			return result, err
+		}
+
+		for _, value := range values {
+			transformed, err := Transform(transformations, value)
+			if err != nil {
+				return result, err
+			}
+
+			matched, err := MatchOperator(c.operator, c.value, transformed)
+			if err != nil {
+				return result, err
+			}
+
+			if matched {
+				result.Variable = variable
+				result.VariableValue = c.variableTypes[variable]
+				result.Matched = true
+				result.MatchedValue = transformed
+
+				return result, nil
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// variableValues returns the values selected by a variable, optionally filtered by the key in variableValue.
+func variableValues(variable string, variableValue string, req evaluationRequest) ([]string, error) {
+	switch variable {
+	case "REQUEST_URI":
+		return []string{req.url.RequestURI()}, nil
+	case "REQUEST_URI_RAW":
+		u := *req.url
+		u.Fragment = ""
+		return []string{u.String()}, nil
+	case "ARGS":
+		values := collectionValues(req.argsGet, variableValue)
+		return append(values, collectionValues(req.argsPost, variableValue)...), nil
+	case "ARGS_COMBINED_SIZE":
+		size := 0
+		for _, args := range []url.Values{req.argsGet, req.argsPost} {
+			for k, values := range args {
+				for _, v := range values {
+					size += len(k) + len(v)
+				}
+			}
+		}
+		return []string{strconv.Itoa(size)}, nil
+	case "ARGS_GET":
+		return collectionValues(req.argsGet, variableValue), nil
+	case "ARGS_GET_NAMES":
+		return collectionNames(req.argsGet, variableValue), nil
+	case "ARGS_POST":
+		return collectionValues(req.argsPost, variableValue), nil
+	case "ARGS_POST_NAMES":
+		return collectionNames(req.argsPost, variableValue), nil
+	case "GEO":
+		geo := map[string]string{}
+		for k, v := range req.Geo {
+			geo[strings.ToUpper(k)] = v
+		}
+		if _, ok := geo["COUNTRY_CODE"]; !ok && req.Country != "" {
+			geo["COUNTRY_CODE"] = req.Country
+		}
+		if variableValue == "" {
+			return sortedMapValues(geo), nil
+		}
+		if v, ok := geo[strings.ToUpper(variableValue)]; ok {
+			return []string{v}, nil
+		}
+		return nil, nil
+	case "REMOTE_ADDR":
+		return []string{req.RemoteIp}, nil
+	case "QUERY_STRING":
+		return []string{req.url.RawQuery}, nil
+	case "REQUEST_BASENAME":
+		return []string{path.Base(req.url.Path)}, nil
+	case "REQUEST_BODY":
+		return []string{req.Body}, nil
+	case "REQUEST_COOKIES_NAMES":
+		values := []string{}
+		for _, cookie := range req.cookies {
+			if variableValue == "" || strings.EqualFold(variableValue, cookie.Name) {
+				values = append(values, cookie.Name)
+			}
+		}
+		return values, nil
+	case "REQUEST_COOKIES":
+		values := []string{}
+		for _, cookie := range req.cookies {
+			if variableValue == "" || strings.EqualFold(variableValue, cookie.Name) {
+				values = append(values, cookie.Value)
+			}
+		}
+		return values, nil
+	case "REQUEST_FILENAME":
+		return []string{req.url.Path}, nil
+	case "REQUEST_HEADERS_NAMES":
+		return headerNames(req.headers, variableValue), nil
+	case "REQUEST_HEADERS":
+		return headerValues(req.headers, variableValue), nil
+	case "REQUEST_LINE":
+		return []string{fmt.Sprintf("%s %s %s", req.Method, req.url.RequestURI(), req.Protocol)}, nil
+	case "REQUEST_METHOD":
+		return []string{req.Method}, nil
+	case "REQUEST_PROTOCOL":
+		return []string{req.Protocol}, nil
+	case "RESPONSE_BODY":
+		return []string{req.ResponseBody}, nil
+	case "RESPONSE_HEADERS":
+		return headerValues(req.responseHeaders, variableValue), nil
+	case "RESPONSE_STATUS":
+		if req.ResponseStatus == 0 {
+			return nil, nil
+		}
+		return []string{strconv.FormatInt(req.ResponseStatus, 10)}, nil
+	case "FINGERPRINT":
+		return []string{req.Fingerprint}, nil
+	case "VERIFIED_BOT_CATEGORY":
+		return []string{req.VerifiedBotCategory}, nil
+	}
+
+	return nil, fmt.Errorf("variable %s cannot be simulated", variable)
+}
+
+// MatchOperator checks a value against the operator and its argument.
+func MatchOperator(operator int64, argument string, value string) (bool, error) {
+	switch operator {
+	case OperatorBeginsWith:
+		return strings.HasPrefix(value, argument), nil
+	case OperatorEndsWith:
+		return strings.HasSuffix(value, argument), nil
+	case OperatorContains, OperatorStrMatch:
+		return strings.Contains(value, argument), nil
+	case OperatorContainsWord:
+		return containsWord(value, argument), nil
+	case OperatorEq, OperatorGe, OperatorGt, OperatorLe, OperatorLt:
+		expected, err := strconv.ParseInt(strings.TrimSpace(argument), 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid value \"%s\": numeric operators require an integer", argument)
+		}
+
+		// non-numeric values are compared as 0
+		actual, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+
+		switch operator {
+		case OperatorEq:
+			return actual == expected, nil
+		case OperatorGe:
+			return actual >= expected, nil
+		case OperatorGt:
+			return actual > expected, nil
+		case OperatorLe:
+			return actual <= expected, nil
+		default:
+			return actual < expected, nil
+		}
+	case OperatorWithin:
+		return value != "" && strings.Contains(argument, value), nil
+	case OperatorRx:
+		re, err := regexp.Compile(argument)
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression \"%s\": %w", argument, err)
+		}
+
+		return re.MatchString(value), nil
+	case OperatorStrEq:
+		return value == argument, nil
+	case OperatorDetectSqli, OperatorDetectXss:
+		return false, fmt.Errorf("operator %d cannot be simulated", operator)
+	}
+
+	return false, fmt.Errorf("invalid operator %d", operator)
+}
+
+func containsWord(value string, word string) bool {
+	if word == "" {
+		return true
+	}
+
+	isWordChar := func(s string, i int) bool {
+		if i < 0 || i >= len(s) {
+			return false
+		}
+
+		r := rune(s[i])
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+	}
+
+	for offset := 0; offset <= len(value)-len(word); {
+		i := strings.Index(value[offset:], word)
+		if i == -1 {
+			return false
+		}
+
+		start := offset + i
+		end := start + len(word)
+		if !isWordChar(value, start-1) && !isWordChar(value, end) {
+			return true
+		}
+
+		offset = start + 1
+	}
+
+	return false
+}
+
+func collectionValues(values url.Values, name string) []string {
+	result := []string{}
+	for _, k := range sortedKeys(values) {
+		if name == "" || k == name {
+			result = append(result, values[k]...)
+		}
+	}
+
+	return result
+}
+
+func collectionNames(values url.Values, name string) []string {
+	result := []string{}
+	for _, k := range sortedKeys(values) {
+		if name == "" || k == name {
+			result = append(result, k)
+		}
+	}
+
+	return result
+}
+
+func headerNames(headers http.Header, name string) []string {
+	result := []string{}
+	for _, k := range sortedKeys(headers) {
+		if name == "" || strings.EqualFold(k, name) {
+			result = append(result, k)
+		}
+	}
+
+	return result
+}
+
+func headerValues(headers http.Header, name string) []string {
+	if name != "" {
+		return headers.Values(name)
+	}
+
+	result := []string{}
+	for _, k := range sortedKeys(headers) {
+		result = append(result, headers[k]...)
+	}
+
+	return result
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedMapValues(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		result = append(result, m[k])
+	}
+
+	return result
+}
@@ -0,0 +1,200 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"testing"
+)
+
+func TestMatchOperator(t *testing.T) {
+	type testCase struct {
+		Operator int64
+		Argument string
+		Value    string
+		Expected bool
+	}
+
+	dataProvider := []testCase{
+		{OperatorBeginsWith, "/admin", "/admin/users", true},
+		{OperatorBeginsWith, "/admin", "/ADMIN/users", false},
+		{OperatorEndsWith, ".php", "/index.php", true},
+		{OperatorContains, "select", "union select 1", true},
+		{OperatorStrMatch, "select", "union select 1", true},
+		{OperatorContainsWord, "select", "union select 1", true},
+		{OperatorContainsWord, "select", "preselected", false},
+		{OperatorContainsWord, "select", "selected select", true},
+		{OperatorEq, "200", "200", true},
+		{OperatorGe, "10", "10", true},
+		{OperatorGt, "10", "10", false},
+		{OperatorLe, "10", "9", true},
+		{OperatorLt, "10", "abc", true},
+		{OperatorWithin, "GET HEAD", "GET", true},
+		{OperatorWithin, "GET HEAD", "POST", false},
+		{OperatorWithin, "GET HEAD", "", false},
+		{OperatorRx, "^/api/v[0-9]+/", "/api/v2/users", true},
+		{OperatorRx, "(?i)^/API/", "/api/v2/users", true},
+		{OperatorRx, "^/API/", "/api/v2/users", false},
+		{OperatorStrEq, "POST", "POST", true},
+		{OperatorStrEq, "POST", "post", false},
+	}
+
+	for _, tc := range dataProvider {
+		result, err := MatchOperator(tc.Operator, tc.Argument, tc.Value)
+		if err != nil {
+			t.Errorf("unexpected error for %d %q: %s", tc.Operator, tc.Argument, err)
+			continue
+		}
+
+		if result != tc.Expected {
+			t.Errorf("expected operator %d %q on %q to be %t, got %t", tc.Operator, tc.Argument, tc.Value, tc.Expected, result)
+		}
+	}
+
+	errorProvider := []testCase{
+		{OperatorRx, "^(", "x", false},
+		{OperatorGt, "ten", "11", false},
+		{OperatorDetectSqli, "", "1' OR 1=1", false},
+		{99, "", "x", false},
+	}
+
+	for _, tc := range errorProvider {
+		if _, err := MatchOperator(tc.Operator, tc.Argument, tc.Value); err == nil {
+			t.Errorf("expected operator %d %q to fail", tc.Operator, tc.Argument)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rule := api.PullzoneWafRuleConfiguration{
+		VariableTypes:       map[string]string{"REQUEST_URI": ""},
+		OperatorType:        OperatorBeginsWith,
+		Value:               "/admin",
+		TransformationTypes: []int64{TransformationUrlDecode, TransformationLowercase},
+		ChainedRules: []api.PullzoneWafRuleChainedRule{
+			{VariableTypes: map[string]string{"REQUEST_HEADERS": "X-Debug"}, OperatorType: OperatorStrEq, Value: "1"},
+			{VariableTypes: map[string]string{"REQUEST_METHOD": ""}, OperatorType: OperatorWithin, Value: "get post"},
+		},
+	}
+
+	type testCase struct {
+		Request  Request
+		Expected bool
+	}
+
+	dataProvider := []testCase{
+		{Request{Url: "https://example.com/%41dmin/users", Headers: map[string]string{"x-debug": "1"}}, true},
+		{Request{Url: "https://example.com/admin", Headers: map[string]string{"X-Debug": "1"}, Method: "DELETE"}, false},
+		{Request{Url: "https://example.com/admin"}, false},
+		{Request{Url: "https://example.com/public", Headers: map[string]string{"X-Debug": "1"}}, false},
+	}
+
+	for i, tc := range dataProvider {
+		result, err := Evaluate(rule, tc.Request)
+		if err != nil {
+			t.Errorf("unexpected error for request %d: %s", i, err)
+			continue
+		}
+
+		if result.Matched != tc.Expected {
+			t.Errorf("expected request %d to match: %t, got %t (%+v)", i, tc.Expected, result.Matched, result.Conditions)
+		}
+
+		if len(result.Conditions) != 3 {
+			t.Errorf("expected 3 condition results for request %d, got %d", i, len(result.Conditions))
+		}
+	}
+
+	result, _ := Evaluate(rule, dataProvider[0].Request)
+	if result.Conditions[0].MatchedValue != "/admin/users" {
+		t.Errorf("expected transformed value /admin/users, got %s", result.Conditions[0].MatchedValue)
+	}
+}
+
+func TestEvaluateVariables(t *testing.T) {
+	request := Request{
+		Url:      "https://example.com/files/report.pdf?id=5&debug=true",
+		Method:   "POST",
+		Headers:  map[string]string{"Content-Type": "application/x-www-form-urlencoded", "Cookie": "session=abc; theme=dark"},
+		Body:     "user=admin&password=secret",
+		RemoteIp: "192.0.2.1",
+		Country:  "DE",
+	}
+
+	type testCase struct {
+		Variable      string
+		VariableValue string
+		Operator      int64
+		Value         string
+		Expected      bool
+	}
+
+	dataProvider := []testCase{
+		{"REQUEST_URI", "", OperatorStrEq, "/files/report.pdf?id=5&debug=true", true},
+		{"REQUEST_URI_RAW", "", OperatorBeginsWith, "https://example.com/files", true},
+		{"REQUEST_FILENAME", "", OperatorStrEq, "/files/report.pdf", true},
+		{"REQUEST_BASENAME", "", OperatorStrEq, "report.pdf", true},
+		{"QUERY_STRING", "", OperatorContains, "debug=true", true},
+		{"ARGS", "user", OperatorStrEq, "admin", true},
+		{"ARGS", "", OperatorStrEq, "5", true},
+		{"ARGS_GET", "user", OperatorStrEq, "admin", false},
+		{"ARGS_POST", "user", OperatorStrEq, "admin", true},
+		{"ARGS_GET_NAMES", "", OperatorStrEq, "debug", true},
+		{"ARGS_POST_NAMES", "", OperatorStrEq, "password", true},
+		{"ARGS_COMBINED_SIZE", "", OperatorEq, "35", true},
+		{"REQUEST_COOKIES", "session", OperatorStrEq, "abc", true},
+		{"REQUEST_COOKIES_NAMES", "", OperatorStrEq, "theme", true},
+		{"REQUEST_HEADERS", "Host", OperatorStrEq, "example.com", true},
+		{"REQUEST_HEADERS_NAMES", "", OperatorStrEq, "Cookie", true},
+		{"REQUEST_LINE", "", OperatorBeginsWith, "POST /files/report.pdf?id=5", true},
+		{"REQUEST_PROTOCOL", "", OperatorStrEq, "HTTP/1.1", true},
+		{"REMOTE_ADDR", "", OperatorStrEq, "192.0.2.1", true},
+		{"GEO", "COUNTRY_CODE", OperatorWithin, "DE AT CH", true},
+		{"RESPONSE_STATUS", "", OperatorEq, "0", false},
+	}
+
+	for _, tc := range dataProvider {
+		rule := api.PullzoneWafRuleConfiguration{
+			VariableTypes: map[string]string{tc.Variable: tc.VariableValue},
+			OperatorType:  tc.Operator,
+			Value:         tc.Value,
+		}
+
+		result, err := Evaluate(rule, request)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tc.Variable, err)
+			continue
+		}
+
+		if result.Matched != tc.Expected {
+			t.Errorf("expected %s:%s %d %q to match: %t, got %t", tc.Variable, tc.VariableValue, tc.Operator, tc.Value, tc.Expected, result.Matched)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	rule := api.PullzoneWafRuleConfiguration{
+		VariableTypes: map[string]string{"REQUEST_URI": ""},
+		OperatorType:  OperatorRx,
+		Value:         "^(",
+	}
+
+	if _, err := Evaluate(rule, Request{Url: "https://example.com/"}); err == nil {
+		t.Error("expected invalid regex to fail")
+	}
+
+	rule = api.PullzoneWafRuleConfiguration{
+		VariableTypes: map[string]string{"FILES_NAMES": ""},
+		OperatorType:  OperatorContains,
+		Value:         ".php",
+	}
+
+	if _, err := Evaluate(rule, Request{Url: "https://example.com/"}); err == nil {
+		t.Error("expected FILES_NAMES to fail")
+	}
+
+	if _, err := Evaluate(rule, Request{Url: "/relative"}); err == nil {
+		t.Error("expected relative url to fail")
+	}
+}
@@ -0,0 +1,562 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names maps the enum values of a rule to the names used in expressions.
+type Names struct {
+	Variables       map[uint8]string
+	Operators       map[int64]string
+	Transformations map[int64]string
+}
+
+// ExpressionError is a syntax or semantic error, with the position where it was found.
+type ExpressionError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+var expressionOperatorSymbols = map[int64]string{
+	OperatorStrEq: "==",
+	OperatorGe:    ">=",
+	OperatorGt:    ">",
+	OperatorLe:    "<=",
+	OperatorLt:    "<",
+	OperatorRx:    "matches",
+}
+
+var expressionOperatorAliases = map[string]int64{
+	"==":         OperatorStrEq,
+	">=":         OperatorGe,
+	">":          OperatorGt,
+	"<=":         OperatorLe,
+	"<":          OperatorLt,
+	"=~":         OperatorRx,
+	"matches":    OperatorRx,
+	"in":         OperatorWithin,
+	"startswith": OperatorBeginsWith,
+}
+
+var expressionTransformationAliases = map[string]int64{
+	"lower": TransformationLowercase,
+}
+
+// ParseExpression converts an expression into a rule configuration, i.e.:
+//
+//	lowercase(request_headers["User-Agent"]) contains "curl" and lowercase(remote_addr) in ["192.0.2.1", "192.0.2.2"]
+//
+// Conditions are joined by "and". Transformations apply to the whole rule, so every condition must
+// declare the same ones.
+func ParseExpression(expression string, names Names) (api.PullzoneWafRuleConfiguration, error) {
+	rule := api.PullzoneWafRuleConfiguration{}
+
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return rule, err
+	}
+
+	p := &expressionParser{tokens: tokens, names: names}
+
+	var transformations []int64
+	var transformationsToken expressionToken
+
+	for i := 0; ; i++ {
+		conditionToken := p.peek()
+		condition, conditionTransformations, err := p.parseCondition()
+		if err != nil {
+			return rule, err
+		}
+
+		if i == 0 {
+			transformations = conditionTransformations
+			transformationsToken = conditionToken
+		} else if !slices.Equal(transformations, conditionTransformations) {
+			return rule, conditionToken.errorf("transformations apply to the whole rule, so every condition must declare the same ones as in line %d, column %d", transformationsToken.line, transformationsToken.column)
+		}
+
+		if i == 0 {
+			rule.VariableTypes = condition.VariableTypes
+			rule.OperatorType = condition.OperatorType
+			rule.Value = condition.Value
+		} else {
+			rule.ChainedRules = append(rule.ChainedRules, condition)
+		}
+
+		next := p.next()
+		switch {
+		case next.kind == tokenEOF:
+			rule.TransformationTypes = transformations
+			return rule, nil
+		case next.isKeyword("and") || next.value == "&&":
+			continue
+		case next.isKeyword("or") || next.value == "||":
+			return rule, next.errorf("\"or\" is not supported, use a separate rule instead")
+		default:
+			return rule, next.errorf("expected \"and\" or end of expression, got %s", next)
+		}
+	}
+}
+
+// FormatExpression converts a rule configuration into an expression.
+func FormatExpression(rule api.PullzoneWafRuleConfiguration, names Names) (string, error) {
+	conditions := make([]api.PullzoneWafRuleChainedRule, 0, len(rule.ChainedRules)+1)
+	conditions = append(conditions, api.PullzoneWafRuleChainedRule{
+		VariableTypes: rule.VariableTypes,
+		OperatorType:  rule.OperatorType,
+		Value:         rule.Value,
+	})
+	conditions = append(conditions, rule.ChainedRules...)
+
+	parts := make([]string, 0, len(conditions))
+	for i, condition := range conditions {
+		if len(condition.VariableTypes) != 1 {
+			return "", fmt.Errorf("condition %d: expressions support a single variable per condition", i)
+		}
+
+		var operand string
+		for variable, variableValue := range condition.VariableTypes {
+			operand = strings.ToLower(variable)
+			if variableValue != "" {
+				operand += "[" + quoteExpressionString(variableValue) + "]"
+			}
+		}
+
+		for _, t := range rule.TransformationTypes {
+			name, ok := names.Transformations[t]
+			if !ok {
+				return "", fmt.Errorf("condition %d: invalid transformation %d", i, t)
+			}
+
+			operand = strings.ToLower(name) + "(" + operand + ")"
+		}
+
+		operator, ok := expressionOperatorSymbols[condition.OperatorType]
+		if !ok {
+			name, ok := names.Operators[condition.OperatorType]
+			if !ok {
+				return "", fmt.Errorf("condition %d: invalid operator %d", i, condition.OperatorType)
+			}
+
+			operator = strings.ToLower(name)
+		}
+
+		var value string
+		switch condition.OperatorType {
+		case OperatorEq, OperatorGe, OperatorGt, OperatorLe, OperatorLt:
+			if _, err := strconv.ParseInt(condition.Value, 10, 64); err == nil {
+				value = condition.Value
+			} else {
+				value = quoteExpressionString(condition.Value)
+			}
+		case OperatorWithin:
+			items := strings.Fields(condition.Value)
+			if len(items) > 0 && strings.Join(items, " ") == condition.Value {
+				operator = "in"
+				for j, item := range items {
+					items[j] = quoteExpressionString(item)
+				}
+				value = "[" + strings.Join(items, ", ") + "]"
+			} else {
+				value = quoteExpressionString(condition.Value)
+			}
+		case OperatorDetectSqli, OperatorDetectXss:
+			if condition.Value != "" {
+				value = quoteExpressionString(condition.Value)
+			}
+		default:
+			value = quoteExpressionString(condition.Value)
+		}
+
+		part := operand + " " + operator
+		if value != "" {
+			part += " " + value
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, " and "), nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type expressionToken struct {
+	kind   tokenKind
+	value  string
+	line   int
+	column int
+}
+
+func (t expressionToken) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return quoteExpressionString(t.value)
+	}
+
+	return "\"" + t.value + "\""
+}
+
+func (t expressionToken) isKeyword(keyword string) bool {
+	return t.kind == tokenIdentifier && strings.EqualFold(t.value, keyword)
+}
+
+func (t expressionToken) errorf(format string, args ...any) *ExpressionError {
+	return &ExpressionError{Line: t.line, Column: t.column, Message: fmt.Sprintf(format, args...)}
+}
+
+func tokenizeExpression(expression string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	line, column := 1, 1
+
+	for i := 0; i < len(expression); {
+		r, size := utf8.DecodeRuneInString(expression[i:])
+		token := expressionToken{line: line, column: column}
+
+		switch {
+		case r == '\n':
+			line++
+			column = 1
+			i += size
+			continue
+		case unicode.IsSpace(r):
+			column++
+			i += size
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			j := i
+			for j < len(expression) {
+				r, size := utf8.DecodeRuneInString(expression[j:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				j += size
+			}
+			token.kind = tokenIdentifier
+			token.value = expression[i:j]
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(expression) && unicode.IsDigit(rune(expression[i+1]))):
+			j := i + 1
+			for j < len(expression) && unicode.IsDigit(rune(expression[j])) {
+				j++
+			}
+			token.kind = tokenNumber
+			token.value = expression[i:j]
+		case r == '"':
+			value, end, err := unquoteExpressionString(expression, i)
+			if err != nil {
+				return nil, token.errorf("%s", err.Error())
+			}
+			if strings.Contains(expression[i:end], "\n") {
+				return nil, token.errorf("strings cannot span multiple lines")
+			}
+			tokens = append(tokens, expressionToken{kind: tokenString, value: value, line: line, column: column})
+			column += utf8.RuneCountInString(expression[i:end])
+			i = end
+			continue
+		default:
+			symbol := ""
+			for _, s := range []string{"==", ">=", "<=", "=~", "&&", "||", ">", "<", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(expression[i:], s) {
+					symbol = s
+					break
+				}
+			}
+
+			if symbol == "" {
+				return nil, token.errorf("unexpected character %q", r)
+			}
+
+			token.kind = tokenSymbol
+			token.value = symbol
+		}
+
+		tokens = append(tokens, token)
+		column += utf8.RuneCountInString(token.value)
+		i += len(token.value)
+	}
+
+	tokens = append(tokens, expressionToken{kind: tokenEOF, line: line, column: column})
+
+	return tokens, nil
+}
+
+// quoteExpressionString is the inverse of unquoteExpressionString.
+func quoteExpressionString(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\r", "\\r", "\t", "\\t")
+	return "\"" + replacer.Replace(value) + "\""
+}
+
+// unquoteExpressionString reads the string starting at expression[start], returning its value and end offset.
+func unquoteExpressionString(expression string, start int) (string, int, error) {
+	var sb strings.Builder
+
+	for i := start + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(expression) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+
+			i++
+			switch expression[i] {
+			case '"', '\\':
+				sb.WriteByte(expression[i])
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				return "", 0, fmt.Errorf("invalid escape sequence \"\\%c\"", expression[i])
+			}
+		default:
+			sb.WriteByte(expression[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+type expressionParser struct {
+	tokens []expressionToken
+	pos    int
+	names  Names
+}
+
+func (p *expressionParser) peek() expressionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *expressionParser) next() expressionToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+
+	return token
+}
+
+func (p *expressionParser) expect(symbol string) error {
+	token := p.next()
+	if token.kind != tokenSymbol || token.value != symbol {
+		return token.errorf("expected \"%s\", got %s", symbol, token)
+	}
+
+	return nil
+}
+
+// parseCondition returns the condition and its transformations, in the order they are applied.
+func (p *expressionParser) parseCondition() (api.PullzoneWafRuleChainedRule, []int64, error) {
+	condition := api.PullzoneWafRuleChainedRule{}
+
+	variable, variableValue, transformations, err := p.parseOperand()
+	if err != nil {
+		return condition, nil, err
+	}
+
+	condition.VariableTypes = map[string]string{variable: variableValue}
+
+	operatorToken := p.next()
+	if condition.OperatorType, err = p.lookupOperator(operatorToken); err != nil {
+		return condition, nil, err
+	}
+
+	valueToken := p.peek()
+
+	switch condition.OperatorType {
+	case OperatorDetectSqli, OperatorDetectXss:
+		if valueToken.kind == tokenString {
+			condition.Value = p.next().value
+		}
+	case OperatorWithin:
+		if valueToken.kind == tokenSymbol && valueToken.value == "[" {
+			items, err := p.parseList()
+			if err != nil {
+				return condition, nil, err
+			}
+			condition.Value = strings.Join(items, " ")
+		} else if valueToken.kind == tokenString {
+			condition.Value = p.next().value
+		} else {
+			return condition, nil, valueToken.errorf("expected a list or a string, got %s", valueToken)
+		}
+	case OperatorEq, OperatorGe, OperatorGt, OperatorLe, OperatorLt:
+		p.next()
+		if valueToken.kind == tokenString {
+			if _, err := strconv.ParseInt(strings.TrimSpace(valueToken.value), 10, 64); err != nil {
+				return condition, nil, valueToken.errorf("operator %s requires an integer, got %s", operatorToken, valueToken)
+			}
+		} else if valueToken.kind != tokenNumber {
+			return condition, nil, valueToken.errorf("operator %s requires an integer, got %s", operatorToken, valueToken)
+		}
+		condition.Value = valueToken.value
+	default:
+		p.next()
+		if valueToken.kind != tokenString {
+			return condition, nil, valueToken.errorf("expected a string, got %s", valueToken)
+		}
+		condition.Value = valueToken.value
+	}
+
+	return condition, transformations, nil
+}
+
+func (p *expressionParser) parseOperand() (string, string, []int64, error) {
+	token := p.next()
+	if token.kind != tokenIdentifier {
+		return "", "", nil, token.errorf("expected a variable or a transformation, got %s", token)
+	}
+
+	if next := p.peek(); next.kind == tokenSymbol && next.value == "(" {
+		transformation, err := p.lookupTransformation(token)
+		if err != nil {
+			return "", "", nil, err
+		}
+
+		p.next()
+		variable, variableValue, transformations, err := p.parseOperand()
+		if err != nil {
+			return "", "", nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return "", "", nil, err
+		}
+
+		if transformations == nil {
+			transformations = []int64{}
+		}
+
+		return variable, variableValue, append(transformations, transformation), nil
+	}
+
+	variable, err := p.lookupVariable(token)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	var variableValue string
+	if next := p.peek(); next.kind == tokenSymbol && next.value == "[" {
+		p.next()
+		valueToken := p.next()
+		if valueToken.kind != tokenString {
+			return "", "", nil, valueToken.errorf("expected a string, got %s", valueToken)
+		}
+
+		if err := p.expect("]"); err != nil {
+			return "", "", nil, err
+		}
+
+		variableValue = valueToken.value
+	}
+
+	return variable, variableValue, nil, nil
+}
+
+func (p *expressionParser) parseList() ([]string, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+
+	items := []string{}
+	for {
+		token := p.next()
+		if token.kind == tokenSymbol && token.value == "]" && len(items) > 0 {
+			return items, nil
+		}
+
+		if token.kind != tokenString && token.kind != tokenNumber {
+			return nil, token.errorf("expected a string, got %s", token)
+		}
+
+		if token.value == "" || strings.ContainsFunc(token.value, unicode.IsSpace) {
+			return nil, token.errorf("list items cannot be empty or contain whitespace")
+		}
+
+		items = append(items, token.value)
+
+		separator := p.next()
+		if separator.kind == tokenSymbol && separator.value == "]" {
+			return items, nil
+		}
+
+		if separator.kind != tokenSymbol || separator.value != "," {
+			return nil, separator.errorf("expected \",\" or \"]\", got %s", separator)
+		}
+	}
+}
+
+func (p *expressionParser) lookupVariable(token expressionToken) (string, error) {
+	name := normalizeExpressionName(token.value)
+	for _, variable := range p.names.Variables {
+		if normalizeExpressionName(variable) == name {
+			return variable, nil
+		}
+	}
+
+	return "", token.errorf("unknown variable %s", token)
+}
+
+func (p *expressionParser) lookupOperator(token expressionToken) (int64, error) {
+	if token.kind == tokenSymbol || token.kind == tokenIdentifier {
+		name := normalizeExpressionName(token.value)
+		if operator, ok := expressionOperatorAliases[name]; ok {
+			return operator, nil
+		}
+
+		for operator, operatorName := range p.names.Operators {
+			if normalizeExpressionName(operatorName) == name {
+				return operator, nil
+			}
+		}
+	}
+
+	return 0, token.errorf("expected an operator, got %s", token)
+}
+
+func (p *expressionParser) lookupTransformation(token expressionToken) (int64, error) {
+	name := normalizeExpressionName(token.value)
+	if transformation, ok := expressionTransformationAliases[name]; ok {
+		return transformation, nil
+	}
+
+	for transformation, transformationName := range p.names.Transformations {
+		if normalizeExpressionName(transformationName) == name {
+			return transformation, nil
+		}
+	}
+
+	return 0, token.errorf("unknown transformation %s", token)
+}
+
+// normalizeExpressionName makes names case-insensitive and ignores underscores, so "begins_with" matches "BEGINSWITH".
+func normalizeExpressionName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,179 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"errors"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"reflect"
+	"testing"
+)
+
+var testNames = Names{
+	Variables: map[uint8]string{
+		0:  "REQUEST_URI",
+		1:  "REQUEST_URI_RAW",
+		2:  "ARGS",
+		10: "REMOTE_ADDR",
+		18: "REQUEST_HEADERS",
+		20: "REQUEST_METHOD",
+		24: "RESPONSE_STATUS",
+	},
+	Operators: map[int64]string{
+		0:  "BEGINSWITH",
+		2:  "CONTAINS",
+		5:  "EQ",
+		6:  "GE",
+		12: "WITHIN",
+		14: "RX",
+		15: "STREQ",
+		17: "DETECTSQLI",
+	},
+	Transformations: map[int64]string{
+		8:  "LOWERCASE",
+		19: "URLDECODE",
+	},
+}
+
+func TestParseExpression(t *testing.T) {
+	type testCase struct {
+		Expression string
+		Expected   api.PullzoneWafRuleConfiguration
+	}
+
+	dataProvider := []testCase{
+		{
+			`request_uri begins_with "/admin"`,
+			api.PullzoneWafRuleConfiguration{
+				VariableTypes: map[string]string{"REQUEST_URI": ""},
+				OperatorType:  OperatorBeginsWith,
+				Value:         "/admin",
+			},
+		},
+		{
+			`lower(request_headers["user-agent"]) contains "curl" and lower(remote_addr) in ["1.2.3.4", "5.6.7.8"]`,
+			api.PullzoneWafRuleConfiguration{
+				VariableTypes:       map[string]string{"REQUEST_HEADERS": "user-agent"},
+				OperatorType:        OperatorContains,
+				Value:               "curl",
+				TransformationTypes: []int64{TransformationLowercase},
+				ChainedRules: []api.PullzoneWafRuleChainedRule{
+					{VariableTypes: map[string]string{"REMOTE_ADDR": ""}, OperatorType: OperatorWithin, Value: "1.2.3.4 5.6.7.8"},
+				},
+			},
+		},
+		{
+			"LOWERCASE(URLDECODE(ARGS[\"q\"])) DETECTSQLI\n&& lowercase(urldecode(request_uri)) =~ \"^/api/\"\n&& lowercase(urldecode(response_status)) >= 400",
+			api.PullzoneWafRuleConfiguration{
+				VariableTypes:       map[string]string{"ARGS": "q"},
+				OperatorType:        OperatorDetectSqli,
+				TransformationTypes: []int64{TransformationUrlDecode, TransformationLowercase},
+				ChainedRules: []api.PullzoneWafRuleChainedRule{
+					{VariableTypes: map[string]string{"REQUEST_URI": ""}, OperatorType: OperatorRx, Value: "^/api/"},
+					{VariableTypes: map[string]string{"RESPONSE_STATUS": ""}, OperatorType: OperatorGe, Value: "400"},
+				},
+			},
+		},
+		{
+			`request_method == "POST" and request_uri_raw contains "say \"hi\""`,
+			api.PullzoneWafRuleConfiguration{
+				VariableTypes: map[string]string{"REQUEST_METHOD": ""},
+				OperatorType:  OperatorStrEq,
+				Value:         "POST",
+				ChainedRules: []api.PullzoneWafRuleChainedRule{
+					{VariableTypes: map[string]string{"REQUEST_URI_RAW": ""}, OperatorType: OperatorContains, Value: "say \"hi\""},
+				},
+			},
+		},
+	}
+
+	for _, tc := range dataProvider {
+		result, err := ParseExpression(tc.Expression, testNames)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tc.Expression, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, tc.Expected) {
+			t.Errorf("unexpected result for %s:\nexpected %+v\ngot      %+v", tc.Expression, tc.Expected, result)
+		}
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	type testCase struct {
+		Expression string
+		Line       int
+		Column     int
+	}
+
+	dataProvider := []testCase{
+		{`request_uri`, 1, 12},
+		{`request_url contains "x"`, 1, 1},
+		{`request_uri contains x`, 1, 22},
+		{`request_uri contains "x`, 1, 22},
+		{`request_uri contains "x" or args contains "y"`, 1, 26},
+		{`request_uri contains "x" args`, 1, 26},
+		{"request_uri contains \"x\" and\n  response_status ge \"abc\"", 2, 22},
+		{`lower(request_uri) contains "x" and urldecode(args) contains "y"`, 1, 37},
+		{`lower(request_headers["a"]) contains "x" and request_uri contains "Y"`, 1, 46},
+		{`request_uri contains "x" and lower(args) contains "y"`, 1, 30},
+		{`upper(request_uri) contains "x"`, 1, 1},
+		{`lower(request_uri contains "x"`, 1, 19},
+		{`remote_addr in ["1.2.3.4" "5.6.7.8"]`, 1, 27},
+		{`remote_addr in []`, 1, 17},
+		{`request_uri contains "x" $`, 1, 26},
+	}
+
+	for _, tc := range dataProvider {
+		_, err := ParseExpression(tc.Expression, testNames)
+
+		var expressionErr *ExpressionError
+		if !errors.As(err, &expressionErr) {
+			t.Errorf("expected an expression error for %s, got %v", tc.Expression, err)
+			continue
+		}
+
+		if expressionErr.Line != tc.Line || expressionErr.Column != tc.Column {
+			t.Errorf("expected error at %d:%d for %s, got %s", tc.Line, tc.Column, tc.Expression, err)
+		}
+	}
+}
+
+func TestFormatExpression(t *testing.T) {
+	rule := api.PullzoneWafRuleConfiguration{
+		VariableTypes:       map[string]string{"REQUEST_HEADERS": "User-Agent"},
+		OperatorType:        OperatorRx,
+		Value:               "curl|\"wget\"",
+		TransformationTypes: []int64{TransformationUrlDecode, TransformationLowercase},
+		ChainedRules: []api.PullzoneWafRuleChainedRule{
+			{VariableTypes: map[string]string{"REMOTE_ADDR": ""}, OperatorType: OperatorWithin, Value: "1.2.3.4 5.6.7.8"},
+			{VariableTypes: map[string]string{"RESPONSE_STATUS": ""}, OperatorType: OperatorEq, Value: "403"},
+			{VariableTypes: map[string]string{"ARGS": ""}, OperatorType: OperatorDetectSqli, Value: ""},
+		},
+	}
+
+	expected := `lowercase(urldecode(request_headers["User-Agent"])) matches "curl|\"wget\"" and ` +
+		`lowercase(urldecode(remote_addr)) in ["1.2.3.4", "5.6.7.8"] and ` +
+		`lowercase(urldecode(response_status)) eq 403 and ` +
+		`lowercase(urldecode(args)) detectsqli`
+
+	result, err := FormatExpression(rule, testNames)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result != expected {
+		t.Errorf("expected %s, got %s", expected, result)
+	}
+
+	parsed, err := ParseExpression(result, testNames)
+	if err != nil {
+		t.Fatalf("unexpected error parsing the formatted expression: %s", err)
+	}
+
+	if !reflect.DeepEqual(parsed, rule) {
+		t.Errorf("expected the formatted expression to parse back into the rule, got %+v", parsed)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogEntry is a request read from a request log.
+type LogEntry struct {
+	// Line is the line of the request in the log, starting at 1.
+	Line      int
+	Timestamp time.Time
+	RemoteIp  string
+	// Path is the request path and query, or an absolute URL.
+	Path    string
+	Method  string
+	Headers map[string]string
+}
+
+type logEntryJson struct {
+	Timestamp json.RawMessage   `json:"timestamp"`
+	Ip        string            `json:"ip"`
+	Path      string            `json:"path"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+}
+
+// ParseLog reads a request log, either as JSON lines or as CSV with a header row.
+//
+// Each JSON line is an object with the "timestamp", "ip", "path", "method" and "headers" attributes.
+// CSV logs require the "timestamp", "ip" and "path" columns. The "headers" column holds a JSON object,
+// and any other column is read as a request header, i.e. a "user-agent" column.
+// Timestamps are in RFC 3339 format or Unix seconds.
+func ParseLog(content string) ([]LogEntry, error) {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return []LogEntry{}, nil
+	}
+
+	if strings.HasPrefix(trimmed, "{") {
+		return parseLogJsonLines(content)
+	}
+
+	return parseLogCsv(content)
+}
+
+func parseLogJsonLines(content string) ([]LogEntry, error) {
+	entries := []LogEntry{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var data logEntryJson
+		if err := json.Unmarshal([]byte(text), &data); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		var timestamp string
+		if err := json.Unmarshal(data.Timestamp, &timestamp); err != nil {
+			// unix timestamps can be numbers
+			timestamp = string(data.Timestamp)
+		}
+
+		entry, err := newLogEntry(line, timestamp, data.Ip, data.Path, data.Method, data.Headers)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func parseLogCsv(content string) ([]LogEntry, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("line 1: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"timestamp", "ip", "path"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("line 1: missing column \"%s\"", name)
+		}
+	}
+
+	entries := []LogEntry{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		headers := map[string]string{}
+
+		for name, i := range columns {
+			switch name {
+			case "timestamp", "ip", "path", "method":
+				continue
+			case "headers":
+				if record[i] == "" {
+					continue
+				}
+
+				if err := json.Unmarshal([]byte(record[i]), &headers); err != nil {
+					return nil, fmt.Errorf("line %d: headers must be a JSON object: %w", line, err)
+				}
+			default:
+				if record[i] != "" {
+					headers[strings.TrimSpace(header[i])] = record[i]
+				}
+			}
+		}
+
+		method := ""
+		if i, ok := columns["method"]; ok {
+			method = record[i]
+		}
+
+		entry, err := newLogEntry(line, record[columns["timestamp"]], record[columns["ip"]], record[columns["path"]], method, headers)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func newLogEntry(line int, timestamp string, ip string, path string, method string, headers map[string]string) (LogEntry, error) {
+	t, err := parseLogTimestamp(timestamp)
+	if err != nil {
+		return LogEntry{}, err
+	}
+
+	if ip == "" {
+		return LogEntry{}, errors.New("missing ip")
+	}
+
+	if path == "" {
+		return LogEntry{}, errors.New("missing path")
+	}
+
+	if headers == nil {
+		headers = map[string]string{}
+	}
+
+	return LogEntry{
+		Line:      line,
+		Timestamp: t,
+		RemoteIp:  ip,
+		Path:      path,
+		Method:    method,
+		Headers:   headers,
+	}, nil
+}
+
+func parseLogTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, errors.New("missing timestamp")
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return time.Time{}, fmt.Errorf("invalid timestamp \"%s\", expected RFC 3339 or Unix seconds", value)
+	}
+
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*float64(time.Second))).UTC(), nil
+}
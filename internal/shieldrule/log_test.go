@@ -0,0 +1,67 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLog(t *testing.T) {
+	type testCase struct {
+		Content string
+		Entries int
+		Error   string
+	}
+
+	testCases := []testCase{
+		{"", 0, ""},
+		{"timestamp,ip,path\n2026-01-01T00:00:00Z,192.0.2.1,/login\n2026-01-01T00:00:01Z,192.0.2.2,/\n", 2, ""},
+		{"Timestamp, IP, Path, Method, User-Agent\n1767225600,192.0.2.1,/login,POST,curl/8.0\n", 1, ""},
+		{`{"timestamp": "2026-01-01T00:00:00Z", "ip": "192.0.2.1", "path": "/login"}` + "\n\n" + `{"timestamp": 1767225600.5, "ip": "192.0.2.1", "path": "/login", "extra": true}`, 2, ""},
+		{"timestamp,path\n2026-01-01T00:00:00Z,/login\n", 0, "line 1: missing column \"ip\""},
+		{"timestamp,ip,path\nyesterday,192.0.2.1,/login\n", 0, "line 2: invalid timestamp"},
+		{"timestamp,ip,path,headers\n1767225600,192.0.2.1,/login,nope\n", 0, "line 2: headers must be a JSON object"},
+		{`{"timestamp": "2026-01-01T00:00:00Z", "path": "/login"}`, 0, "line 1: missing ip"},
+		{`{"timestamp": "2026-01-01T00:00:00Z", "ip": "192.0.2.1", "path": "/"}` + "\n{", 0, "line 2:"},
+	}
+
+	for _, tc := range testCases {
+		entries, err := ParseLog(tc.Content)
+		if tc.Error != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.Error) {
+				t.Errorf("%q: expected error containing %q, got %v", tc.Content, tc.Error, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.Content, err)
+			continue
+		}
+
+		if len(entries) != tc.Entries {
+			t.Errorf("%q: expected %d entries, got %d", tc.Content, tc.Entries, len(entries))
+		}
+	}
+}
+
+func TestParseLogFields(t *testing.T) {
+	entries, err := ParseLog("timestamp,ip,path,method,user-agent,headers\n1767225600.25,192.0.2.1,/login?next=/,POST,curl/8.0,\"{\"\"Host\"\": \"\"example.com\"\"}\"\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := entries[0]
+	expectedTimestamp := time.Date(2026, 1, 1, 0, 0, 0, int(250*time.Millisecond), time.UTC)
+
+	if entry.Line != 2 || !entry.Timestamp.Equal(expectedTimestamp) || entry.RemoteIp != "192.0.2.1" || entry.Path != "/login?next=/" || entry.Method != "POST" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	if entry.Headers["user-agent"] != "curl/8.0" || entry.Headers["Host"] != "example.com" {
+		t.Errorf("unexpected headers: %v", entry.Headers)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RatelimitResult is the outcome of replaying a request log against a rate limit rule.
+type RatelimitResult struct {
+	Requests        int
+	MatchedRequests int
+	// Blocked lists the blocked requests, in chronological order.
+	Blocked []BlockedRequest
+	// Clients lists the clients blocked at least once, sorted by IP.
+	Clients []RatelimitClient
+}
+
+type BlockedRequest struct {
+	LogEntry
+	BlockedUntil time.Time
+}
+
+type RatelimitClient struct {
+	RemoteIp        string
+	MatchedRequests int
+	BlockedRequests int
+	Blocks          []RatelimitBlock
+	// BlockedFor is the total time the client is blocked.
+	BlockedFor time.Duration
+}
+
+type RatelimitBlock struct {
+	From  time.Time
+	Until time.Time
+}
+
+type ratelimitClientState struct {
+	client       *RatelimitClient
+	window       []time.Time
+	blockedUntil time.Time
+}
+
+// SimulateRatelimit replays the log entries against the rate limit rule.
+//
+// Requests matching the rule are counted per client IP within a sliding window of rule.Timeframe seconds.
+// The request exceeding rule.RequestCount blocks the client for rule.BlockTime seconds, and the matching
+// requests of the client are blocked until then. Once a block expires, the counter starts over.
+func SimulateRatelimit(rule api.PullzoneWafRuleConfiguration, entries []LogEntry) (RatelimitResult, error) {
+	result := RatelimitResult{
+		Blocked: []BlockedRequest{},
+		Clients: []RatelimitClient{},
+	}
+
+	if rule.RequestCount < 0 {
+		return result, errors.New("the request count must not be negative")
+	}
+
+	if rule.Timeframe <= 0 {
+		return result, errors.New("the timeframe must be positive")
+	}
+
+	if rule.BlockTime <= 0 {
+		return result, errors.New("the block time must be positive")
+	}
+
+	timeframe := time.Duration(rule.Timeframe) * time.Second
+	blockTime := time.Duration(rule.BlockTime) * time.Second
+
+	sorted := make([]LogEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	clients := map[string]*ratelimitClientState{}
+
+	for _, entry := range sorted {
+		result.Requests++
+
+		matched, err := ratelimitMatch(rule, entry)
+		if err != nil {
+			return result, fmt.Errorf("line %d: %w", entry.Line, err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		result.MatchedRequests++
+
+		state, ok := clients[entry.RemoteIp]
+		if !ok {
+			state = &ratelimitClientState{client: &RatelimitClient{RemoteIp: entry.RemoteIp}}
+			clients[entry.RemoteIp] = state
+		}
+
+		state.client.MatchedRequests++
+
+		if entry.Timestamp.Before(state.blockedUntil) {
+			state.client.BlockedRequests++
+			result.Blocked = append(result.Blocked, BlockedRequest{LogEntry: entry, BlockedUntil: state.blockedUntil})
+			continue
+		}
+
+		// drop the requests outside the window
+		windowStart := entry.Timestamp.Add(-timeframe)
+		window := state.window[:0]
+		for _, t := range state.window {
+			if t.After(windowStart) {
+				window = append(window, t)
+			}
+		}
+
+		state.window = append(window, entry.Timestamp)
+		if int64(len(state.window)) <= rule.RequestCount {
+			continue
+		}
+
+		state.blockedUntil = entry.Timestamp.Add(blockTime)
+		state.window = nil
+		state.client.BlockedRequests++
+		state.client.BlockedFor += blockTime
+		state.client.Blocks = append(state.client.Blocks, RatelimitBlock{From: entry.Timestamp, Until: state.blockedUntil})
+		result.Blocked = append(result.Blocked, BlockedRequest{LogEntry: entry, BlockedUntil: state.blockedUntil})
+	}
+
+	for _, state := range clients {
+		if len(state.client.Blocks) > 0 {
+			result.Clients = append(result.Clients, *state.client)
+		}
+	}
+
+	sort.Slice(result.Clients, func(i, j int) bool {
+		return result.Clients[i].RemoteIp < result.Clients[j].RemoteIp
+	})
+
+	return result, nil
+}
+
+func ratelimitMatch(rule api.PullzoneWafRuleConfiguration, entry LogEntry) (bool, error) {
+	// rules without conditions apply to every request
+	if len(rule.VariableTypes) == 0 && len(rule.ChainedRules) == 0 {
+		return true, nil
+	}
+
+	requestUrl := entry.Path
+	if !strings.Contains(requestUrl, "://") {
+		host := "localhost"
+		for k, v := range entry.Headers {
+			if strings.EqualFold(k, "Host") && v != "" {
+				host = v
+			}
+		}
+
+		if !strings.HasPrefix(requestUrl, "/") {
+			requestUrl = "/" + requestUrl
+		}
+
+		requestUrl = "https://" + host + requestUrl
+	}
+
+	result, err := Evaluate(rule, Request{
+		Url:      requestUrl,
+		Method:   entry.Method,
+		Headers:  entry.Headers,
+		RemoteIp: entry.RemoteIp,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.Matched, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"github.com/bunnyway/terraform-provider-bunnynet/internal/api"
+	"testing"
+	"time"
+)
+
+func TestSimulateRatelimit(t *testing.T) {
+	rule := api.PullzoneWafRuleConfiguration{
+		VariableTypes: map[string]string{"REQUEST_URI": ""},
+		OperatorType:  OperatorBeginsWith,
+		Value:         "/login",
+		RequestCount:  2,
+		Timeframe:     10,
+		BlockTime:     30,
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(line int, seconds int, ip string, path string) LogEntry {
+		return LogEntry{Line: line, Timestamp: start.Add(time.Duration(seconds) * time.Second), RemoteIp: ip, Path: path}
+	}
+
+	entries := []LogEntry{
+		entry(1, 0, "192.0.2.1", "/login"),
+		entry(2, 1, "192.0.2.1", "/login"),
+		entry(4, 3, "192.0.2.1", "/login"), // exceeds the limit, blocked until 33s
+		entry(3, 2, "192.0.2.1", "/"),      // does not match, out of order
+		entry(5, 20, "192.0.2.1", "/login"),
+		entry(6, 40, "192.0.2.1", "/login"), // block expired
+		entry(7, 0, "192.0.2.2", "/login"),
+		entry(8, 15, "192.0.2.2", "/login"),
+		entry(9, 30, "192.0.2.2", "/login"), // outside the window of the previous requests
+	}
+
+	result, err := SimulateRatelimit(rule, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Requests != 9 || result.MatchedRequests != 8 {
+		t.Errorf("expected 9 requests and 8 matched, got %d and %d", result.Requests, result.MatchedRequests)
+	}
+
+	if len(result.Blocked) != 2 || result.Blocked[0].Line != 4 || result.Blocked[1].Line != 5 {
+		t.Fatalf("expected lines 4 and 5 to be blocked, got %+v", result.Blocked)
+	}
+
+	if !result.Blocked[1].BlockedUntil.Equal(start.Add(33 * time.Second)) {
+		t.Errorf("expected the block to last until 33s, got %s", result.Blocked[1].BlockedUntil)
+	}
+
+	if len(result.Clients) != 1 {
+		t.Fatalf("expected 1 blocked client, got %+v", result.Clients)
+	}
+
+	client := result.Clients[0]
+	if client.RemoteIp != "192.0.2.1" || client.MatchedRequests != 5 || client.BlockedRequests != 2 || client.BlockedFor != 30*time.Second || len(client.Blocks) != 1 {
+		t.Errorf("unexpected client: %+v", client)
+	}
+}
+
+func TestSimulateRatelimitErrors(t *testing.T) {
+	type testCase struct {
+		Rule    api.PullzoneWafRuleConfiguration
+		Entries []LogEntry
+	}
+
+	testCases := []testCase{
+		{api.PullzoneWafRuleConfiguration{RequestCount: -1, Timeframe: 10, BlockTime: 30}, nil},
+		{api.PullzoneWafRuleConfiguration{RequestCount: 1, Timeframe: 0, BlockTime: 30}, nil},
+		{api.PullzoneWafRuleConfiguration{RequestCount: 1, Timeframe: 10, BlockTime: 0}, nil},
+		{
+			api.PullzoneWafRuleConfiguration{VariableTypes: map[string]string{"REQUEST_URI": ""}, OperatorType: OperatorRx, Value: "^(", RequestCount: 1, Timeframe: 10, BlockTime: 30},
+			[]LogEntry{{Line: 1, RemoteIp: "192.0.2.1", Path: "/"}},
+		},
+	}
+
+	for i, tc := range testCases {
+		if _, err := SimulateRatelimit(tc.Rule, tc.Entries); err == nil {
+			t.Errorf("expected test case %d to fail", i)
+		}
+	}
+}
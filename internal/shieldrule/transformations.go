@@ -0,0 +1,370 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	TransformationCmdLine            int64 = 1
+	TransformationCompressWhitespace int64 = 2
+	TransformationCssDecode          int64 = 3
+	TransformationHexEncode          int64 = 4
+	TransformationHtmlEntityDecode   int64 = 5
+	TransformationJsDecode           int64 = 6
+	TransformationLength             int64 = 7
+	TransformationLowercase          int64 = 8
+	TransformationMd5                int64 = 9
+	TransformationNormalizePath      int64 = 10
+	TransformationNormalisePath      int64 = 11
+	TransformationNormalizePathWin   int64 = 12
+	TransformationNormalisePathWin   int64 = 13
+	TransformationRemoveComments     int64 = 14
+	TransformationRemoveNulls        int64 = 15
+	TransformationRemoveWhitespace   int64 = 16
+	TransformationReplaceComments    int64 = 17
+	TransformationSha1               int64 = 18
+	TransformationUrlDecode          int64 = 19
+	TransformationUrlDecodeUni       int64 = 20
+	TransformationUtf8ToUnicode      int64 = 21
+)
+
+// Transform applies the transformations, in order, to a value.
+func Transform(transformations []int64, value string) (string, error) {
+	for _, t := range transformations {
+		switch t {
+		case TransformationCmdLine:
+			value = cmdLine(value)
+		case TransformationCompressWhitespace:
+			value = compressWhitespace(value)
+		case TransformationCssDecode:
+			value = cssDecode(value)
+		case TransformationHexEncode:
+			value = hex.EncodeToString([]byte(value))
+		case TransformationHtmlEntityDecode:
+			value = html.UnescapeString(value)
+		case TransformationJsDecode:
+			value = jsDecode(value)
+		case TransformationLength:
+			value = strconv.Itoa(len(value))
+		case TransformationLowercase:
+			value = strings.ToLower(value)
+		case TransformationMd5:
+			sum := md5.Sum([]byte(value))
+			value = string(sum[:])
+		case TransformationNormalizePath, TransformationNormalisePath:
+			value = normalizePath(value)
+		case TransformationNormalizePathWin, TransformationNormalisePathWin:
+			value = normalizePath(strings.ReplaceAll(value, "\\", "/"))
+		case TransformationRemoveComments:
+			value = removeComments(value, "")
+		case TransformationRemoveNulls:
+			value = strings.ReplaceAll(value, "\x00", "")
+		case TransformationRemoveWhitespace:
+			value = strings.Join(strings.FieldsFunc(value, isWhitespace), "")
+		case TransformationReplaceComments:
+			value = replaceComments(value)
+		case TransformationSha1:
+			sum := sha1.Sum([]byte(value))
+			value = string(sum[:])
+		case TransformationUrlDecode:
+			value = urlDecode(value, false)
+		case TransformationUrlDecodeUni:
+			value = urlDecode(value, true)
+		case TransformationUtf8ToUnicode:
+			value = utf8ToUnicode(value)
+		default:
+			return "", fmt.Errorf("transformation %d cannot be simulated", t)
+		}
+	}
+
+	return value, nil
+}
+
+func isWhitespace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\r', '\f', '\v', '\u00a0':
+		return true
+	}
+
+	return false
+}
+
+// cmdLine follows the "cmdLine" transformation, used to detect obfuscated shell commands.
+func cmdLine(value string) string {
+	var sb strings.Builder
+	space := false
+
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == '"' || r == '\'' || r == '^':
+			continue
+		case r == ',' || r == ';' || isWhitespace(r):
+			space = true
+			continue
+		case r == '/' || r == '(':
+			// spaces before a slash or an open parenthesis are removed
+			space = false
+		}
+
+		if space {
+			sb.WriteRune(' ')
+			space = false
+		}
+
+		sb.WriteString(strings.ToLower(string(r)))
+	}
+
+	return sb.String()
+}
+
+func compressWhitespace(value string) string {
+	var sb strings.Builder
+	space := false
+
+	for _, r := range value {
+		if isWhitespace(r) {
+			if !space {
+				sb.WriteRune(' ')
+			}
+			space = true
+			continue
+		}
+
+		space = false
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+func cssDecode(value string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 >= len(value) {
+			sb.WriteByte(value[i])
+			continue
+		}
+
+		j := i + 1
+		for j < len(value) && j-i <= 6 && isHex(value[j]) {
+			j++
+		}
+
+		if j == i+1 {
+			// not a hex escape, the backslash is removed
+			if value[j] != '\n' {
+				sb.WriteByte(value[j])
+			}
+			i = j
+			continue
+		}
+
+		n, _ := strconv.ParseUint(value[i+1:j], 16, 32)
+		if n > utf8.MaxRune {
+			n = utf8.RuneError
+		}
+		sb.WriteRune(rune(n))
+
+		// a single whitespace after the escape is part of it
+		if j < len(value) && value[j] == ' ' {
+			j++
+		}
+		i = j - 1
+	}
+
+	return sb.String()
+}
+
+func jsDecode(value string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 >= len(value) {
+			sb.WriteByte(value[i])
+			continue
+		}
+
+		c := value[i+1]
+		switch {
+		case c == 'u' && i+5 < len(value) && isHexString(value[i+2:i+6]):
+			n, _ := strconv.ParseUint(value[i+2:i+6], 16, 32)
+			sb.WriteRune(rune(n))
+			i += 5
+		case c == 'x' && i+3 < len(value) && isHexString(value[i+2:i+4]):
+			n, _ := strconv.ParseUint(value[i+2:i+4], 16, 8)
+			sb.WriteByte(byte(n))
+			i += 3
+		case c >= '0' && c <= '7':
+			j := i + 1
+			for j < len(value) && j-i <= 3 && value[j] >= '0' && value[j] <= '7' {
+				j++
+			}
+			n, _ := strconv.ParseUint(value[i+1:j], 8, 16)
+			sb.WriteByte(byte(n))
+			i = j - 1
+		default:
+			switch c {
+			case 'a':
+				sb.WriteByte('\a')
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'v':
+				sb.WriteByte('\v')
+			default:
+				sb.WriteByte(c)
+			}
+			i++
+		}
+	}
+
+	return sb.String()
+}
+
+func normalizePath(value string) string {
+	if value == "" {
+		return value
+	}
+
+	absolute := strings.HasPrefix(value, "/")
+	trailing := strings.HasSuffix(value, "/") || strings.HasSuffix(value, "/.") || strings.HasSuffix(value, "/..")
+
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(value, "/") {
+		switch segment {
+		case "", ".":
+			continue
+		case "..":
+			if len(segments) > 0 && segments[len(segments)-1] != ".." {
+				segments = segments[:len(segments)-1]
+			} else if !absolute {
+				segments = append(segments, segment)
+			}
+		default:
+			segments = append(segments, segment)
+		}
+	}
+
+	result := strings.Join(segments, "/")
+	if absolute {
+		result = "/" + result
+	}
+
+	if trailing && !strings.HasSuffix(result, "/") {
+		result += "/"
+	}
+
+	return result
+}
+
+// removeComments strips "/* */" and "<!-- -->" comments, and anything after "--" or "#".
+// If replacement is not empty, it is written in place of each C-style comment.
+func removeComments(value string, replacement string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		rest := value[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			sb.WriteString(replacement)
+			if end == -1 {
+				return sb.String()
+			}
+			i += end + 3
+		case replacement == "" && strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end == -1 {
+				return sb.String()
+			}
+			i += end + 6
+		case replacement == "" && (strings.HasPrefix(rest, "--") || rest[0] == '#'):
+			return sb.String()
+		default:
+			sb.WriteByte(value[i])
+		}
+	}
+
+	return sb.String()
+}
+
+func replaceComments(value string) string {
+	return removeComments(value, " ")
+}
+
+func urlDecode(value string, unicode bool) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case c == '+':
+			sb.WriteByte(' ')
+		case c == '%' && unicode && i+5 < len(value) && (value[i+1] == 'u' || value[i+1] == 'U') && isHexString(value[i+2:i+6]):
+			n, _ := strconv.ParseUint(value[i+2:i+6], 16, 32)
+			// full-width ASCII is mapped back to ASCII
+			if n >= 0xff01 && n <= 0xff5e {
+				n -= 0xfee0
+			}
+			sb.WriteRune(rune(n))
+			i += 5
+		case c == '%' && i+2 < len(value) && isHexString(value[i+1:i+3]):
+			n, _ := strconv.ParseUint(value[i+1:i+3], 16, 8)
+			sb.WriteByte(byte(n))
+			i += 2
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String()
+}
+
+func utf8ToUnicode(value string) string {
+	var sb strings.Builder
+
+	for _, r := range value {
+		if r < utf8.RuneSelf {
+			sb.WriteRune(r)
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("%%u%04x", r))
+	}
+
+	return sb.String()
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isHexString(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if !isHex(value[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,70 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package shieldrule
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	type testCase struct {
+		Transformations []int64
+		Value           string
+		Expected        string
+	}
+
+	dataProvider := []testCase{
+		{[]int64{}, "Unchanged", "Unchanged"},
+		{[]int64{TransformationLowercase}, "/ADMIN", "/admin"},
+		{[]int64{TransformationUrlDecode}, "%2Fadmin%3f+x%zz", "/admin? x%zz"},
+		{[]int64{TransformationUrlDecodeUni}, "%u0041%uff41%41", "Aa" + "A"},
+		{[]int64{TransformationUrlDecode, TransformationLowercase}, "%2FADMIN", "/admin"},
+		{[]int64{TransformationCompressWhitespace}, "a \t\n b", "a b"},
+		{[]int64{TransformationRemoveWhitespace}, "a \t\n b", "ab"},
+		{[]int64{TransformationRemoveNulls}, "a\x00b", "ab"},
+		{[]int64{TransformationCmdLine}, "C^:\\WINDOWS\\system32 ,  /c \"DIR\"", "c:windowssystem32/c dir"},
+		{[]int64{TransformationNormalizePath}, "/a/b/../c/./d//e/", "/a/c/d/e/"},
+		{[]int64{TransformationNormalizePath}, "/../../etc/passwd", "/etc/passwd"},
+		{[]int64{TransformationNormalizePathWin}, "\\a\\..\\b\\c", "/b/c"},
+		{[]int64{TransformationRemoveComments}, "SELECT/* x */1 -- comment", "SELECT1 "},
+		{[]int64{TransformationRemoveComments}, "a<!-- b -->c#d", "ac"},
+		{[]int64{TransformationReplaceComments}, "UNION/**/SELECT", "UNION SELECT"},
+		{[]int64{TransformationReplaceComments}, "UNION/*SELECT", "UNION "},
+		{[]int64{TransformationHtmlEntityDecode}, "&lt;script&gt;&#x61;", "<script>a"},
+		{[]int64{TransformationJsDecode}, "\\x3cscript\\u003e\\n\\101", "<script>\nA"},
+		{[]int64{TransformationCssDecode}, "\\6a \\61 vascript\\:", "javascript:"},
+		{[]int64{TransformationLength}, "abcd", "4"},
+		{[]int64{TransformationHexEncode}, "ab", "6162"},
+		{[]int64{TransformationUtf8ToUnicode}, "aé", "a%u00e9"},
+	}
+
+	for _, tc := range dataProvider {
+		result, err := Transform(tc.Transformations, tc.Value)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", tc.Value, err)
+			continue
+		}
+
+		if result != tc.Expected {
+			t.Errorf("expected %v(%q) to be %q, got %q", tc.Transformations, tc.Value, tc.Expected, result)
+		}
+	}
+}
+
+func TestTransformHashes(t *testing.T) {
+	md5Value, _ := Transform([]int64{TransformationMd5, TransformationHexEncode}, "abc")
+	if md5Value != "900150983cd24fb0d6963f7d28e17f72" {
+		t.Errorf("unexpected md5: %s", md5Value)
+	}
+
+	sha1Value, _ := Transform([]int64{TransformationSha1}, "abc")
+	if hex.EncodeToString([]byte(sha1Value)) != "a9993e364706816aba3e25717850c26c9cd0d89d" {
+		t.Errorf("unexpected sha1: %x", sha1Value)
+	}
+
+	if _, err := Transform([]int64{99}, "abc"); err == nil {
+		t.Error("expected unknown transformation to fail")
+	}
+}
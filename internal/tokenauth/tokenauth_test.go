@@ -0,0 +1,103 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package tokenauth
+
+import (
+	"testing"
+)
+
+const testKey = "229248f0-f007-4bf9-ba1f-bbf1b4ad9d40"
+const testExpires = 1735689600
+
+// The expected URLs were produced with the reference sign_bcdn_url implementation of bunny.net token authentication.
+func TestSignUrl(t *testing.T) {
+	type testCase struct {
+		Url      string
+		Options  Options
+		Expected string
+	}
+
+	dataProvider := []testCase{
+		{
+			Url:      "https://cdn.example.com/videos/video.mp4",
+			Options:  Options{},
+			Expected: "https://cdn.example.com/videos/video.mp4?token=hDSxEMBkZzBiX3So64bE9hkF-BAkiXHubeEEZ0qyvA0&expires=1735689600",
+		},
+		{
+			Url:      "https://cdn.example.com/videos/video.mp4",
+			Options:  Options{RemoteIp: "192.0.2.1"},
+			Expected: "https://cdn.example.com/videos/video.mp4?token=WMCXrFNuDj3lDEBKkS0QtfOHMOCX5d9gouZTzn35JW8&expires=1735689600",
+		},
+		{
+			Url:      "https://cdn.example.com/videos/playlist.m3u8",
+			Options:  Options{TokenPath: "/videos/", CountriesAllowed: []string{"SI", "HR"}, Directory: true},
+			Expected: "https://cdn.example.com/bcdn_token=3Ec0epkRlPQfe_EKcId17ofexmGER54i6TI2r_OyL2E&token_countries=SI%2CHR&token_path=%2Fvideos%2F&expires=1735689600/videos/playlist.m3u8",
+		},
+		{
+			Url:      "https://cdn.example.com/videos/video.mp4?width=100",
+			Options:  Options{CountriesBlocked: []string{"US"}},
+			Expected: "https://cdn.example.com/videos/video.mp4?token=Viq21kHHa7BwweIa-dm11bftyI1g_d5PasiCZnXOwjc&token_countries_blocked=US&width=100&expires=1735689600",
+		},
+		{
+			// query values are encoded like PHP's urlencode
+			Url:      "https://cdn.example.com/videos/video.mp4?title=my+video&tag=a~b",
+			Options:  Options{},
+			Expected: "https://cdn.example.com/videos/video.mp4?token=Gs8ncwWyA_ZfVa60DVeWG5ehTbDn9DtW076HBdYjaF4&tag=a%7Eb&title=my+video&expires=1735689600",
+		},
+		{
+			Url:      "https://cdn.example.com/videos/video.mp4",
+			Options:  Options{Basic: true},
+			Expected: "https://cdn.example.com/videos/video.mp4?token=NG_qvQCPxbPxx9YiJjw6nA&expires=1735689600",
+		},
+	}
+
+	for _, tc := range dataProvider {
+		result, err := SignUrl(tc.Url, testKey, testExpires, tc.Options)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", tc.Url, err)
+			continue
+		}
+
+		if result != tc.Expected {
+			t.Errorf("expected %s, got %s", tc.Expected, result)
+		}
+	}
+}
+
+func TestSignUrlErrors(t *testing.T) {
+	type testCase struct {
+		Url     string
+		Key     string
+		Options Options
+	}
+
+	dataProvider := []testCase{
+		{"https://cdn.example.com/video.mp4", "", Options{}},
+		{"/video.mp4", testKey, Options{}},
+		{"https://cdn.example.com/video.mp4", testKey, Options{TokenPath: "/videos/"}},
+		{"https://cdn.example.com/video.mp4", testKey, Options{Basic: true, CountriesAllowed: []string{"SI"}}},
+	}
+
+	for _, tc := range dataProvider {
+		if _, err := SignUrl(tc.Url, tc.Key, testExpires, tc.Options); err == nil {
+			t.Errorf("expected error for %s with %+v", tc.Url, tc.Options)
+		}
+	}
+}
+
+func TestSignStreamEmbed(t *testing.T) {
+	result, err := SignStreamEmbed(12345, "8f2f6b4d-8c7e-4d0b-9d6b-3b1f0e2a9c11", testKey, testExpires)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "https://iframe.mediadelivery.net/embed/12345/8f2f6b4d-8c7e-4d0b-9d6b-3b1f0e2a9c11?token=b0c312ee08fdf3dd6098e650a67ca7fce1e4f9675b1962feb0c00102832d39c8&expires=1735689600"
+	if result != expected {
+		t.Errorf("expected %s, got %s", expected, result)
+	}
+
+	if _, err := SignStreamEmbed(12345, "", testKey, testExpires); err == nil {
+		t.Error("expected error for empty video ID")
+	}
+}
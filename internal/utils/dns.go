@@ -0,0 +1,22 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"context"
+	"net"
+)
+
+// LookupTXTOnNameserver queries a specific nameserver for TXT records, bypassing the system resolver and its cache.
+func LookupTXTOnNameserver(ctx context.Context, nameserver string, name string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			d := net.Dialer{}
+			return d.DialContext(ctx, network, net.JoinHostPort(nameserver, "53"))
+		},
+	}
+
+	return resolver.LookupTXT(ctx, name)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TxtCharacterStringMaxLength is the maximum length, in bytes, of a single TXT character-string (RFC 1035, section 3.3).
+const TxtCharacterStringMaxLength = 255
+
+// NormalizeTxtValue returns the content of a TXT value, to compare values that differ only in formatting.
+// Values made of one or more quoted character-strings (i.e. `"v=DKIM1; p=" "MIGf..."`) are unescaped and joined,
+// any other value has its whitespace trimmed and collapsed.
+func NormalizeTxtValue(value string) string {
+	if chunks, ok := parseTxtCharacterStrings(value); ok {
+		return strings.Join(chunks, "")
+	}
+
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// UnquoteTxtValue returns the content of a TXT value made of quoted character-strings, or the value as written otherwise.
+func UnquoteTxtValue(value string) string {
+	if chunks, ok := parseTxtCharacterStrings(value); ok {
+		return strings.Join(chunks, "")
+	}
+
+	return value
+}
+
+// SplitTxtValue splits the content of a TXT value into character-strings of up to 255 bytes, without splitting UTF-8 characters.
+func SplitTxtValue(value string) []string {
+	if len(value) == 0 {
+		return []string{""}
+	}
+
+	var chunks []string
+	for len(value) > TxtCharacterStringMaxLength {
+		i := TxtCharacterStringMaxLength
+		for i > 0 && !utf8.RuneStart(value[i]) {
+			i--
+		}
+
+		// not valid UTF-8, split at the byte limit
+		if i == 0 {
+			i = TxtCharacterStringMaxLength
+		}
+
+		chunks = append(chunks, value[:i])
+		value = value[i:]
+	}
+
+	return append(chunks, value)
+}
+
+// FormatTxtValue returns the content of a TXT value as quoted, escaped character-strings of up to 255 bytes.
+func FormatTxtValue(value string) string {
+	chunks := SplitTxtValue(value)
+	for i, chunk := range chunks {
+		chunk = strings.ReplaceAll(chunk, `\`, `\\`)
+		chunk = strings.ReplaceAll(chunk, `"`, `\"`)
+		chunks[i] = `"` + chunk + `"`
+	}
+
+	return strings.Join(chunks, " ")
+}
+
+// parseTxtCharacterStrings parses a sequence of quoted character-strings, as found in zone files.
+// It returns false if the value is not exclusively made of quoted character-strings.
+func parseTxtCharacterStrings(value string) ([]string, bool) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, `"`) {
+		return nil, false
+	}
+
+	var chunks []string
+	i := 0
+	for i < len(value) {
+		switch value[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+			continue
+		case '"':
+		default:
+			return nil, false
+		}
+
+		i++
+		var chunk strings.Builder
+		closed := false
+		for i < len(value) {
+			c := value[i]
+			if c == '"' {
+				closed = true
+				i++
+				break
+			}
+
+			if c == '\\' && i+1 < len(value) {
+				// \DDD is a decimal byte escape, anything else escapes the following character
+				if i+3 < len(value) && isDigit(value[i+1]) && isDigit(value[i+2]) && isDigit(value[i+3]) {
+					b := int(value[i+1]-'0')*100 + int(value[i+2]-'0')*10 + int(value[i+3]-'0')
+					if b > 255 {
+						return nil, false
+					}
+					chunk.WriteByte(byte(b))
+					i += 4
+					continue
+				}
+
+				chunk.WriteByte(value[i+1])
+				i += 2
+				continue
+			}
+
+			chunk.WriteByte(c)
+			i++
+		}
+
+		if !closed {
+			return nil, false
+		}
+
+		chunks = append(chunks, chunk.String())
+	}
+
+	return chunks, true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
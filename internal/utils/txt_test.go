@@ -0,0 +1,122 @@
+// Copyright (c) BunnyWay d.o.o.
+// SPDX-License-Identifier: MPL-2.0
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTxtValue(t *testing.T) {
+	type testCase struct {
+		ExpectedResult bool
+		VOld           string
+		VNew           string
+	}
+
+	longValue := strings.Repeat("a", 300)
+
+	dataProvider := []testCase{
+		// plain values
+		{true, "v=spf1 -all", "v=spf1 -all"},
+		{false, "v=spf1 -all", "v=spf1 ~all"},
+
+		// whitespace
+		{true, "v=spf1 include:bunny.net ~all", "v=spf1  include:bunny.net\t~all\n"},
+		{true, "v=spf1 include:bunny.net ~all", `  "v=spf1 include:bunny.net ~all"  `},
+		{false, "v=spf1 include:bunny.net ~all", `"v=spf1  include:bunny.net ~all"`},
+
+		// quoted and chunked
+		{true, "v=DKIM1; k=rsa; p=MIGfMA0", `"v=DKIM1; k=rsa; " "p=MIGfMA0"`},
+		{true, `"v=DKIM1; k=rsa; p=MIGfMA0"`, `"v=DKIM1; k=rsa; " "p=MIGfMA0"`},
+		{true, longValue, `"` + longValue[:255] + `" "` + longValue[255:] + `"`},
+		{false, "v=DKIM1; k=rsa; p=MIGfMA0", `"v=DKIM1; k=rsa;" "p=MIGfMA0"`},
+
+		// escapes
+		{true, `say "hello"`, `"say \"hello\""`},
+		{true, `back\slash`, `"back\\slash"`},
+		{true, "\"tab\there\"", `"tab\009here"`},
+
+		// not a character-string sequence
+		{false, `abc`, `"abc`},
+		{true, `"abc" def`, `"abc"  def`},
+	}
+
+	for _, tc := range dataProvider {
+		result := NormalizeTxtValue(tc.VOld) == NormalizeTxtValue(tc.VNew)
+		if tc.ExpectedResult != result {
+			t.Errorf("expected %s == %s to be %t, got %t", tc.VOld, tc.VNew, tc.ExpectedResult, result)
+		}
+	}
+}
+
+func TestSplitTxtValue(t *testing.T) {
+	type testCase struct {
+		Value    string
+		Expected []string
+	}
+
+	dataProvider := []testCase{
+		{"", []string{""}},
+		{strings.Repeat("a", 255), []string{strings.Repeat("a", 255)}},
+		{strings.Repeat("a", 256), []string{strings.Repeat("a", 255), "a"}},
+		// "é" is 2 bytes long and would be cut in half at the byte limit
+		{strings.Repeat("a", 254) + "é", []string{strings.Repeat("a", 254), "é"}},
+		{strings.Repeat("é", 128), []string{strings.Repeat("é", 127), "é"}},
+	}
+
+	for _, tc := range dataProvider {
+		result := SplitTxtValue(tc.Value)
+		if !reflect.DeepEqual(result, tc.Expected) {
+			t.Errorf("expected %q, got %q", tc.Expected, result)
+		}
+	}
+}
+
+func TestFormatTxtValue(t *testing.T) {
+	type testCase struct {
+		Value    string
+		Expected string
+	}
+
+	dataProvider := []testCase{
+		{"", `""`},
+		{"v=spf1 -all", `"v=spf1 -all"`},
+		{`say "hello"`, `"say \"hello\""`},
+		{strings.Repeat("a", 256), `"` + strings.Repeat("a", 255) + `" "a"`},
+	}
+
+	for _, tc := range dataProvider {
+		result := FormatTxtValue(tc.Value)
+		if result != tc.Expected {
+			t.Errorf("expected %s, got %s", tc.Expected, result)
+		}
+
+		if NormalizeTxtValue(result) != tc.Value {
+			t.Errorf("expected %s to round-trip, got %s", tc.Value, NormalizeTxtValue(result))
+		}
+	}
+}
+
+func TestUnquoteTxtValue(t *testing.T) {
+	type testCase struct {
+		Value    string
+		Expected string
+	}
+
+	dataProvider := []testCase{
+		{"v=spf1  include:bunny.net ~all", "v=spf1  include:bunny.net ~all"},
+		{`"v=DKIM1; " "p=abc"`, "v=DKIM1; p=abc"},
+		{`"say \"hello\""`, `say "hello"`},
+		{`"unterminated`, `"unterminated`},
+	}
+
+	for _, tc := range dataProvider {
+		result := UnquoteTxtValue(tc.Value)
+		if result != tc.Expected {
+			t.Errorf("expected %s, got %s", tc.Expected, result)
+		}
+	}
+}